@@ -0,0 +1,575 @@
+package cap
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cmeyer18/weather-common/v6/data_structures"
+	"github.com/cmeyer18/weather-common/v6/data_structures/geojson_v2"
+	"github.com/cmeyer18/weather-common/v6/generative/golang"
+)
+
+const (
+	// Namespace is the XML namespace of CAP 1.2 documents.
+	Namespace = "urn:oasis:names:tc:emergency:cap:1.2"
+	// DefaultLanguage is the language of info blocks that do not give one.
+	DefaultLanguage = "en-US"
+	// timeLayout is the CAP date time format, which always carries a numeric offset.
+	timeLayout = "2006-01-02T15:04:05-07:00"
+	// endsParameter is the parameter NWS alerts give the end of the event in, since CAP has no element for it.
+	endsParameter = "eventEndingTime"
+)
+
+type capAlert struct {
+	XMLName    xml.Name  `xml:"urn:oasis:names:tc:emergency:cap:1.2 alert"`
+	Identifier string    `xml:"identifier"`
+	Sender     string    `xml:"sender"`
+	Sent       string    `xml:"sent"`
+	Status     string    `xml:"status"`
+	MsgType    string    `xml:"msgType"`
+	Source     string    `xml:"source,omitempty"`
+	Scope      string    `xml:"scope"`
+	Code       []string  `xml:"code"`
+	Note       string    `xml:"note,omitempty"`
+	References string    `xml:"references,omitempty"`
+	Infos      []capInfo `xml:"info"`
+}
+
+type capInfo struct {
+	Language      string     `xml:"language,omitempty"`
+	Categories    []string   `xml:"category"`
+	Event         string     `xml:"event"`
+	ResponseTypes []string   `xml:"responseType"`
+	Urgency       string     `xml:"urgency"`
+	Severity      string     `xml:"severity"`
+	Certainty     string     `xml:"certainty"`
+	EventCodes    []capValue `xml:"eventCode"`
+	Effective     string     `xml:"effective,omitempty"`
+	Onset         string     `xml:"onset,omitempty"`
+	Expires       string     `xml:"expires,omitempty"`
+	SenderName    string     `xml:"senderName,omitempty"`
+	Headline      string     `xml:"headline,omitempty"`
+	Description   string     `xml:"description,omitempty"`
+	Instruction   string     `xml:"instruction,omitempty"`
+	Web           string     `xml:"web,omitempty"`
+	Contact       string     `xml:"contact,omitempty"`
+	Parameters    []capValue `xml:"parameter"`
+	Areas         []capArea  `xml:"area"`
+}
+
+type capValue struct {
+	ValueName string `xml:"valueName"`
+	Value     string `xml:"value"`
+}
+
+type capArea struct {
+	AreaDesc string     `xml:"areaDesc"`
+	Polygons []string   `xml:"polygon"`
+	Circles  []string   `xml:"circle"`
+	Geocodes []capValue `xml:"geocode"`
+}
+
+// Translation is an alert as told by one info block of a CAP message, in the language of the block.
+type Translation struct {
+	Language string
+	Alert    data_structures.AlertV2
+}
+
+// Decode reads every CAP alert of the document, which may be a single alert, an IPAWS feed of alerts or an ATOM feed
+// with alerts embedded in its entries. Each info block of an alert becomes its own translation, so a message sent in
+// English and Spanish returns two. The polygons and circles of all areas of a block are combined into the geometry of
+// the alert, with circles approximated by polygons.
+func Decode(r io.Reader) ([]Translation, error) {
+	decoder := xml.NewDecoder(r)
+
+	var translations []Translation
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+
+		start, ok := token.(xml.StartElement)
+		if !ok || start.Name.Space != Namespace || start.Name.Local != "alert" {
+			continue
+		}
+
+		var alert capAlert
+		err = decoder.DecodeElement(&alert, &start)
+		if err != nil {
+			return nil, err
+		}
+
+		decoded, err := decodeAlert(alert)
+		if err != nil {
+			return nil, err
+		}
+		translations = append(translations, decoded...)
+	}
+
+	return translations, nil
+}
+
+// DecodeAlert reads the first CAP alert of the document in the language, falling back to its first info block when
+// none is in the language. It returns nil when the document has no alert.
+func DecodeAlert(r io.Reader, language string) (*data_structures.AlertV2, error) {
+	translations, err := Decode(r)
+	if err != nil || len(translations) == 0 {
+		return nil, err
+	}
+
+	id := translations[0].Alert.ID
+	for _, translation := range translations {
+		if translation.Alert.ID == id && strings.EqualFold(translation.Language, language) {
+			return &translation.Alert, nil
+		}
+	}
+	return &translations[0].Alert, nil
+}
+
+// Encode writes the translations of one alert as a CAP message with an info block per translation. The message level
+// elements are taken from the first translation. References are written with the sender and sent time of the alert,
+// since AlertV2 only keeps the identifiers of the alerts it references. Polygons without their holes are the only
+// geometries CAP can carry, so holes and other geometries are left out.
+func Encode(w io.Writer, translations []Translation) error {
+	if len(translations) == 0 {
+		return errors.New("cannot encode CAP alert without translations")
+	}
+
+	first := translations[0].Alert
+	alert := capAlert{
+		Identifier: first.ID,
+		Sender:     first.Sender,
+		Sent:       formatTime(first.Sent),
+		Status:     string(first.Status),
+		MsgType:    string(first.MessageType),
+		Scope:      "Public",
+	}
+
+	var references []string
+	for _, reference := range first.References {
+		references = append(references, strings.Join([]string{first.Sender, reference, alert.Sent}, ","))
+	}
+	alert.References = strings.Join(references, " ")
+
+	for _, translation := range translations {
+		info, err := encodeInfo(translation)
+		if err != nil {
+			return err
+		}
+		alert.Infos = append(alert.Infos, info)
+	}
+
+	_, err := io.WriteString(w, xml.Header)
+	if err != nil {
+		return err
+	}
+
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	return encoder.Encode(alert)
+}
+
+// EncodeAlert writes the alert as a CAP message with one info block in DefaultLanguage.
+func EncodeAlert(w io.Writer, alert data_structures.AlertV2) error {
+	return Encode(w, []Translation{{Language: DefaultLanguage, Alert: alert}})
+}
+
+func decodeAlert(alert capAlert) ([]Translation, error) {
+	base := data_structures.AlertV2{
+		ID:          alert.Identifier,
+		Sender:      alert.Sender,
+		Status:      golang.AlertStatus(alert.Status),
+		MessageType: golang.AlertMessageType(alert.MsgType),
+	}
+
+	if alert.Status != "" && !base.Status.Valid() {
+		return nil, fmt.Errorf("invalid CAP status: %s", alert.Status)
+	}
+	if alert.MsgType != "" && !base.MessageType.Valid() {
+		return nil, fmt.Errorf("invalid CAP msgType: %s", alert.MsgType)
+	}
+
+	var err error
+	base.Sent, err = parseTime("sent", alert.Sent)
+	if err != nil {
+		return nil, err
+	}
+
+	// References are sender,identifier,sent triplets separated by whitespace.
+	for _, reference := range strings.Fields(alert.References) {
+		parts := strings.Split(reference, ",")
+		if len(parts) == 3 {
+			base.References = append(base.References, parts[1])
+		} else {
+			base.References = append(base.References, reference)
+		}
+	}
+
+	if len(alert.Infos) == 0 {
+		return []Translation{{Alert: base}}, nil
+	}
+
+	translations := make([]Translation, 0, len(alert.Infos))
+	for _, info := range alert.Infos {
+		translation, err := decodeInfo(base, info)
+		if err != nil {
+			return nil, err
+		}
+		translations = append(translations, translation)
+	}
+	return translations, nil
+}
+
+func decodeInfo(alert data_structures.AlertV2, info capInfo) (Translation, error) {
+	alert.References = slices.Clone(alert.References)
+	alert.Event = info.Event
+	alert.SenderName = info.SenderName
+	alert.Headline = info.Headline
+	alert.Description = info.Description
+	alert.Instruction = info.Instruction
+	alert.Urgency = golang.AlertUrgency(info.Urgency)
+	alert.Severity = golang.AlertSeverity(info.Severity)
+	alert.Certainty = golang.AlertCertainty(info.Certainty)
+	if len(info.Categories) > 0 {
+		alert.Category = golang.AlertCategory(info.Categories[0])
+	}
+	if len(info.ResponseTypes) > 0 {
+		alert.Response = golang.AlertResponse(info.ResponseTypes[0])
+	}
+
+	if alert.Urgency != "" && !alert.Urgency.Valid() {
+		return Translation{}, fmt.Errorf("invalid CAP urgency: %s", alert.Urgency)
+	} else if alert.Severity != "" && !alert.Severity.Valid() {
+		return Translation{}, fmt.Errorf("invalid CAP severity: %s", alert.Severity)
+	} else if alert.Certainty != "" && !alert.Certainty.Valid() {
+		return Translation{}, fmt.Errorf("invalid CAP certainty: %s", alert.Certainty)
+	} else if alert.Category != "" && !alert.Category.Valid() {
+		return Translation{}, fmt.Errorf("invalid CAP category: %s", alert.Category)
+	} else if alert.Response != "" && !alert.Response.Valid() {
+		return Translation{}, fmt.Errorf("invalid CAP responseType: %s", alert.Response)
+	}
+
+	var err error
+	alert.Effective, err = parseTime("effective", info.Effective)
+	if err != nil {
+		return Translation{}, err
+	}
+
+	alert.Onset, err = parseTime("onset", info.Onset)
+	if err != nil {
+		return Translation{}, err
+	}
+
+	alert.Expires, err = parseTime("expires", info.Expires)
+	if err != nil {
+		return Translation{}, err
+	}
+
+	for _, parameter := range info.Parameters {
+		if parameter.ValueName == endsParameter {
+			alert.Ends, err = parseTime(endsParameter, parameter.Value)
+			if err != nil {
+				return Translation{}, err
+			}
+			continue
+		}
+
+		if alert.Parameters == nil {
+			alert.Parameters = make(map[string]interface{})
+		}
+		values, _ := alert.Parameters[parameter.ValueName].([]interface{})
+		alert.Parameters[parameter.ValueName] = append(values, parameter.Value)
+	}
+
+	var areaDescs []string
+	var geometries []*geojson_v2.Geometry
+	geocode := &data_structures.AlertPropertiesGeocodeV2{}
+	for _, area := range info.Areas {
+		areaDescs = append(areaDescs, area.AreaDesc)
+
+		for _, polygon := range area.Polygons {
+			ring, err := parsePolygon(polygon)
+			if err != nil {
+				return Translation{}, err
+			}
+			geometries = append(geometries, &geojson_v2.Geometry{
+				Polygon: &geojson_v2.Polygon{OuterPath: &geojson_v2.MultiPoint{Points: ring}},
+			})
+		}
+
+		for _, circle := range area.Circles {
+			geometry, err := parseCircle(circle)
+			if err != nil {
+				return Translation{}, err
+			}
+			geometries = append(geometries, geometry)
+		}
+
+		for _, code := range area.Geocodes {
+			if code.ValueName == "SAME" {
+				geocode.SAME = append(geocode.SAME, code.Value)
+			} else if code.ValueName == "UGC" {
+				geocode.UGC = append(geocode.UGC, code.Value)
+			}
+		}
+	}
+
+	alert.AreaDesc = strings.Join(areaDescs, "; ")
+	alert.Geometry = combineGeometries(geometries)
+	if len(geocode.SAME) > 0 || len(geocode.UGC) > 0 {
+		alert.Geocode = geocode
+	}
+
+	language := info.Language
+	if language == "" {
+		language = DefaultLanguage
+	}
+
+	return Translation{Language: language, Alert: alert}, nil
+}
+
+func encodeInfo(translation Translation) (capInfo, error) {
+	alert := translation.Alert
+	info := capInfo{
+		Language:    translation.Language,
+		Event:       alert.Event,
+		Urgency:     string(alert.Urgency),
+		Severity:    string(alert.Severity),
+		Certainty:   string(alert.Certainty),
+		Effective:   formatTime(alert.Effective),
+		Onset:       formatTime(alert.Onset),
+		Expires:     formatTime(alert.Expires),
+		SenderName:  alert.SenderName,
+		Headline:    alert.Headline,
+		Description: alert.Description,
+		Instruction: alert.Instruction,
+	}
+	if alert.Category != "" {
+		info.Categories = []string{string(alert.Category)}
+	}
+	if alert.Response != "" {
+		info.ResponseTypes = []string{string(alert.Response)}
+	}
+
+	names := make([]string, 0, len(alert.Parameters))
+	for name := range alert.Parameters {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	for _, name := range names {
+		values, err := parameterValues(alert.Parameters, name)
+		if err != nil {
+			return info, err
+		}
+		for _, value := range values {
+			info.Parameters = append(info.Parameters, capValue{ValueName: name, Value: value})
+		}
+	}
+	if !alert.Ends.IsZero() && alert.Parameters[endsParameter] == nil {
+		info.Parameters = append(info.Parameters, capValue{ValueName: endsParameter, Value: formatTime(alert.Ends)})
+	}
+
+	area := capArea{AreaDesc: alert.AreaDesc}
+	for _, ring := range outerRings(alert.Geometry) {
+		area.Polygons = append(area.Polygons, formatPositions(ring))
+	}
+	if alert.Geocode != nil {
+		for _, code := range alert.Geocode.SAME {
+			area.Geocodes = append(area.Geocodes, capValue{ValueName: "SAME", Value: code})
+		}
+		for _, code := range alert.Geocode.UGC {
+			area.Geocodes = append(area.Geocodes, capValue{ValueName: "UGC", Value: code})
+		}
+	}
+	if area.AreaDesc != "" || len(area.Polygons) > 0 || len(area.Geocodes) > 0 {
+		info.Areas = []capArea{area}
+	}
+
+	return info, nil
+}
+
+// parseTime parses a CAP date time, returning the zero time for an empty value.
+func parseTime(name, value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid CAP %s: %w", name, err)
+	}
+	return parsed, nil
+}
+
+// formatTime formats the time the way CAP requires, writing UTC as -00:00 rather than Z, or returns an empty string
+// for the zero time.
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+
+	formatted := t.Format(timeLayout)
+	if strings.HasSuffix(formatted, "+00:00") {
+		formatted = strings.TrimSuffix(formatted, "+00:00") + "-00:00"
+	}
+	return formatted
+}
+
+// parsePositions parses space separated latitude,longitude pairs.
+func parsePositions(value string) ([]*geojson_v2.Point, error) {
+	var points []*geojson_v2.Point
+	for _, pair := range strings.Fields(value) {
+		latitude, longitude, ok := strings.Cut(pair, ",")
+		if !ok {
+			return nil, fmt.Errorf("invalid CAP position: %s", pair)
+		}
+
+		point := &geojson_v2.Point{}
+		var err error
+		point.Latitude, err = strconv.ParseFloat(latitude, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid CAP position: %w", err)
+		}
+
+		point.Longitude, err = strconv.ParseFloat(longitude, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid CAP position: %w", err)
+		}
+
+		points = append(points, point)
+	}
+	return points, nil
+}
+
+// parsePolygon parses the ring of a polygon, which CAP requires to have at least four positions with the last being
+// the first.
+func parsePolygon(value string) ([]*geojson_v2.Point, error) {
+	points, err := parsePositions(value)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(points) < 4 {
+		return nil, fmt.Errorf("invalid CAP polygon: %v positions", len(points))
+	} else if !points[0].Equal(*points[len(points)-1]) {
+		return nil, fmt.Errorf("invalid CAP polygon: ring is not closed")
+	}
+	return points, nil
+}
+
+// formatPositions writes the positions as space separated latitude,longitude pairs, closing the ring when its last
+// position is not its first.
+func formatPositions(points []*geojson_v2.Point) string {
+	pairs := make([]string, 0, len(points)+1)
+	for _, point := range points {
+		pairs = append(pairs, strconv.FormatFloat(point.Latitude, 'f', -1, 64)+","+strconv.FormatFloat(point.Longitude, 'f', -1, 64))
+	}
+	if len(pairs) > 0 && pairs[0] != pairs[len(pairs)-1] {
+		pairs = append(pairs, pairs[0])
+	}
+	return strings.Join(pairs, " ")
+}
+
+// parseCircle parses a "latitude,longitude radius" circle, with the radius in kilometers, into the polygon it covers,
+// or into its center point when the radius is zero.
+func parseCircle(value string) (*geojson_v2.Geometry, error) {
+	fields := strings.Fields(value)
+	if len(fields) != 2 {
+		return nil, fmt.Errorf("invalid CAP circle: %s", value)
+	}
+
+	points, err := parsePositions(fields[0])
+	if err != nil {
+		return nil, err
+	}
+
+	radius, err := strconv.ParseFloat(fields[1], 64)
+	if err != nil || radius < 0 || math.IsNaN(radius) || math.IsInf(radius, 0) {
+		return nil, fmt.Errorf("invalid CAP circle radius: %s", fields[1])
+	}
+
+	center := &geojson_v2.Geometry{Point: points[0]}
+	return center.Buffer(radius * 1000), nil
+}
+
+// combineGeometries combines the geometries of the areas into a polygon or multi polygon, or into a geometry collection
+// when any of them is a point.
+func combineGeometries(geometries []*geojson_v2.Geometry) *geojson_v2.Geometry {
+	if len(geometries) == 0 {
+		return nil
+	} else if len(geometries) == 1 {
+		return geometries[0]
+	}
+
+	var polygons []*geojson_v2.Polygon
+	for _, geometry := range geometries {
+		if geometry.Polygon != nil {
+			polygons = append(polygons, geometry.Polygon)
+		} else if geometry.MultiPolygon != nil {
+			polygons = append(polygons, geometry.MultiPolygon.Polygons...)
+		} else {
+			return &geojson_v2.Geometry{GeometryCollection: &geojson_v2.GeometryCollection{Geometries: geometries}}
+		}
+	}
+
+	return &geojson_v2.Geometry{MultiPolygon: &geojson_v2.MultiPolygon{Polygons: polygons}}
+}
+
+// outerRings returns the outer rings of the polygons of the geometry.
+func outerRings(g *geojson_v2.Geometry) [][]*geojson_v2.Point {
+	if g == nil {
+		return nil
+	}
+
+	var rings [][]*geojson_v2.Point
+	if g.Polygon != nil && g.Polygon.OuterPath != nil {
+		rings = append(rings, g.Polygon.OuterPath.Points)
+	} else if g.MultiPolygon != nil {
+		for _, polygon := range g.MultiPolygon.Polygons {
+			if polygon.OuterPath != nil {
+				rings = append(rings, polygon.OuterPath.Points)
+			}
+		}
+	} else if g.GeometryCollection != nil {
+		for _, geometry := range g.GeometryCollection.Geometries {
+			rings = append(rings, outerRings(geometry)...)
+		}
+	}
+	return rings
+}
+
+// parameterValues returns the values of the parameter, which NWS alerts give as a list of strings.
+func parameterValues(parameters map[string]interface{}, key string) ([]string, error) {
+	switch parameter := parameters[key].(type) {
+	case nil:
+		return nil, nil
+	case string:
+		return []string{parameter}, nil
+	case []string:
+		return parameter, nil
+	case []interface{}:
+		values := make([]string, 0, len(parameter))
+		for _, value := range parameter {
+			text, ok := value.(string)
+			if !ok {
+				return nil, fmt.Errorf("unsupported %s parameter type: %T", key, value)
+			}
+			values = append(values, text)
+		}
+		return values, nil
+	default:
+		return nil, fmt.Errorf("unsupported %s parameter type: %T", key, parameter)
+	}
+}
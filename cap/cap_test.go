@@ -0,0 +1,243 @@
+package cap
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cmeyer18/weather-common/v6/data_structures/geojson_v2"
+	"github.com/cmeyer18/weather-common/v6/generative/golang"
+)
+
+func decodeFile(t *testing.T, path string) []Translation {
+	t.Helper()
+
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	translations, err := Decode(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return translations
+}
+
+func TestDecodeNWSAlert(t *testing.T) {
+	translations := decodeFile(t, "testdata/nws_alert.xml")
+	if len(translations) != 1 {
+		t.Fatalf("translations = %d, want 1", len(translations))
+	}
+
+	translation := translations[0]
+	alert := translation.Alert
+	if translation.Language != "en-US" {
+		t.Errorf("Language = %s", translation.Language)
+	}
+	if alert.ID != "urn:oid:2.49.0.1.840.0.7b0a4e4d1f2f0c6b1c8e6d7a8b9c0d1e2f3a4b5c.001.1" || alert.Sender != "w-nws.webmaster@noaa.gov" {
+		t.Errorf("ID = %s, Sender = %s", alert.ID, alert.Sender)
+	}
+	if alert.Status != golang.AlertStatusActual || alert.MessageType != golang.AlertMessageTypeUpdate ||
+		alert.Category != golang.AlertCategoryMet || alert.Response != golang.AlertResponseShelter ||
+		alert.Urgency != golang.AlertUrgencyImmediate || alert.Severity != golang.AlertSeveritySevere ||
+		alert.Certainty != golang.AlertCertaintyObserved {
+		t.Errorf("enums = %s %s %s %s %s %s %s", alert.Status, alert.MessageType, alert.Category, alert.Response,
+			alert.Urgency, alert.Severity, alert.Certainty)
+	}
+	if alert.Event != "Severe Thunderstorm Warning" || alert.SenderName != "NWS Omaha/Valley NE" {
+		t.Errorf("Event = %s, SenderName = %s", alert.Event, alert.SenderName)
+	}
+
+	if !alert.Sent.Equal(time.Date(2024, 5, 16, 21, 6, 0, 0, time.UTC)) {
+		t.Errorf("Sent = %v", alert.Sent)
+	}
+	if !alert.Expires.Equal(time.Date(2024, 5, 16, 21, 45, 0, 0, time.UTC)) || !alert.Ends.Equal(alert.Expires) {
+		t.Errorf("Expires = %v, Ends = %v", alert.Expires, alert.Ends)
+	}
+
+	if len(alert.References) != 1 || alert.References[0] != "urn:oid:2.49.0.1.840.0.4f1e2d3c4b5a69788796a5b4c3d2e1f0a9b8c7d6.001.1" {
+		t.Errorf("References = %v", alert.References)
+	}
+
+	if alert.Parameters[endsParameter] != nil {
+		t.Errorf("Parameters keep %s", endsParameter)
+	}
+	channels, err := parameterValues(alert.Parameters, "BLOCKCHANNEL")
+	if err != nil || len(channels) != 2 || channels[0] != "EAS" || channels[1] != "NWEM" {
+		t.Errorf("BLOCKCHANNEL = %v, %v", channels, err)
+	}
+	codes, err := alert.VTEC()
+	if err != nil || len(codes) != 1 {
+		t.Errorf("VTEC = %v, %v", codes, err)
+	}
+
+	if alert.AreaDesc != "Douglas, NE; Sarpy, NE" {
+		t.Errorf("AreaDesc = %s", alert.AreaDesc)
+	}
+	if alert.Geocode == nil || strings.Join(alert.Geocode.SAME, " ") != "031055 031153" ||
+		strings.Join(alert.Geocode.UGC, " ") != "NEC055 NEC153" {
+		t.Errorf("Geocode = %+v", alert.Geocode)
+	}
+
+	geometry, err := json.Marshal(alert.Geometry)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"type":"Polygon","coordinates":[[[-96.2,41.1],[-95.9,41.1],[-95.9,41.4],[-96.2,41.4],[-96.2,41.1]]]}`
+	if string(geometry) != want {
+		t.Errorf("Geometry = %s, want %s", geometry, want)
+	}
+}
+
+func TestDecodeIPAWSFeed(t *testing.T) {
+	translations := decodeFile(t, "testdata/ipaws_feed.xml")
+	if len(translations) != 3 {
+		t.Fatalf("translations = %d, want 3", len(translations))
+	}
+
+	english, spanish, test := translations[0], translations[1], translations[2]
+	if english.Language != "en-US" || spanish.Language != "es-US" || test.Language != DefaultLanguage {
+		t.Errorf("languages = %s %s %s", english.Language, spanish.Language, test.Language)
+	}
+	if english.Alert.ID != "CA-OES-2024-0517-0001" || spanish.Alert.ID != english.Alert.ID || test.Alert.ID != "WA-EMD-2024-0517-0002" {
+		t.Errorf("IDs = %s %s %s", english.Alert.ID, spanish.Alert.ID, test.Alert.ID)
+	}
+	if english.Alert.Event != "Evacuation Immediate" || spanish.Alert.Event != "Evacuación Inmediata" {
+		t.Errorf("events = %s %s", english.Alert.Event, spanish.Alert.Event)
+	}
+	if !spanish.Alert.Sent.Equal(english.Alert.Sent) || spanish.Alert.Response != golang.AlertResponseEvacuate {
+		t.Errorf("Spanish alert = %+v", spanish.Alert)
+	}
+	if english.Alert.AreaDesc != "Within 5 kilometers of Paradise, CA; Magalia, CA" {
+		t.Errorf("AreaDesc = %s", english.Alert.AreaDesc)
+	}
+	if english.Alert.Geocode == nil || len(english.Alert.Geocode.SAME) != 1 || english.Alert.Geocode.SAME[0] != "006007" {
+		t.Errorf("Geocode = %+v", english.Alert.Geocode)
+	}
+
+	// The circle and the polygon of the two areas make up a multi polygon.
+	geometry := english.Alert.Geometry
+	if geometry == nil || geometry.MultiPolygon == nil || len(geometry.MultiPolygon.Polygons) != 2 {
+		t.Fatalf("Geometry = %+v, want a multi polygon of the circle and the polygon", geometry)
+	}
+
+	circle := &geojson_v2.Geometry{Polygon: geometry.MultiPolygon.Polygons[0]}
+	center := geojson_v2.Point{Latitude: 39.7596, Longitude: -121.6219}
+	if !circle.Contains(center) {
+		t.Error("circle does not contain its center")
+	}
+	bbox := circle.BBox()
+	radius := (bbox.MaxLatitude - bbox.MinLatitude) / 2 * 111.195
+	if math.Abs(radius-5) > 0.05 {
+		t.Errorf("circle radius = %v km, want 5", radius)
+	}
+
+	// A circle with a zero radius is its center point.
+	if test.Alert.Geometry == nil || test.Alert.Geometry.Point == nil ||
+		!test.Alert.Geometry.Point.Equal(geojson_v2.Point{Latitude: 47.6062, Longitude: -122.3321}) {
+		t.Errorf("Geometry = %+v, want the center point", test.Alert.Geometry)
+	}
+	if test.Alert.Status != golang.AlertStatusTest || test.Alert.Category != golang.AlertCategorySafety {
+		t.Errorf("Status = %s, Category = %s", test.Alert.Status, test.Alert.Category)
+	}
+}
+
+func TestDecodeAlertLanguage(t *testing.T) {
+	data, err := os.ReadFile("testdata/ipaws_feed.xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		language string
+		want     string
+	}{
+		{language: "es-US", want: "Evacuación Inmediata"},
+		{language: "EN-us", want: "Evacuation Immediate"},
+		{language: "fr-CA", want: "Evacuation Immediate"},
+	}
+
+	for _, test := range tests {
+		alert, err := DecodeAlert(bytes.NewReader(data), test.language)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if alert == nil || alert.Event != test.want {
+			t.Errorf("%s: alert = %+v, want %s", test.language, alert, test.want)
+		}
+	}
+
+	alert, err := DecodeAlert(strings.NewReader(`<feed xmlns="http://www.w3.org/2005/Atom"></feed>`), DefaultLanguage)
+	if err != nil || alert != nil {
+		t.Errorf("alert = %+v, %v, want none", alert, err)
+	}
+}
+
+func TestEncodeRoundTrip(t *testing.T) {
+	for _, test := range []struct {
+		path         string
+		translations int
+	}{
+		{path: "testdata/nws_alert.xml", translations: 1},
+		{path: "testdata/ipaws_feed.xml", translations: 2},
+	} {
+		t.Run(test.path, func(t *testing.T) {
+			want := decodeFile(t, test.path)[:test.translations]
+
+			var buffer bytes.Buffer
+			err := Encode(&buffer, want)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := Decode(&buffer)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(got) != len(want) {
+				t.Fatalf("translations = %d, want %d", len(got), len(want))
+			}
+
+			for i := range want {
+				if got[i].Language != want[i].Language {
+					t.Errorf("Language = %s, want %s", got[i].Language, want[i].Language)
+				}
+
+				wantJSON, err := json.Marshal(want[i].Alert)
+				if err != nil {
+					t.Fatal(err)
+				}
+				gotJSON, err := json.Marshal(got[i].Alert)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if string(gotJSON) != string(wantJSON) {
+					t.Errorf("translation %d\n got: %s\nwant: %s", i, gotJSON, wantJSON)
+				}
+			}
+		})
+	}
+}
+
+func TestDecodeRejectsInvalidPolygons(t *testing.T) {
+	for _, polygon := range []string{
+		" ",
+		"41.1,-96.2 41.1,-95.9 41.1,-96.2",
+		"41.1,-96.2 41.1,-95.9 41.4,-95.9 41.4,-96.2",
+		"41.1,-96.2 41.1,-95.9 41.4,-95.9 41.4,-96.2 41.2,-96.2",
+	} {
+		data := `<alert xmlns="urn:oasis:names:tc:emergency:cap:1.2"><identifier>1</identifier><info><event>Test</event>` +
+			`<area><areaDesc>Test</areaDesc><polygon>` + polygon + `</polygon></area></info></alert>`
+
+		_, err := Decode(strings.NewReader(data))
+		if err == nil || !strings.Contains(err.Error(), "invalid CAP polygon") {
+			t.Errorf("%q: err = %v, want invalid CAP polygon", polygon, err)
+		}
+	}
+}
@@ -0,0 +1,282 @@
+package data_structures
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cmeyer18/weather-common/v6/data_structures/geojson_v2"
+)
+
+// knotsToMilesPerHour converts wind speeds given in knots, as marine alerts do.
+const knotsToMilesPerHour = 1.150779
+
+var (
+	measurementPattern = regexp.MustCompile(`(\d*\.?\d+)\s*([A-Za-z]*)`)
+	motionPattern      = regexp.MustCompile(`^(.+?)\.\.\.(.+?)\.\.\.(\d+)DEG\.\.\.(\d+)KT\.\.\.(.+)$`)
+)
+
+// TornadoDetection is how the tornado of a tornado or severe thunderstorm warning is known about.
+type TornadoDetection string
+
+const (
+	TornadoDetection_Possible       TornadoDetection = "POSSIBLE"
+	TornadoDetection_RadarIndicated TornadoDetection = "RADAR INDICATED"
+	TornadoDetection_Observed       TornadoDetection = "OBSERVED"
+)
+
+// Rank orders tornado detections from 0 when there is none to 3 for an observed tornado.
+func (t TornadoDetection) Rank() int {
+	switch t {
+	case TornadoDetection_Possible:
+		return 1
+	case TornadoDetection_RadarIndicated:
+		return 2
+	case TornadoDetection_Observed:
+		return 3
+	default:
+		return 0
+	}
+}
+
+// DamageThreat is the impact based damage tag of a warning.
+type DamageThreat string
+
+const (
+	DamageThreat_Base         DamageThreat = "BASE"
+	DamageThreat_Considerable DamageThreat = "CONSIDERABLE"
+	DamageThreat_Destructive  DamageThreat = "DESTRUCTIVE"
+	DamageThreat_Catastrophic DamageThreat = "CATASTROPHIC"
+)
+
+// Rank orders damage threats from 0 for a base or missing tag to 3 for a catastrophic one.
+func (d DamageThreat) Rank() int {
+	switch d {
+	case DamageThreat_Considerable:
+		return 1
+	case DamageThreat_Destructive:
+		return 2
+	case DamageThreat_Catastrophic:
+		return 3
+	default:
+		return 0
+	}
+}
+
+// EventMotionDescription is where the storm of a warning was and how it was moving, parsed from a value such as
+// 2024-05-16T21:06:00-00:00...storm...245DEG...35KT...41.26,-96.05 41.1,-96.2.
+type EventMotionDescription struct {
+	Time  time.Time
+	Event string
+	// Bearing is the direction in degrees the storm is moving from.
+	Bearing int
+	// Speed is in knots.
+	Speed     int
+	Positions []geojson_v2.Point
+}
+
+// AlertParameters is a typed view of the well known parameters of an alert. Parameters an alert does not carry are
+// left empty.
+type AlertParameters struct {
+	NWSHeadline            []string
+	EventMotionDescription *EventMotionDescription
+	// MaxHailSize is in inches.
+	MaxHailSize *float64
+	// MaxWindGust is in miles per hour.
+	MaxWindGust              *float64
+	TornadoDetection         TornadoDetection
+	TornadoDamageThreat      DamageThreat
+	ThunderstormDamageThreat DamageThreat
+	FlashFloodDamageThreat   DamageThreat
+	WMOIdentifier            string
+	AWIPSIdentifier          string
+	BlockChannels            []string
+}
+
+// TypedParameters parses the well known parameters of the alert.
+func (a *AlertV2) TypedParameters() (*AlertParameters, error) {
+	return ParseAlertParameters(a.Parameters)
+}
+
+// ParseAlertParameters parses the well known parameters out of the parameters member of an NWS alert, where each
+// parameter is a list of strings.
+func ParseAlertParameters(parameters map[string]interface{}) (*AlertParameters, error) {
+	var err error
+	typed := &AlertParameters{}
+
+	typed.NWSHeadline, err = parameterValues(parameters, "NWSheadline")
+	if err != nil {
+		return nil, err
+	}
+
+	typed.BlockChannels, err = parameterValues(parameters, "BLOCKCHANNEL")
+	if err != nil {
+		return nil, err
+	}
+
+	for key, field := range map[string]*string{
+		"WMOidentifier":   &typed.WMOIdentifier,
+		"AWIPSidentifier": &typed.AWIPSIdentifier,
+	} {
+		*field, err = parameterValue(parameters, key)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for key, field := range map[string]*DamageThreat{
+		"tornadoDamageThreat":      &typed.TornadoDamageThreat,
+		"thunderstormDamageThreat": &typed.ThunderstormDamageThreat,
+		"flashFloodDamageThreat":   &typed.FlashFloodDamageThreat,
+	} {
+		value, err := parameterValue(parameters, key)
+		if err != nil {
+			return nil, err
+		}
+		*field = DamageThreat(strings.ToUpper(value))
+	}
+
+	tornadoDetection, err := parameterValue(parameters, "tornadoDetection")
+	if err != nil {
+		return nil, err
+	}
+	typed.TornadoDetection = TornadoDetection(strings.ToUpper(tornadoDetection))
+
+	typed.MaxHailSize, err = parseMeasurement(parameters, "maxHailSize", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	typed.MaxWindGust, err = parseMeasurement(parameters, "maxWindGust", map[string]float64{
+		"KT":  knotsToMilesPerHour,
+		"KTS": knotsToMilesPerHour,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	motion, err := parameterValue(parameters, "eventMotionDescription")
+	if err != nil {
+		return nil, err
+	} else if motion != "" {
+		typed.EventMotionDescription, err = ParseEventMotionDescription(motion)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return typed, nil
+}
+
+// ParseEventMotionDescription parses an eventMotionDescription parameter.
+func ParseEventMotionDescription(value string) (*EventMotionDescription, error) {
+	matches := motionPattern.FindStringSubmatch(strings.TrimSpace(value))
+	if matches == nil {
+		return nil, fmt.Errorf("invalid eventMotionDescription: %s", value)
+	}
+
+	motionTime, err := time.Parse(time.RFC3339, matches[1])
+	if err != nil {
+		return nil, fmt.Errorf("invalid eventMotionDescription time: %w", err)
+	}
+
+	bearing, err := strconv.Atoi(matches[3])
+	if err != nil {
+		return nil, fmt.Errorf("invalid eventMotionDescription bearing: %w", err)
+	}
+
+	speed, err := strconv.Atoi(matches[4])
+	if err != nil {
+		return nil, fmt.Errorf("invalid eventMotionDescription speed: %w", err)
+	}
+
+	var positions []geojson_v2.Point
+	for _, position := range strings.Fields(matches[5]) {
+		latitude, longitude, ok := strings.Cut(position, ",")
+		if !ok {
+			return nil, fmt.Errorf("invalid eventMotionDescription position: %s", position)
+		}
+
+		var point geojson_v2.Point
+		point.Latitude, err = strconv.ParseFloat(latitude, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid eventMotionDescription position: %w", err)
+		}
+
+		point.Longitude, err = strconv.ParseFloat(longitude, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid eventMotionDescription position: %w", err)
+		}
+
+		positions = append(positions, point)
+	}
+
+	return &EventMotionDescription{
+		Time:      motionTime,
+		Event:     matches[2],
+		Bearing:   bearing,
+		Speed:     speed,
+		Positions: positions,
+	}, nil
+}
+
+// parseMeasurement parses the first number of the parameter, such as the 60 of "60 MPH", scaling it by the factor of
+// its unit when the unit has one.
+func parseMeasurement(parameters map[string]interface{}, key string, factors map[string]float64) (*float64, error) {
+	value, err := parameterValue(parameters, key)
+	if err != nil || value == "" {
+		return nil, err
+	}
+
+	matches := measurementPattern.FindStringSubmatch(value)
+	if matches == nil {
+		return nil, fmt.Errorf("invalid %s: %s", key, value)
+	}
+
+	measurement, err := strconv.ParseFloat(matches[1], 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", key, err)
+	}
+
+	factor, ok := factors[strings.ToUpper(matches[2])]
+	if ok {
+		measurement *= factor
+	}
+
+	return &measurement, nil
+}
+
+// parameterValue returns the first value of the parameter, or an empty string when the alert does not carry it.
+func parameterValue(parameters map[string]interface{}, key string) (string, error) {
+	values, err := parameterValues(parameters, key)
+	if err != nil || len(values) == 0 {
+		return "", err
+	}
+	return values[0], nil
+}
+
+// parameterValues returns the values of the parameter, which NWS alerts give as a list of strings.
+func parameterValues(parameters map[string]interface{}, key string) ([]string, error) {
+	switch parameter := parameters[key].(type) {
+	case nil:
+		return nil, nil
+	case string:
+		return []string{parameter}, nil
+	case []string:
+		return parameter, nil
+	case []interface{}:
+		values := make([]string, 0, len(parameter))
+		for _, value := range parameter {
+			text, ok := value.(string)
+			if !ok {
+				return nil, fmt.Errorf("unsupported %s parameter type: %T", key, value)
+			}
+			values = append(values, text)
+		}
+		return values, nil
+	default:
+		return nil, fmt.Errorf("unsupported %s parameter type: %T", key, parameter)
+	}
+}
@@ -0,0 +1,181 @@
+package data_structures
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+	"time"
+)
+
+func parseParameters(t *testing.T, data string) map[string]interface{} {
+	t.Helper()
+
+	var parameters map[string]interface{}
+	err := json.Unmarshal([]byte(data), &parameters)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return parameters
+}
+
+func TestParseAlertParametersSevereThunderstormWarning(t *testing.T) {
+	// The parameters member of a severe thunderstorm warning from the NWS /alerts API.
+	parameters := parseParameters(t, `{
+		"AWIPSidentifier": ["SVSOAX"],
+		"WMOidentifier": ["WWUS53 KOAX 162106"],
+		"NWSheadline": ["A SEVERE THUNDERSTORM WARNING REMAINS IN EFFECT UNTIL 445 PM CDT FOR CENTRAL DOUGLAS AND NORTHEASTERN SARPY COUNTIES"],
+		"eventMotionDescription": ["2024-05-16T21:06:00-00:00...storm...245DEG...35KT...41.26,-96.05 41.1,-96.2"],
+		"windThreat": ["RADAR INDICATED"],
+		"maxWindGust": ["70 MPH"],
+		"hailThreat": ["RADAR INDICATED"],
+		"maxHailSize": ["1.75"],
+		"tornadoDetection": ["POSSIBLE"],
+		"thunderstormDamageThreat": ["CONSIDERABLE"],
+		"BLOCKCHANNEL": ["EAS", "NWEM", "CMAS"],
+		"EAS-ORG": ["WXR"],
+		"VTEC": ["/O.CON.KOAX.SV.W.0100.000000T0000Z-240516T2145Z/"],
+		"eventEndingTime": ["2024-05-16T21:45:00+00:00"]
+	}`)
+
+	typed, err := ParseAlertParameters(parameters)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if typed.AWIPSIdentifier != "SVSOAX" || typed.WMOIdentifier != "WWUS53 KOAX 162106" {
+		t.Errorf("identifiers = %s %s", typed.AWIPSIdentifier, typed.WMOIdentifier)
+	}
+	if len(typed.NWSHeadline) != 1 {
+		t.Errorf("NWSHeadline = %v", typed.NWSHeadline)
+	}
+	if len(typed.BlockChannels) != 3 || typed.BlockChannels[2] != "CMAS" {
+		t.Errorf("BlockChannels = %v", typed.BlockChannels)
+	}
+	if typed.MaxHailSize == nil || *typed.MaxHailSize != 1.75 {
+		t.Errorf("MaxHailSize = %v, want 1.75", typed.MaxHailSize)
+	}
+	if typed.MaxWindGust == nil || *typed.MaxWindGust != 70 {
+		t.Errorf("MaxWindGust = %v, want 70", typed.MaxWindGust)
+	}
+	if typed.TornadoDetection != TornadoDetection_Possible || typed.TornadoDetection.Rank() != 1 {
+		t.Errorf("TornadoDetection = %s", typed.TornadoDetection)
+	}
+	if typed.ThunderstormDamageThreat != DamageThreat_Considerable || typed.TornadoDamageThreat != "" {
+		t.Errorf("damage threats = %s %s", typed.ThunderstormDamageThreat, typed.TornadoDamageThreat)
+	}
+
+	motion := typed.EventMotionDescription
+	if motion == nil {
+		t.Fatal("EventMotionDescription is nil")
+	}
+	if !motion.Time.Equal(time.Date(2024, 5, 16, 21, 6, 0, 0, time.UTC)) {
+		t.Errorf("Time = %v", motion.Time)
+	}
+	if motion.Event != "storm" || motion.Bearing != 245 || motion.Speed != 35 {
+		t.Errorf("motion = %s %d %d", motion.Event, motion.Bearing, motion.Speed)
+	}
+	if len(motion.Positions) != 2 || motion.Positions[0].Latitude != 41.26 || motion.Positions[0].Longitude != -96.05 ||
+		motion.Positions[1].Latitude != 41.1 || motion.Positions[1].Longitude != -96.2 {
+		t.Errorf("Positions = %+v", motion.Positions)
+	}
+}
+
+func TestParseAlertParametersTornadoWarning(t *testing.T) {
+	parameters := parseParameters(t, `{
+		"AWIPSidentifier": ["TOROAX"],
+		"eventMotionDescription": ["2024-04-26T20:38:00-00:00...storm...205DEG...40KT...41.3,-96.23"],
+		"maxHailSize": ["2.50"],
+		"tornadoDetection": ["OBSERVED"],
+		"tornadoDamageThreat": ["CATASTROPHIC"]
+	}`)
+
+	typed, err := ParseAlertParameters(parameters)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if typed.TornadoDetection != TornadoDetection_Observed || typed.TornadoDetection.Rank() != 3 {
+		t.Errorf("TornadoDetection = %s", typed.TornadoDetection)
+	}
+	if typed.TornadoDamageThreat != DamageThreat_Catastrophic || typed.TornadoDamageThreat.Rank() != 3 {
+		t.Errorf("TornadoDamageThreat = %s", typed.TornadoDamageThreat)
+	}
+	if typed.MaxWindGust != nil {
+		t.Errorf("MaxWindGust = %v, want nil", *typed.MaxWindGust)
+	}
+	if typed.EventMotionDescription == nil || len(typed.EventMotionDescription.Positions) != 1 {
+		t.Errorf("EventMotionDescription = %+v", typed.EventMotionDescription)
+	}
+}
+
+func TestParseAlertParametersMarineWarning(t *testing.T) {
+	parameters := parseParameters(t, `{
+		"AWIPSidentifier": ["SMWMKX"],
+		"maxWindGust": ["40 KTS"],
+		"maxHailSize": ["0.75"],
+		"waterspoutDetection": ["POSSIBLE"]
+	}`)
+
+	typed, err := ParseAlertParameters(parameters)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if typed.MaxWindGust == nil || math.Abs(*typed.MaxWindGust-40*knotsToMilesPerHour) > 1e-9 {
+		t.Errorf("MaxWindGust = %v, want 40 knots in miles per hour", typed.MaxWindGust)
+	}
+}
+
+func TestParseAlertParametersValueTypes(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    string
+		wantErr bool
+	}{
+		{name: "list", data: `{"AWIPSidentifier": ["SVSOAX"]}`, want: "SVSOAX"},
+		{name: "string", data: `{"AWIPSidentifier": "SVSOAX"}`, want: "SVSOAX"},
+		{name: "empty list", data: `{"AWIPSidentifier": []}`, want: ""},
+		{name: "missing", data: `{}`, want: ""},
+		{name: "number", data: `{"AWIPSidentifier": 1}`, wantErr: true},
+		{name: "list of numbers", data: `{"AWIPSidentifier": [1]}`, wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			typed, err := ParseAlertParameters(parseParameters(t, test.data))
+			if test.wantErr {
+				if err == nil {
+					t.Error("err = nil, want an unsupported type error")
+				}
+				return
+			} else if err != nil {
+				t.Fatal(err)
+			}
+
+			if typed.AWIPSIdentifier != test.want {
+				t.Errorf("AWIPSIdentifier = %q, want %q", typed.AWIPSIdentifier, test.want)
+			}
+		})
+	}
+
+	values, err := parameterValues(map[string]interface{}{"BLOCKCHANNEL": []string{"EAS", "NWEM"}}, "BLOCKCHANNEL")
+	if err != nil || len(values) != 2 {
+		t.Errorf("[]string values = %v, %v", values, err)
+	}
+}
+
+func TestParseEventMotionDescriptionErrors(t *testing.T) {
+	for _, value := range []string{
+		"",
+		"2024-05-16T21:06:00-00:00...storm...245DEG...35KT",
+		"2024-05-16 21:06...storm...245DEG...35KT...41.26,-96.05",
+		"2024-05-16T21:06:00-00:00...storm...245DEG...35KT...41.26",
+		"2024-05-16T21:06:00-00:00...storm...245DEG...35KT...north,-96.05",
+	} {
+		_, err := ParseEventMotionDescription(value)
+		if err == nil {
+			t.Errorf("%q: err = nil, want an error", value)
+		}
+	}
+}
@@ -1,9 +1,12 @@
 package data_structures
 
 import (
+	"errors"
 	"time"
 
 	"github.com/cmeyer18/weather-common/v6/data_structures/geojson_v2"
+	"github.com/cmeyer18/weather-common/v6/data_structures/vtec"
+	"github.com/cmeyer18/weather-common/v6/generative/golang"
 )
 
 type AlertV2 struct {
@@ -19,23 +22,48 @@ type AlertV2 struct {
 	Onset         time.Time                 `json:"onset"`
 	Expires       time.Time                 `json:"expires"`
 	Ends          time.Time                 `json:"ends"`
-	Status        string                    `json:"status"`
-	MessageType   string                    `json:"messageType"`
-	Category      string                    `json:"category"`
-	Severity      string                    `json:"severity"`
-	Certainty     string                    `json:"certainty"`
-	Urgency       string                    `json:"urgency"`
+	Status        golang.AlertStatus        `json:"status"`
+	MessageType   golang.AlertMessageType   `json:"messageType"`
+	Category      golang.AlertCategory      `json:"category"`
+	Severity      golang.AlertSeverity      `json:"severity"`
+	Certainty     golang.AlertCertainty     `json:"certainty"`
+	Urgency       golang.AlertUrgency       `json:"urgency"`
 	Event         string                    `json:"event"`
 	Sender        string                    `json:"sender"`
 	SenderName    string                    `json:"senderName"`
 	Headline      string                    `json:"headline"`
 	Description   string                    `json:"description"`
 	Instruction   string                    `json:"instruction"`
-	Response      string                    `json:"response"`
+	Response      golang.AlertResponse      `json:"response"`
 	Parameters    map[string]interface{}    `json:"parameters"`
+
+	// GeometryDerived is set when the geometry was derived from the boundaries of the UGC codes rather than issued
+	// with the alert.
+	GeometryDerived bool `json:"geometryDerived"`
 }
 
 type AlertPropertiesGeocodeV2 struct {
 	SAME []string `json:"SAME"`
 	UGC  []string `json:"UGC"`
 }
+
+// VTEC parses the P-VTEC strings of the VTEC parameter. Alerts without the parameter return no VTEC. Strings that do
+// not parse are skipped and reported in the error, alongside the ones that did parse.
+func (a *AlertV2) VTEC() ([]vtec.VTEC, error) {
+	values, err := parameterValues(a.Parameters, "VTEC")
+	if err != nil {
+		return nil, err
+	}
+
+	var parsed []vtec.VTEC
+	var errs []error
+	for _, value := range values {
+		v, err := vtec.Parse(value)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		parsed = append(parsed, v)
+	}
+	return parsed, errors.Join(errs...)
+}
@@ -0,0 +1,148 @@
+package data_structures
+
+import (
+	"time"
+
+	"github.com/cmeyer18/weather-common/v6/data_structures/geojson_v2"
+	"github.com/cmeyer18/weather-common/v6/generative/golang"
+)
+
+// AlertPropertiesV2 is the properties member of an NWS /alerts feature.
+type AlertPropertiesV2 struct {
+	AtID          string                       `json:"@id,omitempty"`
+	AtType        string                       `json:"@type,omitempty"`
+	ID            string                       `json:"id"`
+	AreaDesc      string                       `json:"areaDesc"`
+	Geocode       *AlertPropertiesGeocodeV2    `json:"geocode"`
+	AffectedZones []string                     `json:"affectedZones"`
+	References    []AlertPropertiesReferenceV2 `json:"references"`
+	Sent          time.Time                    `json:"sent"`
+	Effective     time.Time                    `json:"effective"`
+	Onset         time.Time                    `json:"onset"`
+	Expires       time.Time                    `json:"expires"`
+	Ends          time.Time                    `json:"ends"`
+	Status        golang.AlertStatus           `json:"status"`
+	MessageType   golang.AlertMessageType      `json:"messageType"`
+	Category      golang.AlertCategory         `json:"category"`
+	Severity      golang.AlertSeverity         `json:"severity"`
+	Certainty     golang.AlertCertainty        `json:"certainty"`
+	Urgency       golang.AlertUrgency          `json:"urgency"`
+	Event         string                       `json:"event"`
+	Sender        string                       `json:"sender"`
+	SenderName    string                       `json:"senderName"`
+	Headline      string                       `json:"headline"`
+	Description   string                       `json:"description"`
+	Instruction   string                       `json:"instruction"`
+	Response      golang.AlertResponse         `json:"response"`
+	Parameters    map[string]interface{}       `json:"parameters"`
+}
+
+type AlertPropertiesReferenceV2 struct {
+	AtID       string    `json:"@id,omitempty"`
+	Identifier string    `json:"identifier"`
+	Sender     string    `json:"sender"`
+	Sent       time.Time `json:"sent"`
+}
+
+// AlertsV2FromFeatureCollection converts an NWS /alerts FeatureCollection into alerts. The alert ID is taken from the
+// properties id, falling back to the feature id when the properties do not carry one. Only the identifier of each
+// reference is kept, so the @id, sender and sent of references and the @id of the alert are lost.
+func AlertsV2FromFeatureCollection(featureCollection *geojson_v2.FeatureCollection[AlertPropertiesV2]) []AlertV2 {
+	alerts := make([]AlertV2, 0, len(featureCollection.Features))
+	for _, feature := range featureCollection.Features {
+		if feature == nil {
+			continue
+		}
+
+		properties := feature.Properties
+
+		id := properties.ID
+		if featureID, ok := feature.ID.(string); ok && id == "" {
+			id = featureID
+		}
+
+		var references []string
+		for _, reference := range properties.References {
+			references = append(references, reference.Identifier)
+		}
+
+		alerts = append(alerts, AlertV2{
+			ID:            id,
+			Type:          properties.AtType,
+			Geometry:      feature.Geometry,
+			AreaDesc:      properties.AreaDesc,
+			Geocode:       properties.Geocode,
+			AffectedZones: properties.AffectedZones,
+			References:    references,
+			Sent:          properties.Sent,
+			Effective:     properties.Effective,
+			Onset:         properties.Onset,
+			Expires:       properties.Expires,
+			Ends:          properties.Ends,
+			Status:        properties.Status,
+			MessageType:   properties.MessageType,
+			Category:      properties.Category,
+			Severity:      properties.Severity,
+			Certainty:     properties.Certainty,
+			Urgency:       properties.Urgency,
+			Event:         properties.Event,
+			Sender:        properties.Sender,
+			SenderName:    properties.SenderName,
+			Headline:      properties.Headline,
+			Description:   properties.Description,
+			Instruction:   properties.Instruction,
+			Response:      properties.Response,
+			Parameters:    properties.Parameters,
+		})
+	}
+
+	return alerts
+}
+
+// AlertsV2ToFeatureCollection converts alerts into a FeatureCollection shaped like the NWS /alerts response. References
+// carry only their identifier, since that is all an alert keeps of them.
+func AlertsV2ToFeatureCollection(alerts []AlertV2) *geojson_v2.FeatureCollection[AlertPropertiesV2] {
+	features := make([]*geojson_v2.Feature[AlertPropertiesV2], 0, len(alerts))
+	for _, alert := range alerts {
+		var references []AlertPropertiesReferenceV2
+		for _, reference := range alert.References {
+			references = append(references, AlertPropertiesReferenceV2{Identifier: reference})
+		}
+
+		features = append(features, &geojson_v2.Feature[AlertPropertiesV2]{
+			ID:       alert.ID,
+			Geometry: alert.Geometry,
+			Properties: AlertPropertiesV2{
+				AtType:        alert.Type,
+				ID:            alert.ID,
+				AreaDesc:      alert.AreaDesc,
+				Geocode:       alert.Geocode,
+				AffectedZones: alert.AffectedZones,
+				References:    references,
+				Sent:          alert.Sent,
+				Effective:     alert.Effective,
+				Onset:         alert.Onset,
+				Expires:       alert.Expires,
+				Ends:          alert.Ends,
+				Status:        alert.Status,
+				MessageType:   alert.MessageType,
+				Category:      alert.Category,
+				Severity:      alert.Severity,
+				Certainty:     alert.Certainty,
+				Urgency:       alert.Urgency,
+				Event:         alert.Event,
+				Sender:        alert.Sender,
+				SenderName:    alert.SenderName,
+				Headline:      alert.Headline,
+				Description:   alert.Description,
+				Instruction:   alert.Instruction,
+				Response:      alert.Response,
+				Parameters:    alert.Parameters,
+			},
+		})
+	}
+
+	return &geojson_v2.FeatureCollection[AlertPropertiesV2]{
+		Features: features,
+	}
+}
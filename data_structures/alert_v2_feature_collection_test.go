@@ -0,0 +1,148 @@
+package data_structures
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/cmeyer18/weather-common/v6/data_structures/geojson_v2"
+	"github.com/cmeyer18/weather-common/v6/generative/golang"
+)
+
+// testAlertsResponse is trimmed from an NWS /alerts/active response.
+const testAlertsResponse = `{
+	"type": "FeatureCollection",
+	"features": [
+		{
+			"id": "https://api.weather.gov/alerts/urn:oid:2.49.0.1.840.0.2",
+			"type": "Feature",
+			"geometry": {"type": "Polygon", "coordinates": [[[-96.2, 41.1], [-95.9, 41.1], [-95.9, 41.4], [-96.2, 41.4], [-96.2, 41.1]]]},
+			"properties": {
+				"@id": "https://api.weather.gov/alerts/urn:oid:2.49.0.1.840.0.2",
+				"@type": "wx:Alert",
+				"id": "urn:oid:2.49.0.1.840.0.2",
+				"areaDesc": "Douglas, NE; Sarpy, NE",
+				"geocode": {"SAME": ["031055", "031153"], "UGC": ["NEC055", "NEC153"]},
+				"affectedZones": ["https://api.weather.gov/zones/county/NEC055", "https://api.weather.gov/zones/county/NEC153"],
+				"references": [
+					{
+						"@id": "https://api.weather.gov/alerts/urn:oid:2.49.0.1.840.0.1",
+						"identifier": "urn:oid:2.49.0.1.840.0.1",
+						"sender": "w-nws.webmaster@noaa.gov",
+						"sent": "2024-05-16T15:48:00-05:00"
+					}
+				],
+				"sent": "2024-05-16T16:06:00-05:00",
+				"effective": "2024-05-16T16:06:00-05:00",
+				"onset": "2024-05-16T16:06:00-05:00",
+				"expires": "2024-05-16T16:45:00-05:00",
+				"ends": "2024-05-16T16:45:00-05:00",
+				"status": "Actual",
+				"messageType": "Update",
+				"category": "Met",
+				"severity": "Severe",
+				"certainty": "Observed",
+				"urgency": "Immediate",
+				"event": "Severe Thunderstorm Warning",
+				"sender": "w-nws.webmaster@noaa.gov",
+				"senderName": "NWS Omaha/Valley NE",
+				"headline": "Severe Thunderstorm Warning issued May 16 at 4:06PM CDT",
+				"description": "At 406 PM CDT, a severe thunderstorm was located over Omaha.",
+				"instruction": "Move to an interior room on the lowest floor of a building.",
+				"response": "Shelter",
+				"parameters": {"VTEC": ["/O.CON.KOAX.SV.W.0100.000000T0000Z-240516T2145Z/"]}
+			}
+		},
+		{
+			"id": "urn:oid:2.49.0.1.840.0.3",
+			"type": "Feature",
+			"geometry": null,
+			"properties": {
+				"areaDesc": "Lancaster",
+				"sent": "2024-05-16T16:10:00-05:00",
+				"status": "Test",
+				"messageType": "Alert",
+				"event": "Test Message"
+			}
+		}
+	]
+}`
+
+func TestAlertsV2FromFeatureCollection(t *testing.T) {
+	var featureCollection geojson_v2.FeatureCollection[AlertPropertiesV2]
+	err := json.Unmarshal([]byte(testAlertsResponse), &featureCollection)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	alerts := AlertsV2FromFeatureCollection(&featureCollection)
+	if len(alerts) != 2 {
+		t.Fatalf("alerts = %d, want 2", len(alerts))
+	}
+
+	alert := alerts[0]
+	if alert.ID != "urn:oid:2.49.0.1.840.0.2" || alert.Type != "wx:Alert" {
+		t.Errorf("ID = %s, Type = %s", alert.ID, alert.Type)
+	}
+	if alert.Status != golang.AlertStatusActual || alert.Severity != golang.AlertSeveritySevere || alert.Response != golang.AlertResponseShelter {
+		t.Errorf("enums = %s %s %s", alert.Status, alert.Severity, alert.Response)
+	}
+	if !alert.Expires.Equal(time.Date(2024, 5, 16, 21, 45, 0, 0, time.UTC)) {
+		t.Errorf("Expires = %v", alert.Expires)
+	}
+	if len(alert.References) != 1 || alert.References[0] != "urn:oid:2.49.0.1.840.0.1" {
+		t.Errorf("References = %v", alert.References)
+	}
+	if alert.Geocode == nil || len(alert.Geocode.UGC) != 2 || alert.Geometry == nil || alert.Geometry.Polygon == nil {
+		t.Errorf("Geocode = %+v, Geometry = %+v", alert.Geocode, alert.Geometry)
+	}
+
+	// The second alert only carries its id on the feature.
+	if alerts[1].ID != "urn:oid:2.49.0.1.840.0.3" || alerts[1].Geometry != nil {
+		t.Errorf("second alert = %+v", alerts[1])
+	}
+}
+
+func TestAlertsV2FeatureCollectionRoundTrip(t *testing.T) {
+	var featureCollection geojson_v2.FeatureCollection[AlertPropertiesV2]
+	err := json.Unmarshal([]byte(testAlertsResponse), &featureCollection)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	alerts := AlertsV2FromFeatureCollection(&featureCollection)
+	marshalled, err := json.Marshal(AlertsV2ToFeatureCollection(alerts))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var decoded geojson_v2.FeatureCollection[AlertPropertiesV2]
+	err = json.Unmarshal(marshalled, &decoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	roundTripped := AlertsV2FromFeatureCollection(&decoded)
+	want, _ := json.Marshal(alerts)
+	got, _ := json.Marshal(roundTripped)
+	if string(got) != string(want) {
+		t.Errorf("round trip\n got: %s\nwant: %s", got, want)
+	}
+
+	// Only the identifier of a reference survives the conversion.
+	reference := decoded.Features[0].Properties.References[0]
+	if reference.Identifier != "urn:oid:2.49.0.1.840.0.1" || reference.AtID != "" || reference.Sender != "" || !reference.Sent.IsZero() {
+		t.Errorf("reference = %+v", reference)
+	}
+}
+
+func TestAlertsV2FromFeatureCollectionSkipsNilFeatures(t *testing.T) {
+	featureCollection := &geojson_v2.FeatureCollection[AlertPropertiesV2]{
+		Features: []*geojson_v2.Feature[AlertPropertiesV2]{nil, {ID: "urn:oid:1"}},
+	}
+
+	alerts := AlertsV2FromFeatureCollection(featureCollection)
+	if len(alerts) != 1 || alerts[0].ID != "urn:oid:1" {
+		t.Errorf("alerts = %+v", alerts)
+	}
+}
@@ -0,0 +1,29 @@
+package data_structures
+
+import (
+	"testing"
+
+	"github.com/cmeyer18/weather-common/v6/data_structures/vtec"
+)
+
+func TestAlertV2VTECSkipsMalformedCodes(t *testing.T) {
+	alert := AlertV2{Parameters: map[string]interface{}{
+		"VTEC": []interface{}{
+			"/O.NEW.KOAX.SV.W.0100.240516T2106Z-240516T2145Z/",
+			"/O.XYZ.KOAX.SV.W.0100.240516T2106Z-240516T2145Z/",
+			"/O.CON.KOAX.TO.A.0250.000000T0000Z-240517T0300Z/",
+		},
+	}}
+
+	codes, err := alert.VTEC()
+	if err == nil {
+		t.Error("err = nil, want the malformed code reported")
+	}
+
+	if len(codes) != 2 {
+		t.Fatalf("codes = %d, want 2", len(codes))
+	}
+	if codes[0].Action != vtec.Action_New || codes[1].Action != vtec.Action_Continue {
+		t.Errorf("actions = %s %s, want NEW CON", codes[0].Action, codes[1].Action)
+	}
+}
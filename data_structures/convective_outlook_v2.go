@@ -20,3 +20,34 @@ type ConvectiveOutlookV2 struct {
 	Stroke      string                       `json:"stroke"`
 	Fill        string                       `json:"fill"`
 }
+
+// MergeConvectiveOutlooksByDN combines outlooks sharing an ID, outlook type and DN into one outlook whose geometry is the
+// union of their geometries. The other fields are taken from the first outlook of each group, and groups are returned in
+// the order they first appear.
+func MergeConvectiveOutlooksByDN(outlooks []ConvectiveOutlookV2) []ConvectiveOutlookV2 {
+	type outlookKey struct {
+		id          string
+		outlookType golang.ConvectiveOutlookType
+		dn          int
+	}
+
+	var merged []ConvectiveOutlookV2
+	indexes := make(map[outlookKey]int)
+	for _, outlook := range outlooks {
+		key := outlookKey{id: outlook.ID, outlookType: outlook.OutlookType, dn: outlook.DN}
+		index, ok := indexes[key]
+		if !ok {
+			indexes[key] = len(merged)
+			merged = append(merged, outlook)
+			continue
+		}
+
+		if merged[index].Geometry == nil {
+			merged[index].Geometry = outlook.Geometry
+		} else if outlook.Geometry != nil {
+			merged[index].Geometry = merged[index].Geometry.Union(outlook.Geometry)
+		}
+	}
+
+	return merged
+}
@@ -0,0 +1,107 @@
+package data_structures
+
+import (
+	"time"
+
+	"github.com/cmeyer18/weather-common/v6/data_structures/geojson_v2"
+	"github.com/cmeyer18/weather-common/v6/generative/golang"
+)
+
+// convectiveOutlookTimeLayout is the layout SPC uses for the VALID, EXPIRE and ISSUE properties, always in UTC.
+const convectiveOutlookTimeLayout = "200601021504"
+
+// ConvectiveOutlookPropertiesV2 is the properties member of an SPC convective outlook GeoJSON feature.
+type ConvectiveOutlookPropertiesV2 struct {
+	DN     int    `json:"DN"`
+	Valid  string `json:"VALID"`
+	Expire string `json:"EXPIRE"`
+	Issue  string `json:"ISSUE"`
+	Label  string `json:"LABEL"`
+	Label2 string `json:"LABEL2"`
+	Stroke string `json:"stroke"`
+	Fill   string `json:"fill"`
+}
+
+// ConvectiveOutlooksV2FromFeatureCollection converts an SPC outlook FeatureCollection into outlooks. SPC does not ship
+// an identifier or outlook type in the features, so both are supplied by the caller.
+func ConvectiveOutlooksV2FromFeatureCollection(id string, outlookType golang.ConvectiveOutlookType, featureCollection *geojson_v2.FeatureCollection[ConvectiveOutlookPropertiesV2]) ([]ConvectiveOutlookV2, error) {
+	outlooks := make([]ConvectiveOutlookV2, 0, len(featureCollection.Features))
+	for _, feature := range featureCollection.Features {
+		if feature == nil {
+			continue
+		}
+
+		properties := feature.Properties
+
+		valid, err := parseConvectiveOutlookTime(properties.Valid)
+		if err != nil {
+			return nil, err
+		}
+
+		expires, err := parseConvectiveOutlookTime(properties.Expire)
+		if err != nil {
+			return nil, err
+		}
+
+		issued, err := parseConvectiveOutlookTime(properties.Issue)
+		if err != nil {
+			return nil, err
+		}
+
+		outlooks = append(outlooks, ConvectiveOutlookV2{
+			ID:          id,
+			OutlookType: outlookType,
+			Geometry:    feature.Geometry,
+			DN:          properties.DN,
+			Valid:       valid,
+			Expires:     expires,
+			Issued:      issued,
+			Label:       properties.Label,
+			Label2:      properties.Label2,
+			Stroke:      properties.Stroke,
+			Fill:        properties.Fill,
+		})
+	}
+
+	return outlooks, nil
+}
+
+// ConvectiveOutlooksV2ToFeatureCollection converts outlooks into a FeatureCollection shaped like the SPC outlook GeoJSON.
+func ConvectiveOutlooksV2ToFeatureCollection(outlooks []ConvectiveOutlookV2) *geojson_v2.FeatureCollection[ConvectiveOutlookPropertiesV2] {
+	features := make([]*geojson_v2.Feature[ConvectiveOutlookPropertiesV2], 0, len(outlooks))
+	for _, outlook := range outlooks {
+		features = append(features, &geojson_v2.Feature[ConvectiveOutlookPropertiesV2]{
+			Geometry: outlook.Geometry,
+			Properties: ConvectiveOutlookPropertiesV2{
+				DN:     outlook.DN,
+				Valid:  formatConvectiveOutlookTime(outlook.Valid),
+				Expire: formatConvectiveOutlookTime(outlook.Expires),
+				Issue:  formatConvectiveOutlookTime(outlook.Issued),
+				Label:  outlook.Label,
+				Label2: outlook.Label2,
+				Stroke: outlook.Stroke,
+				Fill:   outlook.Fill,
+			},
+		})
+	}
+
+	return &geojson_v2.FeatureCollection[ConvectiveOutlookPropertiesV2]{
+		Features: features,
+	}
+}
+
+func parseConvectiveOutlookTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	return time.Parse(convectiveOutlookTimeLayout, value)
+}
+
+func formatConvectiveOutlookTime(value time.Time) string {
+	if value.IsZero() {
+		return ""
+	}
+
+	return value.UTC().Format(convectiveOutlookTimeLayout)
+}
@@ -0,0 +1,67 @@
+package data_structures
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/cmeyer18/weather-common/v6/data_structures/geojson_v2"
+	"github.com/cmeyer18/weather-common/v6/generative/golang"
+)
+
+func TestMergeConvectiveOutlooksByDN(t *testing.T) {
+	// Day 1 categorical features as SPC issues them, with the slight risk split into pieces that share an edge and a
+	// separate piece further east.
+	data := `{"type":"FeatureCollection","features":[
+		{"type":"Feature","properties":{"DN":3,"LABEL":"MRGL"},"geometry":{"type":"Polygon","coordinates":[[[-102.5,35.5],[-92.5,35.5],[-92.5,42.5],[-102.5,42.5],[-102.5,35.5]]]}},
+		{"type":"Feature","properties":{"DN":4,"LABEL":"SLGT"},"geometry":{"type":"Polygon","coordinates":[[[-100.25,37.5],[-97.5,37.5],[-97.5,40.75],[-100.25,40.75],[-100.25,37.5]]]}},
+		{"type":"Feature","properties":{"DN":4,"LABEL":"SLGT"},"geometry":{"type":"Polygon","coordinates":[[[-97.5,37.5],[-94.5,37.5],[-94.5,40.75],[-97.5,40.75],[-97.5,37.5]]]}},
+		{"type":"Feature","properties":{"DN":4,"LABEL":"SLGT"},"geometry":{"type":"Polygon","coordinates":[[[-90.5,36.5],[-88.5,36.5],[-88.5,38.5],[-90.5,38.5],[-90.5,36.5]]]}}
+	]}`
+
+	var featureCollection geojson_v2.FeatureCollection[map[string]interface{}]
+	err := json.Unmarshal([]byte(data), &featureCollection)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var outlooks []ConvectiveOutlookV2
+	for _, feature := range featureCollection.Features {
+		outlooks = append(outlooks, ConvectiveOutlookV2{
+			ID:          "day1",
+			OutlookType: golang.Day1Categorical,
+			Geometry:    feature.Geometry,
+			DN:          int(feature.Properties["DN"].(float64)),
+			Label:       feature.Properties["LABEL"].(string),
+		})
+	}
+
+	merged := MergeConvectiveOutlooksByDN(outlooks)
+	if len(merged) != 2 {
+		t.Fatalf("merged outlooks = %d, want 2", len(merged))
+	}
+
+	if merged[0].DN != 3 || merged[0].Geometry.Polygon == nil {
+		t.Errorf("marginal risk = %+v, want the unchanged polygon", merged[0])
+	}
+
+	slight := merged[1]
+	if slight.DN != 4 || slight.Label != "SLGT" {
+		t.Errorf("slight risk = DN %d label %s", slight.DN, slight.Label)
+	}
+	if slight.Geometry.MultiPolygon == nil || len(slight.Geometry.MultiPolygon.Polygons) != 2 {
+		t.Fatalf("slight risk geometry = %+v, want the shared edge dissolved into two polygons", slight.Geometry)
+	}
+
+	kansas := slight.Geometry.MultiPolygon.Polygons[0]
+	if !kansas.Contains(geojson_v2.Point{Latitude: 39, Longitude: -97.5}) {
+		t.Error("shared edge is not inside the merged polygon")
+	}
+	for _, point := range kansas.OuterPath.Points {
+		if point.Longitude != -100.25 && point.Longitude != -97.5 && point.Longitude != -94.5 {
+			t.Errorf("vertex longitude %v is not an input coordinate", point.Longitude)
+		}
+		if point.Latitude != 37.5 && point.Latitude != 40.75 {
+			t.Errorf("vertex latitude %v is not an input coordinate", point.Latitude)
+		}
+	}
+}
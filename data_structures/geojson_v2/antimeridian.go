@@ -0,0 +1,203 @@
+package geojson_v2
+
+import "math"
+
+// antimeridianPrecision is the precision in degrees that positions produced by splitting are rounded to, removing the
+// rounding error picked up by clipping them in a planar projection.
+const antimeridianPrecision = 1e-9
+
+// CrossesAntimeridian reports whether any line or ring of the geometry steps more than 180 degrees of longitude between
+// neighboring positions, which RFC 7946 reads as crossing the antimeridian.
+func (g *Geometry) CrossesAntimeridian() bool {
+	for _, path := range geometryPaths(g) {
+		for i := 1; i < len(path); i++ {
+			if crossesAntimeridian(path[i-1].Longitude, path[i].Longitude) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// SplitAntimeridian returns a copy of the geometry with every line and polygon crossing the antimeridian cut into
+// pieces on either side of it, as RFC 7946 section 3.1.9 recommends. Crossing lines become a MultiLineString and
+// crossing polygons become a MultiPolygon. Polygons enclosing a pole are left as they are.
+func (g *Geometry) SplitAntimeridian() *Geometry {
+	if g == nil {
+		return nil
+	}
+
+	split := &Geometry{IncludeBBox: g.IncludeBBox, Encoding: g.Encoding}
+	if g.LineString != nil {
+		paths := splitPathAtAntimeridian(g.LineString.Points)
+		if len(paths) == 1 {
+			split.LineString = &LineString{Points: paths[0]}
+		} else {
+			split.MultiLineString = &MultiLineString{LineStrings: lineStrings(paths)}
+		}
+	} else if g.MultiLineString != nil {
+		var paths [][]*Point
+		for _, lineString := range g.MultiLineString.LineStrings {
+			paths = append(paths, splitPathAtAntimeridian(lineString.Points)...)
+		}
+		split.MultiLineString = &MultiLineString{LineStrings: lineStrings(paths)}
+	} else if g.Polygon != nil {
+		polygons := splitPolygonAtAntimeridian(g.Polygon)
+		if len(polygons) == 1 {
+			split.Polygon = polygons[0]
+		} else {
+			split.MultiPolygon = &MultiPolygon{Polygons: polygons}
+		}
+	} else if g.MultiPolygon != nil {
+		var polygons []*Polygon
+		for _, polygon := range g.MultiPolygon.Polygons {
+			polygons = append(polygons, splitPolygonAtAntimeridian(polygon)...)
+		}
+		split.MultiPolygon = &MultiPolygon{Polygons: polygons}
+	} else if g.GeometryCollection != nil {
+		collection := &GeometryCollection{}
+		for _, geometry := range g.GeometryCollection.Geometries {
+			collection.Geometries = append(collection.Geometries, geometry.SplitAntimeridian())
+		}
+		split.GeometryCollection = collection
+	} else {
+		return g.Clone()
+	}
+
+	return split
+}
+
+// longitudeStep returns the change in longitude going the short way around from one longitude to the next. A step
+// between -180 and 180 stays on the same side of the world.
+func longitudeStep(from, to float64) float64 {
+	delta := to - from
+	if delta > 180 && delta < 360 {
+		return delta - 360
+	} else if delta < -180 && delta > -360 {
+		return delta + 360
+	}
+	return delta
+}
+
+func crossesAntimeridian(from, to float64) bool {
+	return longitudeStep(from, to) != to-from
+}
+
+// splitPathAtAntimeridian cuts the path wherever it crosses the antimeridian, adding a position on each side of the
+// cut at the latitude where the segment meets it.
+func splitPathAtAntimeridian(points []*Point) [][]*Point {
+	var paths [][]*Point
+	var current []*Point
+	for i, point := range points {
+		if i > 0 && crossesAntimeridian(points[i-1].Longitude, point.Longitude) {
+			previous := points[i-1]
+			step := longitudeStep(previous.Longitude, point.Longitude)
+			boundary := math.Copysign(180, step)
+
+			fraction := (boundary - previous.Longitude) / step
+			latitude := previous.Latitude + fraction*(point.Latitude-previous.Latitude)
+
+			if previous.Longitude != boundary {
+				current = append(current, &Point{Latitude: latitude, Longitude: boundary})
+			}
+			if len(current) > 1 {
+				paths = append(paths, current)
+			}
+
+			current = nil
+			if point.Longitude != -boundary {
+				current = append(current, &Point{Latitude: latitude, Longitude: -boundary})
+			}
+		}
+		current = append(current, point.Clone())
+	}
+
+	if len(current) > 1 || len(paths) == 0 {
+		paths = append(paths, current)
+	}
+	return paths
+}
+
+func lineStrings(paths [][]*Point) []*LineString {
+	lineStrings := make([]*LineString, len(paths))
+	for i, path := range paths {
+		lineStrings[i] = &LineString{Points: path}
+	}
+	return lineStrings
+}
+
+// splitPolygonAtAntimeridian unwraps the rings of the polygon into one continuous range of longitude and clips it into
+// each 360 degree band, shifting every piece back into [-180, 180].
+func splitPolygonAtAntimeridian(polygon *Polygon) []*Polygon {
+	if polygon.OuterPath == nil || !(&Geometry{Polygon: polygon}).CrossesAntimeridian() {
+		return []*Polygon{polygon.Clone()}
+	}
+
+	outerPath, closed := unwrapRing(closedRingPoints(polygon.OuterPath), 0)
+	if !closed {
+		return []*Polygon{polygon.Clone()}
+	}
+
+	minLongitude, maxLongitude := math.Inf(1), math.Inf(-1)
+	for _, point := range outerPath {
+		minLongitude = min(minLongitude, point.Longitude)
+		maxLongitude = max(maxLongitude, point.Longitude)
+	}
+
+	unwrapped := &Polygon{OuterPath: &MultiPoint{Points: outerPath}}
+	for _, innerPath := range polygon.InnerPaths {
+		hole, closed := unwrapRing(closedRingPoints(innerPath), (minLongitude+maxLongitude)/2)
+		if closed {
+			unwrapped.InnerPaths = append(unwrapped.InnerPaths, &MultiPoint{Points: hole})
+		}
+	}
+
+	var pieces []*Polygon
+	for band := math.Floor((minLongitude + 180) / 360); band*360-180 < maxLongitude; band++ {
+		offset := band * 360
+		clip := &Geometry{Polygon: &Polygon{OuterPath: &MultiPoint{Points: []*Point{
+			{Longitude: offset - 180, Latitude: -90},
+			{Longitude: offset + 180, Latitude: -90},
+			{Longitude: offset + 180, Latitude: 90},
+			{Longitude: offset - 180, Latitude: 90},
+			{Longitude: offset - 180, Latitude: -90},
+		}}}}
+
+		clipped := (&Geometry{Polygon: unwrapped}).Intersection(clip).polygons()
+		for _, piece := range clipped.Polygons {
+			shiftPolygon(piece, -offset)
+			pieces = append(pieces, piece)
+		}
+	}
+
+	return pieces
+}
+
+// unwrapRing returns a copy of the ring with each longitude moved by whole turns to be the short way around from the
+// previous one, starting within 180 degrees of the reference longitude. It also reports whether the ring closes
+// without winding around a pole.
+func unwrapRing(points []*Point, reference float64) ([]*Point, bool) {
+	if len(points) == 0 {
+		return nil, false
+	}
+
+	longitude := reference + wrapLongitude(points[0].Longitude-reference)
+	unwrapped := []*Point{{Latitude: points[0].Latitude, Longitude: longitude}}
+	for i := 1; i < len(points); i++ {
+		longitude += longitudeStep(points[i-1].Longitude, points[i].Longitude)
+		unwrapped = append(unwrapped, &Point{Latitude: points[i].Latitude, Longitude: longitude})
+	}
+
+	return unwrapped, math.Abs(longitude-unwrapped[0].Longitude) < 180
+}
+
+// shiftPolygon moves every position of the polygon by the offset in degrees of longitude, rounding them to
+// antimeridianPrecision.
+func shiftPolygon(polygon *Polygon, offset float64) {
+	for _, ring := range append([]*MultiPoint{polygon.OuterPath}, polygon.InnerPaths...) {
+		for _, point := range ring.Points {
+			point.Longitude = math.Round((point.Longitude+offset)/antimeridianPrecision) * antimeridianPrecision
+			point.Latitude = math.Round(point.Latitude/antimeridianPrecision) * antimeridianPrecision
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package geojson_v2
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+)
+
+func TestUnmarshalSplitsAtAntimeridian(t *testing.T) {
+	tests := []struct {
+		name     string
+		geometry string
+		want     string
+	}{
+		{name: "line",
+			geometry: `{"type":"LineString","coordinates":[[179,0],[-179,2]]}`,
+			want:     `{"type":"MultiLineString","coordinates":[[[179,0],[180,1]],[[-180,1],[-179,2]]]}`},
+		{name: "line ending on the antimeridian",
+			geometry: `{"type":"LineString","coordinates":[[179,0],[180,1]]}`,
+			want:     `{"type":"LineString","coordinates":[[179,0],[180,1]]}`},
+		{name: "line crossing twice",
+			geometry: `{"type":"MultiLineString","coordinates":[[[179,0],[-179,0],[179,2]]]}`,
+			want:     `{"type":"MultiLineString","coordinates":[[[179,0],[180,0]],[[-180,0],[-179,0],[-180,1]],[[180,1],[179,2]]]}`},
+		{name: "polygon",
+			geometry: `{"type":"Polygon","coordinates":[[[179,50],[-179,50],[-179,52],[179,52],[179,50]]]}`,
+			want: `{"type":"MultiPolygon","coordinates":[[[[179,50],[180,50],[180,52],[179,52],[179,50]]],` +
+				`[[[-180,50],[-179,50],[-179,52],[-180,52],[-180,50]]]]}`},
+		{name: "polygon around a pole",
+			geometry: `{"type":"Polygon","coordinates":[[[0,80],[120,80],[-120,80],[0,80]]]}`,
+			want:     `{"type":"Polygon","coordinates":[[[0,80],[120,80],[-120,80],[0,80]]]}`},
+		{name: "collection member",
+			geometry: `{"type":"GeometryCollection","geometries":[{"type":"Point","coordinates":[179,0]},{"type":"LineString","coordinates":[[179,0],[-179,2]]}]}`,
+			want: `{"type":"GeometryCollection","geometries":[{"type":"Point","coordinates":[179,0]},` +
+				`{"type":"MultiLineString","coordinates":[[[179,0],[180,1]],[[-180,1],[-179,2]]]}]}`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			marshalled, err := json.Marshal(mustGeometry(t, test.geometry))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(marshalled) != test.want {
+				t.Errorf("got %s, want %s", marshalled, test.want)
+			}
+		})
+	}
+}
+
+func TestSplitPolygonWithHoleAtAntimeridian(t *testing.T) {
+	geometry := mustGeometry(t, `{"type":"Polygon","coordinates":[[[178,50],[-178,50],[-178,54],[178,54],[178,50]],`+
+		`[[179,51],[179,53],[-179,53],[-179,51],[179,51]]]}`)
+
+	if geometry.MultiPolygon == nil || len(geometry.MultiPolygon.Polygons) != 2 {
+		t.Fatalf("geometry = %+v, want a MultiPolygon of two halves", geometry)
+	}
+	if geometry.CrossesAntimeridian() {
+		t.Error("split geometry still crosses the antimeridian")
+	}
+
+	// Each half keeps 8 of the 16 square degrees of the shell less half of the 4 square degree hole.
+	for _, polygon := range geometry.MultiPolygon.Polygons {
+		if area := planarArea(&Geometry{Polygon: polygon}); math.Abs(area-6) > 1e-9 {
+			t.Errorf("half has area %v, want 6", area)
+		}
+	}
+
+	for _, point := range []Point{{Longitude: 178.5, Latitude: 52}, {Longitude: -178.5, Latitude: 52}} {
+		if !geometry.Contains(point) {
+			t.Errorf("split geometry does not contain %+v", point)
+		}
+	}
+	for _, point := range []Point{{Longitude: 179.5, Latitude: 52}, {Longitude: -179.5, Latitude: 52}} {
+		if geometry.Contains(point) {
+			t.Errorf("split geometry contains %+v inside the hole", point)
+		}
+	}
+
+	bbox := geometry.BBox()
+	if bbox == nil || !bbox.CrossesAntimeridian() || bbox.MinLongitude != 178 || bbox.MaxLongitude != -178 {
+		t.Errorf("bbox = %+v, want a box wrapping from 178 to -178", bbox)
+	}
+}
@@ -0,0 +1,267 @@
+package geojson_v2
+
+import (
+	"cmp"
+	"math"
+	"slices"
+)
+
+// planarEdge is a directed edge in a local planar projection.
+type planarEdge struct {
+	a vec
+	b vec
+}
+
+func (e planarEdge) midpoint() vec {
+	return e.a.add(e.b).scale(0.5)
+}
+
+// vertexSnapper merges positions closer than planarTolerance so that edges meeting at a computed intersection share
+// exactly the same vertex.
+type vertexSnapper struct {
+	cells map[[2]int64][]vec
+}
+
+func newVertexSnapper() *vertexSnapper {
+	return &vertexSnapper{cells: make(map[[2]int64][]vec)}
+}
+
+// snap returns an already seen position within planarTolerance of v, or records and returns v.
+func (s *vertexSnapper) snap(v vec) vec {
+	cellX := int64(math.Floor(v.x / planarTolerance))
+	cellY := int64(math.Floor(v.y / planarTolerance))
+	for dx := int64(-1); dx <= 1; dx++ {
+		for dy := int64(-1); dy <= 1; dy++ {
+			for _, existing := range s.cells[[2]int64{cellX + dx, cellY + dy}] {
+				if existing.sub(v).length() <= planarTolerance {
+					return existing
+				}
+			}
+		}
+	}
+
+	cell := [2]int64{cellX, cellY}
+	s.cells[cell] = append(s.cells[cell], v)
+	return v
+}
+
+// nodeEdges splits the edges wherever they touch or cross another edge, so that edges only meet at their endpoints.
+// Edges that collapse to a single position are dropped.
+func nodeEdges(edges []planarEdge) []planarEdge {
+	snapper := newVertexSnapper()
+
+	var snapped []planarEdge
+	for _, edge := range edges {
+		edge = planarEdge{a: snapper.snap(edge.a), b: snapper.snap(edge.b)}
+		if edge.a != edge.b {
+			snapped = append(snapped, edge)
+		}
+	}
+	edges = snapped
+
+	splits := make([][]vec, len(edges))
+	order := make([]int, len(edges))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortFunc(order, func(i, j int) int {
+		return cmp.Compare(min(edges[i].a.x, edges[i].b.x), min(edges[j].a.x, edges[j].b.x))
+	})
+
+	for oi, i := range order {
+		maxX := max(edges[i].a.x, edges[i].b.x) + planarTolerance
+		for _, j := range order[oi+1:] {
+			if min(edges[j].a.x, edges[j].b.x) > maxX {
+				break
+			}
+
+			if min(edges[i].a.y, edges[i].b.y) > max(edges[j].a.y, edges[j].b.y)+planarTolerance ||
+				min(edges[j].a.y, edges[j].b.y) > max(edges[i].a.y, edges[i].b.y)+planarTolerance {
+				continue
+			}
+
+			for _, intersection := range edgeIntersections(edges[i], edges[j]) {
+				intersection = snapper.snap(intersection)
+				splits[i] = append(splits[i], intersection)
+				splits[j] = append(splits[j], intersection)
+			}
+		}
+	}
+
+	var noded []planarEdge
+	for i, edge := range edges {
+		if len(splits[i]) == 0 {
+			noded = append(noded, edge)
+			continue
+		}
+
+		direction := edge.b.sub(edge.a)
+		points := append(splits[i], edge.a, edge.b)
+		slices.SortFunc(points, func(p, q vec) int {
+			return cmp.Compare(p.sub(edge.a).dot(direction), q.sub(edge.a).dot(direction))
+		})
+		points = slices.Compact(points)
+
+		for k := 1; k < len(points); k++ {
+			noded = append(noded, planarEdge{a: points[k-1], b: points[k]})
+		}
+	}
+
+	return noded
+}
+
+// edgeIntersections returns the positions where the edges cross or touch, including the endpoints of overlapping
+// collinear edges.
+func edgeIntersections(e, f planarEdge) []vec {
+	r := e.b.sub(e.a)
+	s := f.b.sub(f.a)
+	denominator := r.cross(s)
+	offset := f.a.sub(e.a)
+
+	if math.Abs(denominator) > 1e-12*r.length()*s.length() {
+		t := offset.cross(s) / denominator
+		u := offset.cross(r) / denominator
+		slackT := planarTolerance / r.length()
+		slackU := planarTolerance / s.length()
+		if t < -slackT || t > 1+slackT || u < -slackU || u > 1+slackU {
+			return nil
+		}
+
+		return []vec{e.a.add(r.scale(math.Max(0, math.Min(1, t))))}
+	}
+
+	var intersections []vec
+	for _, endpoint := range []vec{f.a, f.b} {
+		if vecSegmentDistance(endpoint, e.a, e.b) <= planarTolerance {
+			intersections = append(intersections, endpoint)
+		}
+	}
+	for _, endpoint := range []vec{e.a, e.b} {
+		if vecSegmentDistance(endpoint, f.a, f.b) <= planarTolerance {
+			intersections = append(intersections, endpoint)
+		}
+	}
+	return intersections
+}
+
+// assembleRings follows the directed edges into closed rings, taking the leftmost turn wherever several unused edges
+// leave a vertex. Chains that do not close are dropped.
+func assembleRings(edges []planarEdge) [][]vec {
+	outgoing := make(map[vec][]int)
+	for i, edge := range edges {
+		outgoing[edge.a] = append(outgoing[edge.a], i)
+	}
+
+	used := make([]bool, len(edges))
+	var rings [][]vec
+	for start := range edges {
+		if used[start] {
+			continue
+		}
+
+		var ring []vec
+		current := start
+		closed := false
+		for {
+			used[current] = true
+			ring = append(ring, edges[current].a)
+			if edges[current].b == edges[start].a {
+				closed = true
+				break
+			}
+
+			next := leftmostEdge(edges, outgoing[edges[current].b], current, used)
+			if next < 0 {
+				break
+			}
+			current = next
+		}
+
+		if closed && len(ring) >= 3 {
+			rings = append(rings, ring)
+		}
+	}
+
+	return rings
+}
+
+// leftmostEdge returns the unused candidate edge turning furthest left from the incoming edge, or -1 when there is
+// none. Turning straight back along the incoming edge is the least preferred.
+func leftmostEdge(edges []planarEdge, candidates []int, incoming int, used []bool) int {
+	direction := edges[incoming].b.sub(edges[incoming].a)
+
+	best := -1
+	bestTurn := math.Inf(-1)
+	for _, candidate := range candidates {
+		if used[candidate] {
+			continue
+		}
+
+		turn := -math.Pi
+		if edges[candidate].b != edges[incoming].a {
+			next := edges[candidate].b.sub(edges[candidate].a)
+			turn = math.Atan2(direction.cross(next), direction.dot(next))
+		}
+
+		if turn > bestTurn {
+			best = candidate
+			bestTurn = turn
+		}
+	}
+
+	return best
+}
+
+// polygonsFromRings groups counterclockwise shells with the clockwise holes they contain, returning each polygon as its
+// shell followed by its holes. Holes outside of every shell are dropped.
+func polygonsFromRings(rings [][]vec) [][][]vec {
+	var shells [][]vec
+	var shellAreas []float64
+	var holes [][]vec
+	for _, ring := range rings {
+		area := vecRingSignedArea(ring)
+		if area > 0 {
+			shells = append(shells, ring)
+			shellAreas = append(shellAreas, area)
+		} else if area < 0 {
+			holes = append(holes, ring)
+		}
+	}
+
+	polygons := make([][][]vec, len(shells))
+	for i, shell := range shells {
+		polygons[i] = [][]vec{shell}
+	}
+
+	for _, hole := range holes {
+		owner := -1
+		for i, shell := range shells {
+			if (owner < 0 || shellAreas[i] < shellAreas[owner]) && ringInsideRing(hole, shell) {
+				owner = i
+			}
+		}
+
+		if owner >= 0 {
+			polygons[owner] = append(polygons[owner], hole)
+		}
+	}
+
+	return polygons
+}
+
+// ringInsideRing reports whether the inner ring lies within the outer ring, judged by its first position that is not on
+// the boundary of the outer ring.
+func ringInsideRing(inner, outer []vec) bool {
+	for i, v := range inner {
+		location := locateVecInRing(outer, v)
+		if location == ringLocation_Boundary {
+			location = locateVecInRing(outer, planarEdge{a: v, b: inner[(i+1)%len(inner)]}.midpoint())
+		}
+
+		if location != ringLocation_Boundary {
+			return location == ringLocation_Interior
+		}
+	}
+
+	return true
+}
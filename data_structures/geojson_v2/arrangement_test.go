@@ -0,0 +1,62 @@
+package geojson_v2
+
+import "testing"
+
+func TestNodeEdges(t *testing.T) {
+	edges := nodeEdges([]planarEdge{
+		{a: vec{x: 0, y: 0}, b: vec{x: 10, y: 10}},
+		{a: vec{x: 0, y: 10}, b: vec{x: 10, y: 0}},
+		{a: vec{x: 20, y: 20}, b: vec{x: 20, y: 20.0001}},
+	})
+
+	if len(edges) != 4 {
+		t.Fatalf("edges = %+v, want the crossing edges split in two and the collapsed edge dropped", edges)
+	}
+	for _, edge := range edges {
+		if edge.a != (vec{x: 5, y: 5}) && edge.b != (vec{x: 5, y: 5}) {
+			t.Errorf("edge %+v does not meet the others at the crossing", edge)
+		}
+	}
+}
+
+func TestAssembleRings(t *testing.T) {
+	// Two squares sharing the corner at (10, 10), traced counterclockwise, and a dangling edge.
+	square := func(x, y float64) []planarEdge {
+		corners := []vec{{x: x, y: y}, {x: x + 10, y: y}, {x: x + 10, y: y + 10}, {x: x, y: y + 10}}
+		var edges []planarEdge
+		for i := range corners {
+			edges = append(edges, planarEdge{a: corners[i], b: corners[(i+1)%len(corners)]})
+		}
+		return edges
+	}
+	edges := append(square(0, 0), square(10, 10)...)
+	edges = append(edges, planarEdge{a: vec{x: 30, y: 30}, b: vec{x: 40, y: 40}})
+
+	rings := assembleRings(edges)
+	if len(rings) != 2 {
+		t.Fatalf("rings = %+v, want two", rings)
+	}
+	for _, ring := range rings {
+		if area := vecRingSignedArea(ring); area != 100 {
+			t.Errorf("ring area = %v, want 100", area)
+		}
+	}
+}
+
+func TestPolygonsFromRings(t *testing.T) {
+	shell := []vec{{x: 0, y: 0}, {x: 10, y: 0}, {x: 10, y: 10}, {x: 0, y: 10}}
+	innerShell := []vec{{x: 4, y: 4}, {x: 6, y: 4}, {x: 6, y: 6}, {x: 4, y: 6}}
+	hole := []vec{{x: 2, y: 2}, {x: 2, y: 8}, {x: 8, y: 8}, {x: 8, y: 2}}
+	strayHole := []vec{{x: 20, y: 20}, {x: 20, y: 21}, {x: 21, y: 21}, {x: 21, y: 20}}
+
+	polygons := polygonsFromRings([][]vec{hole, shell, strayHole, innerShell})
+	if len(polygons) != 2 {
+		t.Fatalf("polygons = %+v, want two", polygons)
+	}
+	if len(polygons[0]) != 2 || vecRingSignedArea(polygons[0][1]) != -36 {
+		t.Errorf("outer polygon = %+v, want the shell with its hole", polygons[0])
+	}
+	if len(polygons[1]) != 1 {
+		t.Errorf("island polygon = %+v, want no holes", polygons[1])
+	}
+}
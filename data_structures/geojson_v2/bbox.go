@@ -0,0 +1,274 @@
+package geojson_v2
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+)
+
+// BBox is an axis aligned bounding box in degrees. Following RFC 7946, a box crossing the antimeridian has a
+// MinLongitude, its western edge, greater than its MaxLongitude, its eastern edge.
+type BBox struct {
+	MinLongitude float64
+	MinLatitude  float64
+	MaxLongitude float64
+	MaxLatitude  float64
+}
+
+func (b *BBox) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]float64{b.MinLongitude, b.MinLatitude, b.MaxLongitude, b.MaxLatitude})
+}
+
+func (b *BBox) UnmarshalJSON(data []byte) error {
+	var coordinates []float64
+	if err := json.Unmarshal(data, &coordinates); err != nil {
+		return err
+	}
+
+	switch len(coordinates) {
+	case 4:
+		*b = BBox{MinLongitude: coordinates[0], MinLatitude: coordinates[1], MaxLongitude: coordinates[2], MaxLatitude: coordinates[3]}
+	case 6:
+		*b = BBox{MinLongitude: coordinates[0], MinLatitude: coordinates[1], MaxLongitude: coordinates[3], MaxLatitude: coordinates[4]}
+	default:
+		return fmt.Errorf("invalid number of coordinates for BBox: %v", coordinates)
+	}
+
+	return nil
+}
+
+// Contains reports whether the point is inside or on the edge of the box.
+func (b BBox) Contains(point Point) bool {
+	if point.Latitude < b.MinLatitude || point.Latitude > b.MaxLatitude {
+		return false
+	}
+
+	if b.CrossesAntimeridian() {
+		return point.Longitude >= b.MinLongitude || point.Longitude <= b.MaxLongitude
+	}
+	return point.Longitude >= b.MinLongitude && point.Longitude <= b.MaxLongitude
+}
+
+// Intersects reports whether the boxes overlap or touch.
+func (b BBox) Intersects(other BBox) bool {
+	if !b.CrossesAntimeridian() && !other.CrossesAntimeridian() {
+		return b.MinLongitude <= other.MaxLongitude && other.MinLongitude <= b.MaxLongitude &&
+			b.MinLatitude <= other.MaxLatitude && other.MinLatitude <= b.MaxLatitude
+	}
+
+	for _, part := range b.Split() {
+		for _, otherPart := range other.Split() {
+			if part.Intersects(otherPart) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Extend returns the smallest box covering both boxes, which crosses the antimeridian when that is narrower than going
+// the other way around.
+func (b BBox) Extend(other BBox) BBox {
+	west, east := coverLongitudes(b, other)
+	return BBox{
+		MinLongitude: west,
+		MinLatitude:  min(b.MinLatitude, other.MinLatitude),
+		MaxLongitude: east,
+		MaxLatitude:  max(b.MaxLatitude, other.MaxLatitude),
+	}
+}
+
+// CrossesAntimeridian reports whether the box wraps from its western edge across 180 degrees to its eastern edge.
+func (b BBox) CrossesAntimeridian() bool {
+	return b.MinLongitude > b.MaxLongitude
+}
+
+// Split returns the box as one box, or as two boxes on either side of the antimeridian when it crosses it.
+func (b BBox) Split() []BBox {
+	if !b.CrossesAntimeridian() {
+		return []BBox{b}
+	}
+
+	return []BBox{
+		{MinLongitude: b.MinLongitude, MinLatitude: b.MinLatitude, MaxLongitude: 180, MaxLatitude: b.MaxLatitude},
+		{MinLongitude: -180, MinLatitude: b.MinLatitude, MaxLongitude: b.MaxLongitude, MaxLatitude: b.MaxLatitude},
+	}
+}
+
+// Center returns the point halfway between the edges of the box.
+func (b BBox) Center() Point {
+	return Point{
+		Latitude:  (b.MinLatitude + b.MaxLatitude) / 2,
+		Longitude: wrapLongitude(b.MinLongitude + b.longitudeSpan()/2),
+	}
+}
+
+// Geometry returns the box as a Polygon, or as a MultiPolygon of its two halves when it crosses the antimeridian.
+func (b BBox) Geometry() *Geometry {
+	var polygons []*Polygon
+	for _, part := range b.Split() {
+		polygons = append(polygons, &Polygon{OuterPath: &MultiPoint{Points: []*Point{
+			{Longitude: part.MinLongitude, Latitude: part.MinLatitude},
+			{Longitude: part.MaxLongitude, Latitude: part.MinLatitude},
+			{Longitude: part.MaxLongitude, Latitude: part.MaxLatitude},
+			{Longitude: part.MinLongitude, Latitude: part.MaxLatitude},
+			{Longitude: part.MinLongitude, Latitude: part.MinLatitude},
+		}}})
+	}
+
+	if len(polygons) == 1 {
+		return &Geometry{Polygon: polygons[0]}
+	}
+	return &Geometry{MultiPolygon: &MultiPolygon{Polygons: polygons}}
+}
+
+// longitudeSpan returns the width of the box in degrees of longitude.
+func (b BBox) longitudeSpan() float64 {
+	if b.CrossesAntimeridian() {
+		return b.MaxLongitude - b.MinLongitude + 360
+	}
+	return b.MaxLongitude - b.MinLongitude
+}
+
+// coverLongitudes returns the western and eastern edges of the narrowest span of longitude covering both boxes.
+func coverLongitudes(a, b BBox) (float64, float64) {
+	bestWest, bestSpan := 0.0, math.Inf(1)
+	for _, west := range []float64{a.MinLongitude, b.MinLongitude} {
+		span := 0.0
+		for _, box := range []BBox{a, b} {
+			span = max(span, math.Mod(box.MinLongitude-west+360, 360)+box.longitudeSpan())
+		}
+
+		if span < bestSpan || (span == bestSpan && west+span <= 180) {
+			bestWest, bestSpan = west, span
+		}
+	}
+
+	return longitudeRange(bestWest, bestSpan)
+}
+
+// longitudeRange returns the western and eastern edges of the span of longitude starting at west, wrapping the eastern
+// edge across the antimeridian when needed.
+func longitudeRange(west, span float64) (float64, float64) {
+	if span >= 360 {
+		return -180, 180
+	}
+
+	if west >= 180 || west < -180 {
+		west = wrapLongitude(west)
+	}
+
+	east := west + span
+	if east > 180 {
+		east -= 360
+	}
+	return west, east
+}
+
+// BBox returns the bounding box of the geometry, or nil when it has no positions.
+func (g *Geometry) BBox() *BBox {
+	if g == nil {
+		return nil
+	}
+
+	if g.Point != nil {
+		return g.Point.BBox()
+	} else if g.MultiPoint != nil {
+		return g.MultiPoint.BBox()
+	} else if g.LineString != nil {
+		return g.LineString.BBox()
+	} else if g.MultiLineString != nil {
+		return g.MultiLineString.BBox()
+	} else if g.Polygon != nil {
+		return g.Polygon.BBox()
+	} else if g.MultiPolygon != nil {
+		return g.MultiPolygon.BBox()
+	} else if g.GeometryCollection != nil {
+		return g.GeometryCollection.BBox()
+	}
+
+	return nil
+}
+
+// BBox returns a zero size box at the point.
+func (p *Point) BBox() *BBox {
+	return &BBox{MinLongitude: p.Longitude, MinLatitude: p.Latitude, MaxLongitude: p.Longitude, MaxLatitude: p.Latitude}
+}
+
+// BBox returns the bounding box of the points, or nil when there are none.
+func (mp *MultiPoint) BBox() *BBox {
+	return pointsBBox(mp.Points)
+}
+
+// BBox returns the bounding box of the line, or nil when it has no positions.
+func (ls *LineString) BBox() *BBox {
+	return pointsBBox(ls.Points)
+}
+
+// BBox returns the bounding box of the lines, or nil when there are none.
+func (mls *MultiLineString) BBox() *BBox {
+	var bbox *BBox
+	for _, lineString := range mls.LineStrings {
+		bbox = extendBBox(bbox, lineString.BBox())
+	}
+	return bbox
+}
+
+// BBox returns the bounding box of the outer path of the polygon.
+func (pg *Polygon) BBox() *BBox {
+	if pg.OuterPath == nil {
+		return nil
+	}
+	return pg.OuterPath.BBox()
+}
+
+// BBox returns the bounding box of the polygons, or nil when there are none.
+func (mpg *MultiPolygon) BBox() *BBox {
+	var bbox *BBox
+	for _, polygon := range mpg.Polygons {
+		bbox = extendBBox(bbox, polygon.BBox())
+	}
+	return bbox
+}
+
+// BBox returns the bounding box of every geometry in the collection, or nil when there are none.
+func (gc *GeometryCollection) BBox() *BBox {
+	var bbox *BBox
+	for _, geometry := range gc.Geometries {
+		bbox = extendBBox(bbox, geometry.BBox())
+	}
+	return bbox
+}
+
+// pointsBBox returns the bounding box of the positions taken as a path, so a step of more than 180 degrees of longitude
+// between neighboring positions crosses the antimeridian.
+func pointsBBox(points []*Point) *BBox {
+	if len(points) == 0 {
+		return nil
+	}
+
+	longitude := points[0].Longitude
+	minLongitude, maxLongitude := longitude, longitude
+	minLatitude, maxLatitude := points[0].Latitude, points[0].Latitude
+	for i := 1; i < len(points); i++ {
+		longitude += longitudeStep(points[i-1].Longitude, points[i].Longitude)
+		minLongitude = min(minLongitude, longitude)
+		maxLongitude = max(maxLongitude, longitude)
+		minLatitude = min(minLatitude, points[i].Latitude)
+		maxLatitude = max(maxLatitude, points[i].Latitude)
+	}
+
+	west, east := longitudeRange(minLongitude, maxLongitude-minLongitude)
+	return &BBox{MinLongitude: west, MinLatitude: minLatitude, MaxLongitude: east, MaxLatitude: maxLatitude}
+}
+
+func extendBBox(bbox *BBox, other *BBox) *BBox {
+	if bbox == nil {
+		return other
+	} else if other == nil {
+		return bbox
+	}
+
+	extended := bbox.Extend(*other)
+	return &extended
+}
@@ -0,0 +1,234 @@
+package geojson_v2
+
+import (
+	"math"
+	"slices"
+)
+
+// bufferSegments is the number of segments used to approximate a full circle when buffering.
+const bufferSegments = 64
+
+// planarShapes holds the positions, paths and polygons of a geometry in a local planar projection. Polygons are stored
+// as a counterclockwise shell followed by clockwise holes, without closing positions.
+type planarShapes struct {
+	points   []vec
+	paths    [][]vec
+	polygons [][][]vec
+}
+
+// Buffer returns the area within the distance in meters of the geometry as a Polygon or MultiPolygon. Curves are
+// approximated with bufferSegments segments per circle. The buffer is computed in an azimuthal equidistant projection
+// centered on the geometry, so it is most accurate for geometries spanning no more than a few hundred kilometers.
+// Buffers reaching across the antimeridian are split there. A copy of the geometry is returned when the distance is not
+// positive.
+func (g *Geometry) Buffer(distance float64) *Geometry {
+	bbox := g.BBox()
+	if bbox == nil || distance <= 0 {
+		return g.Clone()
+	}
+
+	projection := newAzimuthalProjection(*bbox)
+	shapes := &planarShapes{}
+	shapes.add(projection, g)
+
+	var raw []planarEdge
+	for _, point := range shapes.points {
+		raw = append(raw, offsetRing([]vec{point}, distance)...)
+	}
+	for _, path := range shapes.paths {
+		ring := append([]vec{}, path...)
+		for i := len(path) - 2; i > 0; i-- {
+			ring = append(ring, path[i])
+		}
+		raw = append(raw, offsetRing(ring, distance)...)
+	}
+	for _, polygon := range shapes.polygons {
+		for _, ring := range polygon {
+			raw = append(raw, offsetRing(ring, distance)...)
+		}
+	}
+
+	// Chords of the arcs sag below the buffer distance, so keep anything at least as far out as the middle of a chord.
+	threshold := distance*math.Cos(math.Pi/bufferSegments) - planarTolerance
+
+	var boundary []planarEdge
+	for _, edge := range nodeEdges(raw) {
+		midpoint := edge.midpoint()
+		if !shapes.inside(midpoint) && !shapes.within(midpoint, threshold) {
+			boundary = append(boundary, edge)
+		}
+	}
+
+	return inversePolygons(projection, polygonsFromRings(assembleRings(boundary))).SplitAntimeridian()
+}
+
+func (s *planarShapes) add(projection planarProjection, g *Geometry) {
+	if g == nil {
+		return
+	}
+
+	if g.Point != nil {
+		s.points = append(s.points, projection.forward(*g.Point))
+	} else if g.MultiPoint != nil {
+		for _, point := range g.MultiPoint.Points {
+			s.points = append(s.points, projection.forward(*point))
+		}
+	} else if g.LineString != nil {
+		s.addPath(forwardPath(projection, g.LineString.Points))
+	} else if g.MultiLineString != nil {
+		for _, lineString := range g.MultiLineString.LineStrings {
+			s.addPath(forwardPath(projection, lineString.Points))
+		}
+	} else if g.Polygon != nil {
+		s.addPolygon(projection, g.Polygon)
+	} else if g.MultiPolygon != nil {
+		for _, polygon := range g.MultiPolygon.Polygons {
+			s.addPolygon(projection, polygon)
+		}
+	} else if g.GeometryCollection != nil {
+		for _, geometry := range g.GeometryCollection.Geometries {
+			s.add(projection, geometry)
+		}
+	}
+}
+
+func (s *planarShapes) addPath(path []vec) {
+	if len(path) == 1 {
+		s.points = append(s.points, path[0])
+	} else if len(path) > 1 {
+		s.paths = append(s.paths, path)
+	}
+}
+
+// addPolygon adds the polygon with its rings rewound to a counterclockwise shell and clockwise holes. Rings without an
+// area are added as paths.
+func (s *planarShapes) addPolygon(projection planarProjection, polygon *Polygon) {
+	if polygon.OuterPath == nil {
+		return
+	}
+
+	shell := forwardRing(projection, polygon.OuterPath.Points)
+	if vecRingSignedArea(shell) == 0 {
+		s.addPath(shell)
+		return
+	} else if vecRingSignedArea(shell) < 0 {
+		slices.Reverse(shell)
+	}
+
+	rings := [][]vec{shell}
+	for _, innerPath := range polygon.InnerPaths {
+		hole := forwardRing(projection, innerPath.Points)
+		if vecRingSignedArea(hole) == 0 {
+			s.addPath(hole)
+			continue
+		} else if vecRingSignedArea(hole) > 0 {
+			slices.Reverse(hole)
+		}
+		rings = append(rings, hole)
+	}
+
+	s.polygons = append(s.polygons, rings)
+}
+
+// inside reports whether the position is in the interior of any of the polygons.
+func (s *planarShapes) inside(v vec) bool {
+	for _, polygon := range s.polygons {
+		if locateVecInPolygon(polygon, v) == ringLocation_Interior {
+			return true
+		}
+	}
+	return false
+}
+
+// within reports whether the position is closer than the distance to any of the shapes.
+func (s *planarShapes) within(v vec, distance float64) bool {
+	for _, point := range s.points {
+		if point.sub(v).length() < distance {
+			return true
+		}
+	}
+	for _, path := range s.paths {
+		if pathWithin(path, false, v, distance) {
+			return true
+		}
+	}
+	for _, polygon := range s.polygons {
+		for _, ring := range polygon {
+			if pathWithin(ring, true, v, distance) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func pathWithin(path []vec, closed bool, v vec, distance float64) bool {
+	segments := len(path) - 1
+	if closed {
+		segments = len(path)
+	}
+
+	for i := 0; i < segments; i++ {
+		a := path[i]
+		b := path[(i+1)%len(path)]
+		if v.x < min(a.x, b.x)-distance || v.x > max(a.x, b.x)+distance ||
+			v.y < min(a.y, b.y)-distance || v.y > max(a.y, b.y)+distance {
+			continue
+		}
+
+		if vecSegmentDistance(v, a, b) < distance {
+			return true
+		}
+	}
+	return false
+}
+
+// offsetRing returns the raw offset curve of the implicitly closed ring at the distance to the right of each edge, with
+// arcs around every left turn. A single position produces a circle. The raw curve loops back on itself around right
+// turns; those loops are removed by discarding everything closer to the original geometry than the distance.
+func offsetRing(ring []vec, distance float64) []planarEdge {
+	var curve []vec
+	if len(ring) == 1 {
+		curve = appendArc(curve, ring[0], 0, 2*math.Pi, distance)
+	} else {
+		for i, vertex := range ring {
+			incoming := vertex.sub(ring[(i+len(ring)-1)%len(ring)])
+			outgoing := ring[(i+1)%len(ring)].sub(vertex)
+			incomingNormal := vec{x: incoming.y, y: -incoming.x}.scale(1 / incoming.length())
+			outgoingNormal := vec{x: outgoing.y, y: -outgoing.x}.scale(1 / outgoing.length())
+
+			turn := math.Atan2(incoming.cross(outgoing), incoming.dot(outgoing))
+			if turn <= -math.Pi+1e-12 {
+				turn = math.Pi
+			}
+
+			curve = append(curve, vertex.add(incomingNormal.scale(distance)))
+			if turn > 1e-12 {
+				curve = appendArc(curve, vertex, math.Atan2(incomingNormal.y, incomingNormal.x), turn, distance)
+			}
+			curve = append(curve, vertex.add(outgoingNormal.scale(distance)))
+		}
+	}
+
+	edges := make([]planarEdge, 0, len(curve))
+	for i := range curve {
+		edges = append(edges, planarEdge{a: curve[i], b: curve[(i+1)%len(curve)]})
+	}
+	return edges
+}
+
+// appendArc appends the positions strictly between the ends of the counterclockwise arc around the center that starts
+// at the angle and sweeps through the given angle. A full circle also includes its starting position.
+func appendArc(curve []vec, center vec, start, sweep, radius float64) []vec {
+	steps := int(math.Ceil(sweep / (2 * math.Pi / bufferSegments)))
+	first := 1
+	if sweep >= 2*math.Pi {
+		first = 0
+	}
+
+	for k := first; k < steps; k++ {
+		angle := start + sweep*float64(k)/float64(steps)
+		curve = append(curve, center.add(vec{x: math.Cos(angle), y: math.Sin(angle)}.scale(radius)))
+	}
+	return curve
+}
@@ -0,0 +1,131 @@
+package geojson_v2
+
+import (
+	"math"
+	"testing"
+)
+
+func TestBufferPoint(t *testing.T) {
+	center := Point{Latitude: 41.26, Longitude: -96.05}
+	buffer := (&Geometry{Point: &center}).Buffer(1000)
+	if buffer.Polygon == nil {
+		t.Fatalf("buffer = %+v, want a Polygon", buffer)
+	}
+
+	for _, point := range buffer.Polygon.OuterPath.Points {
+		if distance := center.DistanceTo(*point); math.Abs(distance-1000) > 1 {
+			t.Errorf("buffer position %+v is %.3f meters from the center", point, distance)
+		}
+	}
+
+	// A regular polygon with bufferSegments sides inscribed in the circle.
+	want := bufferSegments / 2.0 * 1000 * 1000 * math.Sin(2*math.Pi/bufferSegments)
+	if area := buffer.Area(); math.Abs(area-want)/want > 0.005 {
+		t.Errorf("area = %.0f, want %.0f", area, want)
+	}
+
+	if err := buffer.Validate(); err != nil {
+		t.Errorf("buffer is invalid: %v", err)
+	}
+}
+
+func TestBufferContainment(t *testing.T) {
+	tests := []struct {
+		name     string
+		geometry string
+		distance float64
+		inside   []Point
+		outside  []Point
+	}{
+		{name: "line",
+			geometry: `{"type":"LineString","coordinates":[[-96,41],[-95.9,41],[-95.9,41.1]]}`,
+			distance: 500,
+			inside: []Point{
+				destinationPoint(Point{Latitude: 41, Longitude: -95.95}, 180, 450),
+				destinationPoint(Point{Latitude: 41, Longitude: -96}, 270, 450),
+				destinationPoint(Point{Latitude: 41.1, Longitude: -95.9}, 0, 450),
+			},
+			outside: []Point{
+				destinationPoint(Point{Latitude: 41, Longitude: -95.95}, 180, 550),
+				destinationPoint(Point{Latitude: 41, Longitude: -96}, 270, 550),
+				{Latitude: 41.05, Longitude: -95.95},
+			}},
+		{name: "polygon keeps a large hole",
+			geometry: `{"type":"Polygon","coordinates":[[[-96,41],[-95.8,41],[-95.8,41.2],[-96,41.2],[-96,41]],` +
+				`[[-95.95,41.05],[-95.95,41.15],[-95.85,41.15],[-95.85,41.05],[-95.95,41.05]]]}`,
+			distance: 1000,
+			inside: []Point{
+				destinationPoint(Point{Latitude: 41, Longitude: -95.9}, 180, 900),
+				destinationPoint(Point{Latitude: 41.1, Longitude: -95.95}, 90, 900),
+			},
+			outside: []Point{
+				{Latitude: 41.1, Longitude: -95.9},
+				destinationPoint(Point{Latitude: 41, Longitude: -95.9}, 180, 1100),
+			}},
+		{name: "polygon fills a small hole",
+			geometry: `{"type":"Polygon","coordinates":[[[-96,41],[-95.8,41],[-95.8,41.2],[-96,41.2],[-96,41]],` +
+				`[[-95.901,41.099],[-95.901,41.101],[-95.899,41.101],[-95.899,41.099],[-95.901,41.099]]]}`,
+			distance: 1000,
+			inside:   []Point{{Latitude: 41.1, Longitude: -95.9}}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			buffer := mustGeometry(t, test.geometry).Buffer(test.distance)
+			if err := buffer.Validate(); err != nil {
+				t.Fatalf("buffer is invalid: %v", err)
+			}
+
+			for _, point := range test.inside {
+				if !buffer.Contains(point) {
+					t.Errorf("buffer does not contain %+v", point)
+				}
+			}
+			for _, point := range test.outside {
+				if buffer.Contains(point) {
+					t.Errorf("buffer contains %+v", point)
+				}
+			}
+		})
+	}
+}
+
+func TestBufferMergesOverlaps(t *testing.T) {
+	apart := mustGeometry(t, `{"type":"MultiPoint","coordinates":[[-96,41],[-95.9,41]]}`).Buffer(1000)
+	if apart.MultiPolygon == nil || len(apart.MultiPolygon.Polygons) != 2 {
+		t.Errorf("buffer of distant points = %+v, want two polygons", apart)
+	}
+
+	together := mustGeometry(t, `{"type":"MultiPoint","coordinates":[[-96,41],[-95.99,41]]}`).Buffer(1000)
+	if together.Polygon == nil {
+		t.Fatalf("buffer of close points = %+v, want one polygon", together)
+	}
+	if !together.Contains(Point{Latitude: 41, Longitude: -95.995}) {
+		t.Error("merged buffer does not contain the point between the positions")
+	}
+}
+
+func TestBufferAcrossAntimeridian(t *testing.T) {
+	buffer := mustGeometry(t, `{"type":"Point","coordinates":[179.999,51]}`).Buffer(1000)
+	if buffer.MultiPolygon == nil || len(buffer.MultiPolygon.Polygons) != 2 {
+		t.Fatalf("buffer = %+v, want a MultiPolygon split at the antimeridian", buffer)
+	}
+	if buffer.CrossesAntimeridian() {
+		t.Error("buffer still crosses the antimeridian")
+	}
+	if !buffer.Contains(Point{Latitude: 51, Longitude: -179.998}) {
+		t.Error("buffer does not reach past the antimeridian")
+	}
+}
+
+func TestBufferNonPositiveDistance(t *testing.T) {
+	geometry := mustGeometry(t, `{"type":"Point","coordinates":[-96,41]}`)
+
+	buffer := geometry.Buffer(0)
+	if buffer == geometry || buffer.Point == nil || !buffer.Point.Equal(*geometry.Point) {
+		t.Errorf("buffer = %+v, want a copy of the point", buffer)
+	}
+	if empty := (&Geometry{}).Buffer(1000); !empty.IsEmpty() {
+		t.Errorf("buffer of an empty geometry = %+v", empty)
+	}
+}
@@ -0,0 +1,187 @@
+package geojson_v2
+
+import "math"
+
+const (
+	centroidDimension_Point   = 0
+	centroidDimension_Line    = 1
+	centroidDimension_Polygon = 2
+)
+
+// centroidAccumulator collects weighted positions for the highest dimension seen, so that a collection of polygons and
+// points is centered on the polygons, matching PostGIS ST_Centroid. When unwrap is set, longitudes are taken within 180
+// degrees of origin so that geometries crossing the antimeridian are not averaged across the globe.
+type centroidAccumulator struct {
+	dimension int
+	weight    float64
+	longitude float64
+	latitude  float64
+	unwrap    bool
+	origin    float64
+}
+
+func (c *centroidAccumulator) add(dimension int, longitude, latitude, weight float64) {
+	if dimension > c.dimension {
+		c.dimension = dimension
+		c.weight, c.longitude, c.latitude = 0, 0, 0
+	} else if dimension < c.dimension {
+		return
+	}
+
+	c.longitude += longitude * weight
+	c.latitude += latitude * weight
+	c.weight += weight
+}
+
+func (c *centroidAccumulator) unwrapLongitude(point *Point) float64 {
+	if !c.unwrap {
+		return point.Longitude
+	}
+	return c.origin + wrapLongitude(point.Longitude-c.origin)
+}
+
+func (c *centroidAccumulator) addPoints(points []*Point) {
+	for _, point := range points {
+		c.add(centroidDimension_Point, c.unwrapLongitude(point), point.Latitude, 1)
+	}
+}
+
+func (c *centroidAccumulator) addPath(points []*Point) {
+	length := 0.0
+	for i := 1; i < len(points); i++ {
+		longitude, previousLongitude := c.unwrapLongitude(points[i]), c.unwrapLongitude(points[i-1])
+		segmentLength := math.Hypot(longitude-previousLongitude, points[i].Latitude-points[i-1].Latitude)
+		c.add(centroidDimension_Line, (longitude+previousLongitude)/2, (points[i].Latitude+points[i-1].Latitude)/2, segmentLength)
+		length += segmentLength
+	}
+
+	if length == 0 {
+		c.addPoints(points)
+	}
+}
+
+// addRing adds the area of the ring, or subtracts it for inner paths. Rings without an area are added as paths.
+func (c *centroidAccumulator) addRing(ring *MultiPoint, subtract bool) {
+	if ring == nil || len(ring.Points) == 0 {
+		return
+	}
+
+	points := make([]*Point, len(ring.Points))
+	for i, point := range ring.Points {
+		points[i] = &Point{Longitude: c.unwrapLongitude(point), Latitude: point.Latitude}
+	}
+
+	area := ringSignedArea(&MultiPoint{Points: points})
+	if area == 0 {
+		c.addPath(closedRingPoints(ring))
+		return
+	}
+
+	longitude := 0.0
+	latitude := 0.0
+	for i, j := 0, len(points)-1; i < len(points); j, i = i, i+1 {
+		cross := points[j].Longitude*points[i].Latitude - points[i].Longitude*points[j].Latitude
+		longitude += (points[j].Longitude + points[i].Longitude) * cross
+		latitude += (points[j].Latitude + points[i].Latitude) * cross
+	}
+
+	weight := math.Abs(area)
+	if subtract {
+		weight = -weight
+	}
+	c.add(centroidDimension_Polygon, longitude/(6*area), latitude/(6*area), weight)
+}
+
+func (c *centroidAccumulator) addPolygon(polygon *Polygon) {
+	c.addRing(polygon.OuterPath, false)
+	for _, innerPath := range polygon.InnerPaths {
+		c.addRing(innerPath, true)
+	}
+}
+
+func (c *centroidAccumulator) addGeometry(g *Geometry) {
+	if g == nil {
+		return
+	}
+
+	if g.Point != nil {
+		c.addPoints([]*Point{g.Point})
+	} else if g.MultiPoint != nil {
+		c.addPoints(g.MultiPoint.Points)
+	} else if g.LineString != nil {
+		c.addPath(g.LineString.Points)
+	} else if g.MultiLineString != nil {
+		for _, lineString := range g.MultiLineString.LineStrings {
+			c.addPath(lineString.Points)
+		}
+	} else if g.Polygon != nil {
+		c.addPolygon(g.Polygon)
+	} else if g.MultiPolygon != nil {
+		for _, polygon := range g.MultiPolygon.Polygons {
+			c.addPolygon(polygon)
+		}
+	} else if g.GeometryCollection != nil {
+		for _, geometry := range g.GeometryCollection.Geometries {
+			c.addGeometry(geometry)
+		}
+	}
+}
+
+func (c *centroidAccumulator) centroid() *Point {
+	if c.weight == 0 {
+		return nil
+	}
+
+	longitude := c.longitude / c.weight
+	if c.unwrap {
+		longitude = wrapLongitude(longitude)
+	}
+	return &Point{Longitude: longitude, Latitude: c.latitude / c.weight}
+}
+
+// Centroid returns the center of mass of the geometry, or nil when it has no positions. Collections are centered on
+// their highest dimension members, and geometries crossing the antimeridian are centered on the side they cover.
+func (g *Geometry) Centroid() *Point {
+	accumulator := &centroidAccumulator{}
+	if bbox := g.BBox(); bbox != nil && bbox.CrossesAntimeridian() {
+		accumulator.unwrap = true
+		accumulator.origin = bbox.Center().Longitude
+	}
+	accumulator.addGeometry(g)
+	return accumulator.centroid()
+}
+
+// Centroid returns a copy of the point.
+func (p *Point) Centroid() *Point {
+	return &Point{Longitude: p.Longitude, Latitude: p.Latitude}
+}
+
+// Centroid returns the average of the points, or nil when there are none.
+func (mp *MultiPoint) Centroid() *Point {
+	return (&Geometry{MultiPoint: mp}).Centroid()
+}
+
+// Centroid returns the length weighted center of the line.
+func (ls *LineString) Centroid() *Point {
+	return (&Geometry{LineString: ls}).Centroid()
+}
+
+// Centroid returns the length weighted center of the lines.
+func (mls *MultiLineString) Centroid() *Point {
+	return (&Geometry{MultiLineString: mls}).Centroid()
+}
+
+// Centroid returns the center of mass of the polygon with its inner paths removed.
+func (pg *Polygon) Centroid() *Point {
+	return (&Geometry{Polygon: pg}).Centroid()
+}
+
+// Centroid returns the area weighted center of mass of the polygons.
+func (mpg *MultiPolygon) Centroid() *Point {
+	return (&Geometry{MultiPolygon: mpg}).Centroid()
+}
+
+// Centroid returns the center of mass of the highest dimension members of the collection.
+func (gc *GeometryCollection) Centroid() *Point {
+	return (&Geometry{GeometryCollection: gc}).Centroid()
+}
@@ -0,0 +1,57 @@
+package geojson_v2
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCentroid(t *testing.T) {
+	tests := []struct {
+		name      string
+		geometry  string
+		longitude float64
+		latitude  float64
+	}{
+		{
+			name:      "polygon",
+			geometry:  `{"type":"Polygon","coordinates":[[[-98,38],[-96,38],[-96,40],[-98,40],[-98,38]]]}`,
+			longitude: -97,
+			latitude:  39,
+		},
+		{
+			name:      "aleutian polygon crossing the antimeridian",
+			geometry:  `{"type":"Polygon","coordinates":[[[179,51],[-179,51],[-179,53],[179,53],[179,51]]]}`,
+			longitude: 180,
+			latitude:  52,
+		},
+		{
+			name:      "polygon west of the antimeridian",
+			geometry:  `{"type":"Polygon","coordinates":[[[178,51],[-179,51],[-179,53],[178,53],[178,51]]]}`,
+			longitude: 179.5,
+			latitude:  52,
+		},
+		{
+			name:      "line crossing the antimeridian",
+			geometry:  `{"type":"LineString","coordinates":[[179,52],[-177,52]]}`,
+			longitude: -179,
+			latitude:  52,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			geometry := mustGeometry(t, test.geometry)
+			centroid := geometry.Centroid()
+			if centroid == nil {
+				t.Fatal("centroid is nil")
+			}
+
+			if math.Abs(wrapLongitude(centroid.Longitude-test.longitude)) > 1e-9 || math.Abs(centroid.Latitude-test.latitude) > 1e-9 {
+				t.Errorf("centroid = %v,%v, want %v,%v", centroid.Longitude, centroid.Latitude, test.longitude, test.latitude)
+			}
+			if centroid.Longitude < -180 || centroid.Longitude > 180 {
+				t.Errorf("centroid longitude %v is outside [-180, 180]", centroid.Longitude)
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package geojson_v2
+
+// Clone returns a deep copy of the geometry.
+func (g *Geometry) Clone() *Geometry {
+	if g == nil {
+		return nil
+	}
+
+	clone := &Geometry{IncludeBBox: g.IncludeBBox, Encoding: g.Encoding}
+	if g.Point != nil {
+		clone.Point = g.Point.Clone()
+	}
+	if g.MultiPoint != nil {
+		clone.MultiPoint = g.MultiPoint.Clone()
+	}
+	if g.LineString != nil {
+		clone.LineString = g.LineString.Clone()
+	}
+	if g.MultiLineString != nil {
+		clone.MultiLineString = g.MultiLineString.Clone()
+	}
+	if g.Polygon != nil {
+		clone.Polygon = g.Polygon.Clone()
+	}
+	if g.MultiPolygon != nil {
+		clone.MultiPolygon = g.MultiPolygon.Clone()
+	}
+	if g.GeometryCollection != nil {
+		clone.GeometryCollection = g.GeometryCollection.Clone()
+	}
+
+	return clone
+}
+
+// Clone returns a deep copy of the point.
+func (p *Point) Clone() *Point {
+	clone := *p
+	if p.Altitude != nil {
+		altitude := *p.Altitude
+		clone.Altitude = &altitude
+	}
+	return &clone
+}
+
+// Clone returns a deep copy of the points.
+func (mp *MultiPoint) Clone() *MultiPoint {
+	return &MultiPoint{Points: clonePoints(mp.Points)}
+}
+
+// Clone returns a deep copy of the line.
+func (ls *LineString) Clone() *LineString {
+	return &LineString{Points: clonePoints(ls.Points)}
+}
+
+// Clone returns a deep copy of the lines.
+func (mls *MultiLineString) Clone() *MultiLineString {
+	lineStrings := make([]*LineString, len(mls.LineStrings))
+	for i, lineString := range mls.LineStrings {
+		lineStrings[i] = lineString.Clone()
+	}
+	return &MultiLineString{LineStrings: lineStrings}
+}
+
+// Clone returns a deep copy of the polygon and its paths.
+func (pg *Polygon) Clone() *Polygon {
+	clone := &Polygon{InnerPaths: make([]*MultiPoint, len(pg.InnerPaths))}
+	if pg.OuterPath != nil {
+		clone.OuterPath = pg.OuterPath.Clone()
+	}
+	for i, innerPath := range pg.InnerPaths {
+		clone.InnerPaths[i] = innerPath.Clone()
+	}
+	return clone
+}
+
+// Clone returns a deep copy of the polygons.
+func (mpg *MultiPolygon) Clone() *MultiPolygon {
+	polygons := make([]*Polygon, len(mpg.Polygons))
+	for i, polygon := range mpg.Polygons {
+		polygons[i] = polygon.Clone()
+	}
+	return &MultiPolygon{Polygons: polygons}
+}
+
+// Clone returns a deep copy of the collection and every member geometry.
+func (gc *GeometryCollection) Clone() *GeometryCollection {
+	geometries := make([]*Geometry, len(gc.Geometries))
+	for i, geometry := range gc.Geometries {
+		geometries[i] = geometry.Clone()
+	}
+	return &GeometryCollection{Geometries: geometries}
+}
+
+func clonePoints(points []*Point) []*Point {
+	clone := make([]*Point, len(points))
+	for i, point := range points {
+		clone[i] = point.Clone()
+	}
+	return clone
+}
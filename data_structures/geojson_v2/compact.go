@@ -0,0 +1,343 @@
+package geojson_v2
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// CoordinateEncoding selects how the coordinates of a geometry are written when it is marshalled to JSON. The compact
+// encodings keep the nesting of the GeoJSON coordinates member, but write every path as a single string in place of its
+// array of positions, and add an "encoding" member naming the encoding. Altitudes are dropped by the compact
+// encodings.
+type CoordinateEncoding string
+
+const (
+	// CoordinateEncoding_GeoJSON writes positions as arrays of numbers, as RFC 7946 specifies.
+	CoordinateEncoding_GeoJSON CoordinateEncoding = "geojson"
+	// CoordinateEncoding_Polyline writes each path with the Google encoded polyline algorithm at PolylinePrecision
+	// decimal places.
+	CoordinateEncoding_Polyline CoordinateEncoding = "polyline"
+	// CoordinateEncoding_Delta writes each path as base64 encoded varints of the zigzag encoded differences between
+	// consecutive longitude and latitude pairs, quantized to DeltaPrecision decimal places.
+	CoordinateEncoding_Delta CoordinateEncoding = "delta"
+)
+
+const (
+	// PolylinePrecision is the number of decimal places kept by CoordinateEncoding_Polyline, about a meter.
+	PolylinePrecision = 5
+	// DeltaPrecision is the number of decimal places kept by CoordinateEncoding_Delta, about ten centimeters.
+	DeltaPrecision = 6
+)
+
+// CoordinateEncodings lists every supported coordinate encoding.
+var CoordinateEncodings = []CoordinateEncoding{
+	CoordinateEncoding_GeoJSON,
+	CoordinateEncoding_Polyline,
+	CoordinateEncoding_Delta,
+}
+
+// EncodePolyline encodes the path with the Google encoded polyline algorithm at PolylinePrecision decimal places.
+func EncodePolyline(points []*Point) string {
+	factor := math.Pow10(PolylinePrecision)
+
+	var builder strings.Builder
+	var previousLatitude, previousLongitude int64
+	for _, point := range points {
+		latitude := int64(math.Round(point.Latitude * factor))
+		longitude := int64(math.Round(point.Longitude * factor))
+		writePolylineValue(&builder, latitude-previousLatitude)
+		writePolylineValue(&builder, longitude-previousLongitude)
+		previousLatitude, previousLongitude = latitude, longitude
+	}
+	return builder.String()
+}
+
+// DecodePolyline decodes a path written with the Google encoded polyline algorithm at PolylinePrecision decimal places.
+func DecodePolyline(encoded string) ([]*Point, error) {
+	factor := math.Pow10(PolylinePrecision)
+
+	var points []*Point
+	var latitude, longitude int64
+	for position := 0; position < len(encoded); {
+		latitudeDelta, next, err := readPolylineValue(encoded, position)
+		if err != nil {
+			return nil, err
+		}
+
+		longitudeDelta, next, err := readPolylineValue(encoded, next)
+		if err != nil {
+			return nil, err
+		}
+
+		latitude += latitudeDelta
+		longitude += longitudeDelta
+		points = append(points, &Point{Latitude: float64(latitude) / factor, Longitude: float64(longitude) / factor})
+		position = next
+	}
+	return points, nil
+}
+
+func writePolylineValue(builder *strings.Builder, value int64) {
+	zigzagged := uint64(value<<1) ^ uint64(value>>63)
+	for zigzagged >= 0x20 {
+		builder.WriteByte(byte(0x20|zigzagged&0x1f) + 63)
+		zigzagged >>= 5
+	}
+	builder.WriteByte(byte(zigzagged) + 63)
+}
+
+func readPolylineValue(encoded string, position int) (int64, int, error) {
+	var zigzagged uint64
+	for shift := 0; ; shift += 5 {
+		if position >= len(encoded) || shift > 60 {
+			return 0, 0, errors.New("invalid encoded polyline")
+		}
+
+		chunk := uint64(encoded[position]) - 63
+		if chunk > 0x3f {
+			return 0, 0, fmt.Errorf("invalid encoded polyline character: %q", encoded[position])
+		}
+		position++
+
+		zigzagged |= (chunk & 0x1f) << shift
+		if chunk < 0x20 {
+			break
+		}
+	}
+	return int64(zigzagged>>1) ^ -int64(zigzagged&1), position, nil
+}
+
+// EncodeDelta encodes the path as base64 encoded varints of the zigzag encoded differences between consecutive
+// longitude and latitude pairs, quantized to DeltaPrecision decimal places.
+func EncodeDelta(points []*Point) string {
+	factor := math.Pow10(DeltaPrecision)
+
+	var data []byte
+	var previousLongitude, previousLatitude int64
+	for _, point := range points {
+		longitude := int64(math.Round(point.Longitude * factor))
+		latitude := int64(math.Round(point.Latitude * factor))
+		data = binary.AppendVarint(data, longitude-previousLongitude)
+		data = binary.AppendVarint(data, latitude-previousLatitude)
+		previousLongitude, previousLatitude = longitude, latitude
+	}
+	return base64.StdEncoding.EncodeToString(data)
+}
+
+// DecodeDelta decodes a path written by EncodeDelta.
+func DecodeDelta(encoded string) ([]*Point, error) {
+	data, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, err
+	}
+
+	factor := math.Pow10(DeltaPrecision)
+
+	var points []*Point
+	var longitude, latitude int64
+	for len(data) > 0 {
+		longitudeDelta, read := binary.Varint(data)
+		if read <= 0 {
+			return nil, errors.New("invalid delta encoded coordinates")
+		}
+		data = data[read:]
+
+		latitudeDelta, read := binary.Varint(data)
+		if read <= 0 {
+			return nil, errors.New("invalid delta encoded coordinates")
+		}
+		data = data[read:]
+
+		longitude += longitudeDelta
+		latitude += latitudeDelta
+		points = append(points, &Point{Latitude: float64(latitude) / factor, Longitude: float64(longitude) / factor})
+	}
+	return points, nil
+}
+
+// compact reports whether the encoding replaces position arrays with strings.
+func (e CoordinateEncoding) compact() bool {
+	return e == CoordinateEncoding_Polyline || e == CoordinateEncoding_Delta
+}
+
+func (e CoordinateEncoding) encodePath(points []*Point) string {
+	if e == CoordinateEncoding_Polyline {
+		return EncodePolyline(points)
+	}
+	return EncodeDelta(points)
+}
+
+func (e CoordinateEncoding) decodePath(encoded string) ([]*Point, error) {
+	if e == CoordinateEncoding_Polyline {
+		return DecodePolyline(encoded)
+	}
+	return DecodeDelta(encoded)
+}
+
+func (e CoordinateEncoding) encodeRings(polygon *Polygon) []string {
+	var rings []string
+	if polygon.OuterPath != nil {
+		rings = append(rings, e.encodePath(polygon.OuterPath.Points))
+	}
+	for _, innerPath := range polygon.InnerPaths {
+		rings = append(rings, e.encodePath(innerPath.Points))
+	}
+	return rings
+}
+
+func (e CoordinateEncoding) decodeRings(rings []string) (*Polygon, error) {
+	if len(rings) < 1 {
+		return nil, fmt.Errorf("invalid number of paths for Polygon: %v", rings)
+	}
+
+	polygon := &Polygon{}
+	for i, ring := range rings {
+		points, err := e.decodePath(ring)
+		if err != nil {
+			return nil, err
+		}
+
+		if i == 0 {
+			polygon.OuterPath = &MultiPoint{Points: points}
+		} else {
+			polygon.InnerPaths = append(polygon.InnerPaths, &MultiPoint{Points: points})
+		}
+	}
+	return polygon, nil
+}
+
+// marshalCompactJSON writes the geometry with its coordinates in the compact encoding. Geometries in a collection
+// without an encoding of their own are written with the encoding of the collection.
+func (g *Geometry) marshalCompactJSON(bbox *BBox) ([]byte, error) {
+	member := struct {
+		Type        string             `json:"type"`
+		Encoding    CoordinateEncoding `json:"encoding"`
+		BBox        *BBox              `json:"bbox,omitempty"`
+		Coordinates any                `json:"coordinates,omitempty"`
+		Geometries  []*Geometry        `json:"geometries,omitempty"`
+	}{
+		Encoding: g.Encoding,
+		BBox:     bbox,
+	}
+
+	if g.Point != nil {
+		member.Type = "Point"
+		member.Coordinates = g.Encoding.encodePath([]*Point{g.Point})
+	} else if g.MultiPoint != nil {
+		member.Type = "MultiPoint"
+		member.Coordinates = g.Encoding.encodePath(g.MultiPoint.Points)
+	} else if g.LineString != nil {
+		member.Type = "LineString"
+		member.Coordinates = g.Encoding.encodePath(g.LineString.Points)
+	} else if g.MultiLineString != nil {
+		lineStrings := make([]string, len(g.MultiLineString.LineStrings))
+		for i, lineString := range g.MultiLineString.LineStrings {
+			lineStrings[i] = g.Encoding.encodePath(lineString.Points)
+		}
+		member.Type = "MultiLineString"
+		member.Coordinates = lineStrings
+	} else if g.Polygon != nil {
+		member.Type = "Polygon"
+		member.Coordinates = g.Encoding.encodeRings(g.Polygon)
+	} else if g.MultiPolygon != nil {
+		polygons := make([][]string, len(g.MultiPolygon.Polygons))
+		for i, polygon := range g.MultiPolygon.Polygons {
+			polygons[i] = g.Encoding.encodeRings(polygon)
+		}
+		member.Type = "MultiPolygon"
+		member.Coordinates = polygons
+	} else if g.GeometryCollection != nil {
+		member.Type = "GeometryCollection"
+		member.Geometries = make([]*Geometry, len(g.GeometryCollection.Geometries))
+		for i, geometry := range g.GeometryCollection.Geometries {
+			if geometry != nil && geometry.Encoding == "" {
+				inherited := *geometry
+				inherited.Encoding = g.Encoding
+				geometry = &inherited
+			}
+			member.Geometries[i] = geometry
+		}
+	} else {
+		return []byte("null"), nil
+	}
+
+	return json.Marshal(member)
+}
+
+// unmarshalCompactCoordinates decodes the coordinates member of a geometry written in a compact encoding.
+func (g *Geometry) unmarshalCompactCoordinates(geometryType string, coordinates json.RawMessage) error {
+	switch geometryType {
+	case "Point", "MultiPoint", "LineString":
+		var path string
+		err := json.Unmarshal(coordinates, &path)
+		if err != nil {
+			return err
+		}
+
+		points, err := g.Encoding.decodePath(path)
+		if err != nil {
+			return err
+		}
+
+		if geometryType == "Point" {
+			if len(points) != 1 {
+				return fmt.Errorf("invalid number of positions for Point: %d", len(points))
+			}
+			g.Point = points[0]
+		} else if geometryType == "MultiPoint" {
+			g.MultiPoint = &MultiPoint{Points: points}
+		} else {
+			g.LineString = &LineString{Points: points}
+		}
+	case "MultiLineString":
+		var paths []string
+		err := json.Unmarshal(coordinates, &paths)
+		if err != nil {
+			return err
+		}
+
+		g.MultiLineString = &MultiLineString{}
+		for _, path := range paths {
+			points, err := g.Encoding.decodePath(path)
+			if err != nil {
+				return err
+			}
+			g.MultiLineString.LineStrings = append(g.MultiLineString.LineStrings, &LineString{Points: points})
+		}
+	case "Polygon":
+		var rings []string
+		err := json.Unmarshal(coordinates, &rings)
+		if err != nil {
+			return err
+		}
+
+		g.Polygon, err = g.Encoding.decodeRings(rings)
+		if err != nil {
+			return err
+		}
+	case "MultiPolygon":
+		var polygons [][]string
+		err := json.Unmarshal(coordinates, &polygons)
+		if err != nil {
+			return err
+		}
+
+		g.MultiPolygon = &MultiPolygon{}
+		for _, rings := range polygons {
+			polygon, err := g.Encoding.decodeRings(rings)
+			if err != nil {
+				return err
+			}
+			g.MultiPolygon.Polygons = append(g.MultiPolygon.Polygons, polygon)
+		}
+	default:
+		return fmt.Errorf("unsupported geometry type: %s", geometryType)
+	}
+
+	return nil
+}
@@ -0,0 +1,74 @@
+package geojson_v2
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestCompactEncodingRoundTrip(t *testing.T) {
+	geometries := []string{
+		`{"type":"Point","coordinates":[-96.05,41.26]}`,
+		`{"type":"LineString","coordinates":[[-96.05,41.26],[-96.2,41.1]]}`,
+		`{"type":"Polygon","coordinates":[[[-100,35],[-98,35],[-98,37],[-100,37],[-100,35]],[[-99.5,35.5],[-99.5,36.5],[-98.5,36.5],[-98.5,35.5],[-99.5,35.5]]]}`,
+		`{"type":"MultiPolygon","coordinates":[[[[-100,35],[-98,35],[-98,37],[-100,37],[-100,35]]],[[[-97,35],[-96,35],[-96,36],[-97,36],[-97,35]]]]}`,
+		`{"type":"GeometryCollection","geometries":[{"type":"Point","coordinates":[-96.05,41.26]},{"type":"LineString","coordinates":[[-96.05,41.26],[-96.2,41.1]]}]}`,
+	}
+
+	for _, encoding := range []CoordinateEncoding{CoordinateEncoding_Polyline, CoordinateEncoding_Delta} {
+		for _, data := range geometries {
+			geometry := mustGeometry(t, data)
+
+			compact, err := geometry.MarshalJSONWithEncoding(encoding)
+			if err != nil {
+				t.Fatalf("%s %s: %v", encoding, data, err)
+			}
+
+			decoded := mustGeometry(t, string(compact))
+			marshalled, err := json.Marshal(decoded)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if string(marshalled) != data {
+				t.Errorf("%s round trip\n got: %s\nwant: %s", encoding, marshalled, data)
+			}
+		}
+	}
+}
+
+func TestCompactEncodingRejectsPolygonsWithoutRings(t *testing.T) {
+	for _, data := range []string{
+		`{"type":"Polygon","encoding":"polyline","coordinates":[]}`,
+		`{"type":"Polygon","encoding":"delta","coordinates":[]}`,
+		`{"type":"MultiPolygon","encoding":"polyline","coordinates":[[]]}`,
+		`{"type":"MultiPolygon","encoding":"polyline","coordinates":[["_p~iF~ps|U_ulLnnqC_mqNvxq` + "`" + `@"],[]]}`,
+	} {
+		var geometry Geometry
+		err := json.Unmarshal([]byte(data), &geometry)
+		if err == nil || !strings.Contains(err.Error(), "invalid number of paths for Polygon") {
+			t.Errorf("%s: err = %v, want invalid number of paths", data, err)
+		}
+	}
+}
+
+func TestCompactEncodingIsNotKeptAfterUnmarshal(t *testing.T) {
+	geometry := mustGeometry(t, `{"type":"LineString","coordinates":[[-96.05,41.26],[-96.2,41.1]]}`)
+	compact, err := geometry.MarshalJSONWithEncoding(CoordinateEncoding_Polyline)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	decoded := mustGeometry(t, string(compact))
+	if decoded.Encoding != "" {
+		t.Errorf("encoding = %s, want none", decoded.Encoding)
+	}
+
+	marshalled, err := json.Marshal(decoded)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(marshalled), "encoding") {
+		t.Errorf("marshalled = %s, want GeoJSON coordinates", marshalled)
+	}
+}
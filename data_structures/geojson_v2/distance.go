@@ -0,0 +1,190 @@
+package geojson_v2
+
+import (
+	"errors"
+	"math"
+)
+
+const (
+	// wgs84SemiMajorAxis is the equatorial radius of the WGS 84 ellipsoid in meters.
+	wgs84SemiMajorAxis = 6378137.0
+	// wgs84Flattening is the flattening of the WGS 84 ellipsoid.
+	wgs84Flattening = 1 / 298.257223563
+)
+
+// maxVincentyIterations bounds the iterations of the Vincenty inverse formula before it is considered not to converge.
+const maxVincentyIterations = 200
+
+var ErrVincentyNoConvergence = errors.New("vincenty distance failed to converge")
+
+var compassDirections = []string{"N", "NE", "E", "SE", "S", "SW", "W", "NW"}
+
+// DistanceTo returns the great circle distance to the other point in meters using the haversine formula.
+func (p *Point) DistanceTo(other Point) float64 {
+	return haversineDistance(*p, other)
+}
+
+// VincentyDistanceTo returns the distance to the other point in meters on the WGS 84 ellipsoid. It returns
+// ErrVincentyNoConvergence for nearly antipodal points, where DistanceTo should be used instead.
+func (p *Point) VincentyDistanceTo(other Point) (float64, error) {
+	b := wgs84SemiMajorAxis * (1 - wgs84Flattening)
+
+	u1 := math.Atan((1 - wgs84Flattening) * math.Tan(degreesToRadians(p.Latitude)))
+	u2 := math.Atan((1 - wgs84Flattening) * math.Tan(degreesToRadians(other.Latitude)))
+	sinU1, cosU1 := math.Sincos(u1)
+	sinU2, cosU2 := math.Sincos(u2)
+
+	l := degreesToRadians(other.Longitude - p.Longitude)
+	lambda := l
+
+	for i := 0; i < maxVincentyIterations; i++ {
+		sinLambda, cosLambda := math.Sincos(lambda)
+		sinSigma := math.Sqrt(math.Pow(cosU2*sinLambda, 2) + math.Pow(cosU1*sinU2-sinU1*cosU2*cosLambda, 2))
+		if sinSigma == 0 {
+			return 0, nil
+		}
+
+		cosSigma := sinU1*sinU2 + cosU1*cosU2*cosLambda
+		sigma := math.Atan2(sinSigma, cosSigma)
+		sinAlpha := cosU1 * cosU2 * sinLambda / sinSigma
+		cosSqAlpha := 1 - sinAlpha*sinAlpha
+
+		cos2SigmaM := 0.0
+		if cosSqAlpha != 0 {
+			cos2SigmaM = cosSigma - 2*sinU1*sinU2/cosSqAlpha
+		}
+
+		c := wgs84Flattening / 16 * cosSqAlpha * (4 + wgs84Flattening*(4-3*cosSqAlpha))
+		previousLambda := lambda
+		lambda = l + (1-c)*wgs84Flattening*sinAlpha*(sigma+c*sinSigma*(cos2SigmaM+c*cosSigma*(-1+2*cos2SigmaM*cos2SigmaM)))
+
+		if math.Abs(lambda-previousLambda) < 1e-12 {
+			uSq := cosSqAlpha * (wgs84SemiMajorAxis*wgs84SemiMajorAxis - b*b) / (b * b)
+			bigA := 1 + uSq/16384*(4096+uSq*(-768+uSq*(320-175*uSq)))
+			bigB := uSq / 1024 * (256 + uSq*(-128+uSq*(74-47*uSq)))
+			deltaSigma := bigB * sinSigma * (cos2SigmaM + bigB/4*(cosSigma*(-1+2*cos2SigmaM*cos2SigmaM)-
+				bigB/6*cos2SigmaM*(-3+4*sinSigma*sinSigma)*(-3+4*cos2SigmaM*cos2SigmaM)))
+
+			return b * bigA * (sigma - deltaSigma), nil
+		}
+	}
+
+	return 0, ErrVincentyNoConvergence
+}
+
+// BearingTo returns the initial great circle bearing to the other point in degrees clockwise from true north, in the
+// range [0, 360).
+func (p *Point) BearingTo(other Point) float64 {
+	lat1 := degreesToRadians(p.Latitude)
+	lat2 := degreesToRadians(other.Latitude)
+	deltaLon := degreesToRadians(other.Longitude - p.Longitude)
+
+	y := math.Sin(deltaLon) * math.Cos(lat2)
+	x := math.Cos(lat1)*math.Sin(lat2) - math.Sin(lat1)*math.Cos(lat2)*math.Cos(deltaLon)
+	return math.Mod(radiansToDegrees(math.Atan2(y, x))+360, 360)
+}
+
+// CompassDirection returns the eight point compass abbreviation, such as "NE" or "W", closest to the bearing in degrees.
+func CompassDirection(bearing float64) string {
+	sector := 360.0 / float64(len(compassDirections))
+	index := int(math.Round(math.Mod(math.Mod(bearing, 360)+360, 360)/sector)) % len(compassDirections)
+	return compassDirections[index]
+}
+
+// NearestPoint returns the position on the geometry closest to the point, or nil when the geometry has no positions.
+// A point inside a polygon is its own nearest point. Positions along segments are found in a planar approximation
+// centered on the point, so the result drifts from the true geodesic nearest point for long segments or distant
+// geometries.
+func (g *Geometry) NearestPoint(point Point) *Point {
+	if g.Contains(point) {
+		return &Point{Latitude: point.Latitude, Longitude: point.Longitude}
+	}
+
+	nearest, _ := nearestPointOnPaths(geometryPaths(g), point)
+	return nearest
+}
+
+// DistanceTo returns the great circle distance in meters from the point to the nearest position on the geometry. It is
+// zero when a polygon contains the point and positive infinity when the geometry has no positions.
+func (g *Geometry) DistanceTo(point Point) float64 {
+	nearest := g.NearestPoint(point)
+	if nearest == nil {
+		return math.Inf(1)
+	}
+	return haversineDistance(point, *nearest)
+}
+
+// NearestPoint returns the position on the edges of the polygon closest to the point, or the point itself when it is
+// inside the polygon. Like Geometry.NearestPoint it uses a planar approximation centered on the point.
+func (pg *Polygon) NearestPoint(point Point) *Point {
+	return (&Geometry{Polygon: pg}).NearestPoint(point)
+}
+
+// DistanceTo returns the great circle distance in meters from the point to the nearest edge of the polygon, or zero
+// when the polygon contains the point.
+func (pg *Polygon) DistanceTo(point Point) float64 {
+	return (&Geometry{Polygon: pg}).DistanceTo(point)
+}
+
+// NearestPoint returns the position on the edges of the polygons closest to the point, or the point itself when it is
+// inside any of the polygons. Like Geometry.NearestPoint it uses a planar approximation centered on the point.
+func (mpg *MultiPolygon) NearestPoint(point Point) *Point {
+	return (&Geometry{MultiPolygon: mpg}).NearestPoint(point)
+}
+
+// DistanceTo returns the great circle distance in meters from the point to the nearest edge of the polygons, or zero
+// when any of the polygons contains the point.
+func (mpg *MultiPolygon) DistanceTo(point Point) float64 {
+	return (&Geometry{MultiPolygon: mpg}).DistanceTo(point)
+}
+
+// nearestPointOnPaths returns the position on the paths closest to the point along with its distance in meters.
+// Segments are projected onto a plane tangent at the point, which is accurate for the short distances between a
+// location and nearby weather products.
+func nearestPointOnPaths(paths [][]*Point, point Point) (*Point, float64) {
+	var nearest *Point
+	nearestDistance := math.Inf(1)
+	consider := func(candidate Point) {
+		distance := haversineDistance(point, candidate)
+		if distance < nearestDistance {
+			nearest = &Point{Latitude: candidate.Latitude, Longitude: candidate.Longitude}
+			nearestDistance = distance
+		}
+	}
+
+	for _, path := range paths {
+		if len(path) == 1 {
+			consider(*path[0])
+		}
+		for i := 1; i < len(path); i++ {
+			consider(nearestPointOnSegment(point, *path[i-1], *path[i]))
+		}
+	}
+
+	return nearest, nearestDistance
+}
+
+// nearestPointOnSegment returns the position on the segment from a to b closest to p in a local equirectangular
+// projection centered on p.
+func nearestPointOnSegment(p, a, b Point) Point {
+	scale := math.Cos(degreesToRadians(p.Latitude))
+	ax, ay := wrapLongitude(a.Longitude-p.Longitude)*scale, a.Latitude-p.Latitude
+	bx, by := wrapLongitude(b.Longitude-p.Longitude)*scale, b.Latitude-p.Latitude
+
+	dx, dy := bx-ax, by-ay
+	lengthSq := dx*dx + dy*dy
+	if lengthSq == 0 {
+		return a
+	}
+
+	t := math.Max(0, math.Min(1, -(ax*dx+ay*dy)/lengthSq))
+	return Point{
+		Latitude:  a.Latitude + t*(b.Latitude-a.Latitude),
+		Longitude: a.Longitude + t*wrapLongitude(b.Longitude-a.Longitude),
+	}
+}
+
+// wrapLongitude returns the longitude difference in degrees wrapped into the range [-180, 180).
+func wrapLongitude(delta float64) float64 {
+	return math.Mod(math.Mod(delta+180, 360)+360, 360) - 180
+}
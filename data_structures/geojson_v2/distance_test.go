@@ -0,0 +1,158 @@
+package geojson_v2
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+// degreesMinutesSeconds converts a sexagesimal angle to decimal degrees.
+func degreesMinutesSeconds(degrees, minutes, seconds float64) float64 {
+	if degrees < 0 {
+		return degrees - minutes/60 - seconds/3600
+	}
+	return degrees + minutes/60 + seconds/3600
+}
+
+func TestVincentyDistanceTo(t *testing.T) {
+	tests := []struct {
+		name      string
+		from      Point
+		to        Point
+		want      float64
+		tolerance float64
+	}{
+		// Vincenty's own worked example, from Flinders Peak to Buninyong.
+		{name: "Flinders Peak to Buninyong",
+			from:      Point{Latitude: degreesMinutesSeconds(-37, 57, 3.72030), Longitude: degreesMinutesSeconds(144, 25, 29.52440)},
+			to:        Point{Latitude: degreesMinutesSeconds(-37, 39, 10.15610), Longitude: degreesMinutesSeconds(143, 55, 35.38390)},
+			want:      54972.271,
+			tolerance: 0.001},
+		{name: "one degree along the equator", from: Point{}, to: Point{Longitude: 1}, want: 111319.491, tolerance: 0.001},
+		{name: "one degree along a meridian", from: Point{}, to: Point{Latitude: 1}, want: 110574.389, tolerance: 0.001},
+		{name: "same point", from: Point{Latitude: 41.26, Longitude: -96.05}, to: Point{Latitude: 41.26, Longitude: -96.05}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			distance, err := test.from.VincentyDistanceTo(test.to)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if math.Abs(distance-test.want) > test.tolerance {
+				t.Errorf("distance = %.4f, want %.4f", distance, test.want)
+			}
+
+			reverse, err := test.to.VincentyDistanceTo(test.from)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if math.Abs(reverse-distance) > 1e-6 {
+				t.Errorf("reverse distance = %.4f, want %.4f", reverse, distance)
+			}
+		})
+	}
+}
+
+func TestVincentyDistanceToNearlyAntipodal(t *testing.T) {
+	from := Point{}
+	to := Point{Latitude: 0.5, Longitude: 179.7}
+
+	_, err := from.VincentyDistanceTo(to)
+	if !errors.Is(err, ErrVincentyNoConvergence) {
+		t.Fatalf("err = %v, want ErrVincentyNoConvergence", err)
+	}
+
+	// The haversine distance remains available as the fallback.
+	if distance := from.DistanceTo(to); distance < 19.9e6 || distance > math.Pi*earthRadiusMeters {
+		t.Errorf("haversine distance = %.0f", distance)
+	}
+}
+
+func TestDistanceTo(t *testing.T) {
+	from := Point{}
+	if distance := from.DistanceTo(Point{Longitude: 1}); math.Abs(distance-earthRadiusMeters*math.Pi/180) > 1e-6 {
+		t.Errorf("distance = %.6f", distance)
+	}
+}
+
+func TestBearingTo(t *testing.T) {
+	origin := Point{}
+	tests := []struct {
+		to   Point
+		want float64
+	}{
+		{to: Point{Latitude: 1}, want: 0},
+		{to: Point{Longitude: 1}, want: 90},
+		{to: Point{Latitude: -1}, want: 180},
+		{to: Point{Longitude: -1}, want: 270},
+		{to: Point{Longitude: -179}, want: 270},
+		{to: Point{Latitude: 1, Longitude: 1}, want: 44.9956},
+	}
+
+	for _, test := range tests {
+		bearing := origin.BearingTo(test.to)
+		if math.Abs(bearing-test.want) > 1e-4 {
+			t.Errorf("bearing to %+v = %.4f, want %.4f", test.to, bearing, test.want)
+		}
+		if bearing < 0 || bearing >= 360 {
+			t.Errorf("bearing to %+v = %.4f is out of range", test.to, bearing)
+		}
+	}
+
+	// Kansas City to St. Louis heads slightly south of east.
+	kansasCity := Point{Latitude: 39.0997, Longitude: -94.5786}
+	stLouis := Point{Latitude: 38.6270, Longitude: -90.1994}
+	if bearing := kansasCity.BearingTo(stLouis); bearing < 95 || bearing > 100 {
+		t.Errorf("Kansas City to St. Louis bearing = %.2f", bearing)
+	}
+}
+
+func TestCompassDirection(t *testing.T) {
+	tests := []struct {
+		bearing float64
+		want    string
+	}{
+		{bearing: 0, want: "N"},
+		{bearing: 22.49, want: "N"},
+		{bearing: 22.5, want: "NE"},
+		{bearing: 67.49, want: "NE"},
+		{bearing: 67.5, want: "E"},
+		{bearing: 180, want: "S"},
+		{bearing: 247.5, want: "W"},
+		{bearing: 337.49, want: "NW"},
+		{bearing: 337.5, want: "N"},
+		{bearing: 359.99, want: "N"},
+		{bearing: 360, want: "N"},
+		{bearing: 810, want: "E"},
+		{bearing: -22.5, want: "N"},
+		{bearing: -90, want: "W"},
+	}
+
+	for _, test := range tests {
+		if got := CompassDirection(test.bearing); got != test.want {
+			t.Errorf("CompassDirection(%v) = %s, want %s", test.bearing, got, test.want)
+		}
+	}
+}
+
+func TestNearestPoint(t *testing.T) {
+	polygon := mustGeometry(t, `{"type":"Polygon","coordinates":[[[0,0],[1,0],[1,1],[0,1],[0,0]]]}`)
+
+	inside := Point{Latitude: 0.5, Longitude: 0.5}
+	if nearest := polygon.NearestPoint(inside); nearest == nil || !nearest.Equal(inside) {
+		t.Errorf("nearest point to an inside point = %+v", nearest)
+	}
+	if distance := polygon.DistanceTo(inside); distance != 0 {
+		t.Errorf("distance to an inside point = %v", distance)
+	}
+
+	nearest := polygon.NearestPoint(Point{Latitude: 0.5, Longitude: 1.1})
+	if nearest == nil || math.Abs(nearest.Longitude-1) > 1e-9 || math.Abs(nearest.Latitude-0.5) > 1e-3 {
+		t.Errorf("nearest point east of the polygon = %+v", nearest)
+	}
+
+	if distance := (&Geometry{}).DistanceTo(inside); !math.IsInf(distance, 1) {
+		t.Errorf("distance to an empty geometry = %v", distance)
+	}
+}
@@ -0,0 +1,101 @@
+package geojson_v2
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// Feature is a GeoJSON Feature whose properties member is decoded into P.
+type Feature[P any] struct {
+	ID         interface{}
+	BBox       *BBox
+	Geometry   *Geometry
+	Properties P
+}
+
+func (f *Feature[P]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Type       string      `json:"type"`
+		ID         interface{} `json:"id,omitempty"`
+		BBox       *BBox       `json:"bbox,omitempty"`
+		Geometry   *Geometry   `json:"geometry"`
+		Properties P           `json:"properties"`
+	}{
+		Type:       "Feature",
+		ID:         f.ID,
+		BBox:       f.BBox,
+		Geometry:   f.Geometry,
+		Properties: f.Properties,
+	})
+}
+
+func (f *Feature[P]) UnmarshalJSON(data []byte) error {
+	var feature struct {
+		Type       string      `json:"type"`
+		ID         interface{} `json:"id"`
+		BBox       *BBox       `json:"bbox"`
+		Geometry   *Geometry   `json:"geometry"`
+		Properties P           `json:"properties"`
+	}
+	if err := json.Unmarshal(data, &feature); err != nil {
+		return err
+	}
+	if feature.Type != "Feature" {
+		return fmt.Errorf("invalid type for Feature: %s", feature.Type)
+	}
+
+	f.ID = feature.ID
+	f.BBox = feature.BBox
+	f.Geometry = feature.Geometry
+	f.Properties = feature.Properties
+
+	return nil
+}
+
+// FeatureCollection is a GeoJSON FeatureCollection whose features all share the properties type P.
+type FeatureCollection[P any] struct {
+	BBox     *BBox
+	Features []*Feature[P]
+}
+
+func (fc *FeatureCollection[P]) MarshalJSON() ([]byte, error) {
+	features := fc.Features
+	if features == nil {
+		features = []*Feature[P]{}
+	}
+
+	return json.Marshal(struct {
+		Type     string        `json:"type"`
+		BBox     *BBox         `json:"bbox,omitempty"`
+		Features []*Feature[P] `json:"features"`
+	}{
+		Type:     "FeatureCollection",
+		BBox:     fc.BBox,
+		Features: features,
+	})
+}
+
+func (fc *FeatureCollection[P]) UnmarshalJSON(data []byte) error {
+	var featureCollection struct {
+		Type     string        `json:"type"`
+		BBox     *BBox         `json:"bbox"`
+		Features []*Feature[P] `json:"features"`
+	}
+	if err := json.Unmarshal(data, &featureCollection); err != nil {
+		return err
+	}
+	if featureCollection.Type != "FeatureCollection" {
+		return fmt.Errorf("invalid type for FeatureCollection: %s", featureCollection.Type)
+	}
+	for _, feature := range featureCollection.Features {
+		if feature == nil {
+			return errors.New("invalid null feature in FeatureCollection")
+		}
+	}
+
+	fc.BBox = featureCollection.BBox
+	fc.Features = featureCollection.Features
+
+	return nil
+}
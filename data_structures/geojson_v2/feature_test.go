@@ -0,0 +1,74 @@
+package geojson_v2
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+type testProperties struct {
+	Name string `json:"name"`
+}
+
+func TestFeatureCollectionRoundTrip(t *testing.T) {
+	data := `{"type":"FeatureCollection","bbox":[-100,35,-96,41.26],"features":[` +
+		`{"type":"Feature","id":"a","bbox":[-100,35,-98,37],"geometry":{"type":"Polygon","coordinates":[[[-100,35],[-98,35],[-98,37],[-100,37],[-100,35]]]},"properties":{"name":"first"}},` +
+		`{"type":"Feature","geometry":null,"properties":{"name":"second"}}]}`
+
+	var featureCollection FeatureCollection[testProperties]
+	err := json.Unmarshal([]byte(data), &featureCollection)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if featureCollection.BBox == nil || *featureCollection.BBox != (BBox{MinLongitude: -100, MinLatitude: 35, MaxLongitude: -96, MaxLatitude: 41.26}) {
+		t.Errorf("BBox = %+v", featureCollection.BBox)
+	}
+	if len(featureCollection.Features) != 2 {
+		t.Fatalf("features = %d, want 2", len(featureCollection.Features))
+	}
+	if featureCollection.Features[0].BBox == nil || featureCollection.Features[0].BBox.MaxLongitude != -98 {
+		t.Errorf("feature BBox = %+v", featureCollection.Features[0].BBox)
+	}
+	if featureCollection.Features[1].Geometry != nil || featureCollection.Features[1].Properties.Name != "second" {
+		t.Errorf("second feature = %+v", featureCollection.Features[1])
+	}
+
+	marshalled, err := json.Marshal(&featureCollection)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(marshalled) != data {
+		t.Errorf("round trip\n got: %s\nwant: %s", marshalled, data)
+	}
+}
+
+func TestFeatureCollectionRejectsInvalidMembers(t *testing.T) {
+	tests := []struct {
+		data string
+		want string
+	}{
+		{data: `{"type":"FeatureCollection","features":[null]}`, want: "null feature"},
+		{data: `{"type":"Feature","features":[]}`, want: "invalid type for FeatureCollection"},
+		{data: `{"type":"FeatureCollection","features":[{"type":"Point","properties":{}}]}`, want: "invalid type for Feature"},
+		{data: `{"type":"FeatureCollection","bbox":[1,2,3],"features":[]}`, want: "invalid number of coordinates for BBox"},
+	}
+
+	for _, test := range tests {
+		var featureCollection FeatureCollection[testProperties]
+		err := json.Unmarshal([]byte(test.data), &featureCollection)
+		if err == nil || !strings.Contains(err.Error(), test.want) {
+			t.Errorf("%s: err = %v, want %s", test.data, err, test.want)
+		}
+	}
+}
+
+func TestFeatureCollectionWithoutFeatures(t *testing.T) {
+	marshalled, err := json.Marshal(&FeatureCollection[testProperties]{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(marshalled) != `{"type":"FeatureCollection","features":[]}` {
+		t.Errorf("marshalled = %s", marshalled)
+	}
+}
@@ -0,0 +1,74 @@
+package geojson_v2
+
+import "math"
+
+// earthRadiusMeters is the mean radius of the earth used for spherical calculations.
+const earthRadiusMeters = 6371008.8
+
+// metersPerDegree is the length of one degree of a great circle on the mean earth.
+const metersPerDegree = earthRadiusMeters * math.Pi / 180
+
+func degreesToRadians(degrees float64) float64 {
+	return degrees * math.Pi / 180
+}
+
+func radiansToDegrees(radians float64) float64 {
+	return radians * 180 / math.Pi
+}
+
+// haversineDistance returns the great circle distance between two points in meters.
+func haversineDistance(a, b Point) float64 {
+	lat1 := degreesToRadians(a.Latitude)
+	lat2 := degreesToRadians(b.Latitude)
+	deltaLat := lat2 - lat1
+	deltaLon := degreesToRadians(b.Longitude - a.Longitude)
+
+	h := math.Sin(deltaLat/2)*math.Sin(deltaLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(deltaLon/2)*math.Sin(deltaLon/2)
+	return 2 * earthRadiusMeters * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+// pathLength returns the great circle length of the path in meters.
+func pathLength(points []*Point) float64 {
+	length := 0.0
+	for i := 1; i < len(points); i++ {
+		length += haversineDistance(*points[i-1], *points[i])
+	}
+	return length
+}
+
+// ringGeodesicArea returns the unsigned area of the ring on the sphere in square meters, using the method from
+// Chamberlain and Duquette, "Some Algorithms for Polygons on a Sphere".
+func ringGeodesicArea(ring *MultiPoint) float64 {
+	if ring == nil || len(ring.Points) < 3 {
+		return 0
+	}
+
+	points := ring.Points
+	if ringIsClosed(ring) {
+		points = points[:len(points)-1]
+	}
+
+	area := 0.0
+	for i := range points {
+		lower := points[i]
+		middle := points[(i+1)%len(points)]
+		upper := points[(i+2)%len(points)]
+		area += (degreesToRadians(upper.Longitude) - degreesToRadians(lower.Longitude)) * math.Sin(degreesToRadians(middle.Latitude))
+	}
+
+	return math.Abs(area * earthRadiusMeters * earthRadiusMeters / 2)
+}
+
+// destinationPoint returns the point reached by travelling the distance in meters along the great circle starting at
+// the initial bearing in degrees.
+func destinationPoint(origin Point, bearing, distance float64) Point {
+	lat1 := degreesToRadians(origin.Latitude)
+	lon1 := degreesToRadians(origin.Longitude)
+	theta := degreesToRadians(bearing)
+	delta := distance / earthRadiusMeters
+
+	lat2 := math.Asin(math.Sin(lat1)*math.Cos(delta) + math.Cos(lat1)*math.Sin(delta)*math.Cos(theta))
+	lon2 := lon1 + math.Atan2(math.Sin(theta)*math.Sin(delta)*math.Cos(lat1), math.Cos(delta)-math.Sin(lat1)*math.Sin(lat2))
+
+	return Point{Latitude: radiansToDegrees(lat2), Longitude: wrapLongitude(radiansToDegrees(lon2))}
+}
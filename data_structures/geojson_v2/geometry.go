@@ -8,9 +8,30 @@ import (
 type Geometry struct {
 	Point              *Point
 	MultiPoint         *MultiPoint
+	LineString         *LineString
+	MultiLineString    *MultiLineString
 	Polygon            *Polygon
 	MultiPolygon       *MultiPolygon
 	GeometryCollection *GeometryCollection
+
+	// IncludeBBox adds the optional bbox member when the geometry is marshalled. It is set when an unmarshalled
+	// geometry carried a bbox.
+	IncludeBBox bool
+
+	// Encoding selects how the coordinates are written when the geometry is marshalled. It is not kept from
+	// unmarshalled geometries, so they are written back as GeoJSON unless an encoding is chosen.
+	Encoding CoordinateEncoding
+}
+
+// MarshalJSONWithEncoding marshals the geometry with its coordinates written in the encoding.
+func (g *Geometry) MarshalJSONWithEncoding(encoding CoordinateEncoding) ([]byte, error) {
+	if g == nil {
+		return []byte("null"), nil
+	}
+
+	encoded := *g
+	encoded.Encoding = encoding
+	return encoded.MarshalJSON()
 }
 
 func (g *Geometry) MarshalJSON() ([]byte, error) {
@@ -18,38 +39,87 @@ func (g *Geometry) MarshalJSON() ([]byte, error) {
 		return []byte("null"), nil
 	}
 
+	var bbox *BBox
+	if g.IncludeBBox {
+		bbox = g.BBox()
+	}
+
+	if g.Encoding.compact() {
+		return g.marshalCompactJSON(bbox)
+	} else if g.Encoding != "" && g.Encoding != CoordinateEncoding_GeoJSON {
+		return nil, fmt.Errorf("unsupported coordinate encoding: %s", g.Encoding)
+	}
+
 	if g.Point != nil {
 		return json.Marshal(struct {
 			Type        string `json:"type"`
+			BBox        *BBox  `json:"bbox,omitempty"`
 			Coordinates *Point `json:"coordinates"`
 		}{
 			Type:        "Point",
+			BBox:        bbox,
 			Coordinates: g.Point,
 		})
 	} else if g.MultiPoint != nil {
 		return json.Marshal(struct {
 			Type        string      `json:"type"`
+			BBox        *BBox       `json:"bbox,omitempty"`
 			Coordinates *MultiPoint `json:"coordinates"`
 		}{
 			Type:        "MultiPoint",
+			BBox:        bbox,
 			Coordinates: g.MultiPoint,
 		})
+	} else if g.LineString != nil {
+		return json.Marshal(struct {
+			Type        string      `json:"type"`
+			BBox        *BBox       `json:"bbox,omitempty"`
+			Coordinates *LineString `json:"coordinates"`
+		}{
+			Type:        "LineString",
+			BBox:        bbox,
+			Coordinates: g.LineString,
+		})
+	} else if g.MultiLineString != nil {
+		return json.Marshal(struct {
+			Type        string           `json:"type"`
+			BBox        *BBox            `json:"bbox,omitempty"`
+			Coordinates *MultiLineString `json:"coordinates"`
+		}{
+			Type:        "MultiLineString",
+			BBox:        bbox,
+			Coordinates: g.MultiLineString,
+		})
 	} else if g.Polygon != nil {
 		return json.Marshal(struct {
 			Type        string   `json:"type"`
+			BBox        *BBox    `json:"bbox,omitempty"`
 			Coordinates *Polygon `json:"coordinates"`
 		}{
 			Type:        "Polygon",
+			BBox:        bbox,
 			Coordinates: g.Polygon,
 		})
 	} else if g.MultiPolygon != nil {
 		return json.Marshal(struct {
 			Type        string        `json:"type"`
+			BBox        *BBox         `json:"bbox,omitempty"`
 			Coordinates *MultiPolygon `json:"coordinates"`
 		}{
 			Type:        "MultiPolygon",
+			BBox:        bbox,
 			Coordinates: g.MultiPolygon,
 		})
+	} else if g.GeometryCollection != nil {
+		return json.Marshal(struct {
+			Type       string              `json:"type"`
+			BBox       *BBox               `json:"bbox,omitempty"`
+			Geometries *GeometryCollection `json:"geometries"`
+		}{
+			Type:       "GeometryCollection",
+			BBox:       bbox,
+			Geometries: g.GeometryCollection,
+		})
 	} else {
 		return []byte("null"), nil
 	}
@@ -72,6 +142,40 @@ func (g *Geometry) UnmarshalJSON(data []byte) error {
 		return err
 	}
 
+	coordinates, _ := raw["coordinates"]
+	_, g.IncludeBBox = raw["bbox"]
+
+	encoding, ok := raw["encoding"]
+	if ok {
+		err = json.Unmarshal(encoding, &g.Encoding)
+		if err != nil {
+			return err
+		}
+	}
+
+	if g.Encoding != "" && g.Encoding != CoordinateEncoding_GeoJSON && !g.Encoding.compact() {
+		return fmt.Errorf("unsupported coordinate encoding: %s", g.Encoding)
+	} else if g.Encoding.compact() && geometryType != "GeometryCollection" {
+		err = g.unmarshalCompactCoordinates(geometryType, coordinates)
+		if err != nil {
+			return err
+		}
+	} else {
+		err = g.unmarshalCoordinates(geometryType, raw)
+		if err != nil {
+			return err
+		}
+	}
+
+	if g.CrossesAntimeridian() {
+		*g = *g.SplitAntimeridian()
+	}
+	g.Encoding = ""
+
+	return nil
+}
+
+func (g *Geometry) unmarshalCoordinates(geometryType string, raw map[string]json.RawMessage) error {
 	coordinates, _ := raw["coordinates"]
 
 	switch geometryType {
@@ -85,6 +189,16 @@ func (g *Geometry) UnmarshalJSON(data []byte) error {
 		if err != nil {
 			return err
 		}
+	case "LineString":
+		err := json.Unmarshal(coordinates, &g.LineString)
+		if err != nil {
+			return err
+		}
+	case "MultiLineString":
+		err := json.Unmarshal(coordinates, &g.MultiLineString)
+		if err != nil {
+			return err
+		}
 	case "Polygon":
 		err := json.Unmarshal(coordinates, &g.Polygon)
 		if err != nil {
@@ -97,9 +211,44 @@ func (g *Geometry) UnmarshalJSON(data []byte) error {
 		}
 	case "GeometryCollection":
 		g.GeometryCollection = &GeometryCollection{}
+		geometries, ok := raw["geometries"]
+		if !ok {
+			break
+		}
+
+		err := json.Unmarshal(geometries, &g.GeometryCollection)
+		if err != nil {
+			return err
+		}
 	default:
 		return fmt.Errorf("unsupported geometry type: %s", geometryType)
 	}
 
 	return nil
 }
+
+// Contains reports whether the point lies in the interior of the geometry. It is the in-memory counterpart to the
+// ST_Contains checks done by the sql package.
+func (g *Geometry) Contains(point Point) bool {
+	if g == nil {
+		return false
+	}
+
+	if g.Point != nil {
+		return g.Point.Equal(point)
+	} else if g.MultiPoint != nil {
+		return g.MultiPoint.Contains(point)
+	} else if g.LineString != nil {
+		return g.LineString.Contains(point)
+	} else if g.MultiLineString != nil {
+		return g.MultiLineString.Contains(point)
+	} else if g.Polygon != nil {
+		return g.Polygon.Contains(point)
+	} else if g.MultiPolygon != nil {
+		return g.MultiPolygon.Contains(point)
+	} else if g.GeometryCollection != nil {
+		return g.GeometryCollection.Contains(point)
+	}
+
+	return false
+}
@@ -1,4 +1,43 @@
 package geojson_v2
 
+import (
+	"encoding/json"
+	"errors"
+)
+
 type GeometryCollection struct {
+	Geometries []*Geometry
+}
+
+func (gc *GeometryCollection) MarshalJSON() ([]byte, error) {
+	geometries := gc.Geometries
+	if geometries == nil {
+		geometries = []*Geometry{}
+	}
+	return json.Marshal(geometries)
+}
+
+func (gc *GeometryCollection) UnmarshalJSON(data []byte) error {
+	var geometries []*Geometry
+	if err := json.Unmarshal(data, &geometries); err != nil {
+		return err
+	}
+	for _, geometry := range geometries {
+		if geometry == nil {
+			return errors.New("invalid null geometry in GeometryCollection")
+		}
+	}
+	gc.Geometries = geometries
+	return nil
+}
+
+// Contains reports whether the point lies in the interior of any of the geometries in the collection.
+func (gc *GeometryCollection) Contains(point Point) bool {
+	for _, geometry := range gc.Geometries {
+		if geometry.Contains(point) {
+			return true
+		}
+	}
+
+	return false
 }
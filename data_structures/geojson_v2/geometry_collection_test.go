@@ -0,0 +1,66 @@
+package geojson_v2
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestGeometryCollectionRoundTrip(t *testing.T) {
+	for _, data := range []string{
+		`{"type":"GeometryCollection","geometries":[]}`,
+		`{"type":"GeometryCollection","geometries":[{"type":"Point","coordinates":[-96.05,41.26]},{"type":"Polygon","coordinates":[[[-100,35],[-98,35],[-98,37],[-100,37],[-100,35]]]}]}`,
+		`{"type":"GeometryCollection","geometries":[{"type":"Point","coordinates":[-96.05,41.26,300]},{"type":"GeometryCollection","geometries":[{"type":"LineString","coordinates":[[-96.05,41.26],[-96.2,41.1]]},{"type":"GeometryCollection","geometries":[{"type":"MultiPoint","coordinates":[[-97,38],[-96,39]]}]}]}]}`,
+	} {
+		geometry := mustGeometry(t, data)
+
+		marshalled, err := json.Marshal(geometry)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(marshalled) != data {
+			t.Errorf("round trip\n got: %s\nwant: %s", marshalled, data)
+		}
+	}
+}
+
+func TestGeometryCollectionNested(t *testing.T) {
+	geometry := mustGeometry(t, `{"type":"GeometryCollection","geometries":[{"type":"GeometryCollection","geometries":[`+
+		`{"type":"Polygon","coordinates":[[[0,0],[2,0],[2,2],[0,2],[0,0]]]}]}]}`)
+
+	inner := geometry.GeometryCollection.Geometries[0].GeometryCollection
+	if inner == nil || len(inner.Geometries) != 1 || inner.Geometries[0].Polygon == nil {
+		t.Fatalf("inner collection = %+v", inner)
+	}
+	if !geometry.Contains(Point{Longitude: 1, Latitude: 1}) {
+		t.Error("nested polygon does not contain the point")
+	}
+}
+
+func TestGeometryCollectionRejectsNullMembers(t *testing.T) {
+	for _, data := range []string{
+		`{"type":"GeometryCollection","geometries":[null]}`,
+		`{"type":"GeometryCollection","geometries":[{"type":"Point","coordinates":[0,0]},{"type":"GeometryCollection","geometries":[null]}]}`,
+	} {
+		var geometry Geometry
+		err := json.Unmarshal([]byte(data), &geometry)
+		if err == nil || !strings.Contains(err.Error(), "null geometry") {
+			t.Errorf("%s: err = %v, want null geometry rejected", data, err)
+		}
+	}
+}
+
+func TestGeometryCollectionWithoutGeometries(t *testing.T) {
+	geometry := mustGeometry(t, `{"type":"GeometryCollection"}`)
+	if geometry.GeometryCollection == nil || len(geometry.GeometryCollection.Geometries) != 0 {
+		t.Errorf("GeometryCollection = %+v, want an empty collection", geometry.GeometryCollection)
+	}
+
+	marshalled, err := json.Marshal(geometry)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(marshalled) != `{"type":"GeometryCollection","geometries":[]}` {
+		t.Errorf("marshalled = %s", marshalled)
+	}
+}
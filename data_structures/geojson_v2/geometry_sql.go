@@ -0,0 +1,60 @@
+package geojson_v2
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"encoding/hex"
+	"fmt"
+)
+
+var _ sql.Scanner = (*Geometry)(nil)
+var _ driver.Valuer = (*Geometry)(nil)
+
+// Scan reads a PostGIS geometry column. PostGIS returns geometry as hex encoded EWKB in text mode, while ST_AsBinary
+// and ST_AsEWKB return raw bytes; both are accepted.
+func (g *Geometry) Scan(src interface{}) error {
+	switch value := src.(type) {
+	case nil:
+		*g = Geometry{}
+		return nil
+	case string:
+		return g.scanHex(value)
+	case []byte:
+		if len(value) > 0 && (value[0] == wkbXDR || value[0] == wkbNDR) {
+			return g.UnmarshalWKB(value)
+		}
+		return g.scanHex(string(value))
+	default:
+		return fmt.Errorf("unsupported type for Geometry: %T", src)
+	}
+}
+
+// Value writes the geometry as hex encoded EWKB in WGS84, which PostGIS accepts as input for a geometry parameter. An
+// empty geometry is written as NULL.
+func (g *Geometry) Value() (driver.Value, error) {
+	if g.IsEmpty() {
+		return nil, nil
+	}
+
+	ewkb, err := g.MarshalEWKB(WGS84SRID)
+	if err != nil {
+		return nil, err
+	}
+
+	return hex.EncodeToString(ewkb), nil
+}
+
+// IsEmpty reports whether no geometry type is set.
+func (g *Geometry) IsEmpty() bool {
+	return g == nil || (g.Point == nil && g.MultiPoint == nil && g.LineString == nil && g.MultiLineString == nil &&
+		g.Polygon == nil && g.MultiPolygon == nil && g.GeometryCollection == nil)
+}
+
+func (g *Geometry) scanHex(value string) error {
+	ewkb, err := hex.DecodeString(value)
+	if err != nil {
+		return err
+	}
+
+	return g.UnmarshalWKB(ewkb)
+}
@@ -0,0 +1,78 @@
+package geojson_v2
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func mustGeometry(t *testing.T, data string) *Geometry {
+	t.Helper()
+
+	var geometry Geometry
+	err := json.Unmarshal([]byte(data), &geometry)
+	if err != nil {
+		t.Fatalf("invalid geometry %s: %v", data, err)
+	}
+	return &geometry
+}
+
+func TestContains(t *testing.T) {
+	polygonWithHole := `{"type":"Polygon","coordinates":[[[0,0],[10,0],[10,10],[0,10],[0,0]],[[4,4],[4,6],[6,6],[6,4],[4,4]]]}`
+	multiPolygon := `{"type":"MultiPolygon","coordinates":[[[[0,0],[2,0],[2,2],[0,2],[0,0]]],[[[5,5],[7,5],[7,7],[5,7],[5,5]]]]}`
+	collection := `{"type":"GeometryCollection","geometries":[{"type":"Point","coordinates":[20,20]},` +
+		`{"type":"LineString","coordinates":[[30,30],[32,30]]},{"type":"Polygon","coordinates":[[[0,0],[2,0],[2,2],[0,2],[0,0]]]}]}`
+
+	tests := []struct {
+		name     string
+		geometry string
+		point    Point
+		want     bool
+	}{
+		{name: "polygon interior", geometry: polygonWithHole, point: Point{Longitude: 2, Latitude: 2}, want: true},
+		{name: "polygon hole", geometry: polygonWithHole, point: Point{Longitude: 5, Latitude: 5}, want: false},
+		{name: "polygon hole edge", geometry: polygonWithHole, point: Point{Longitude: 4, Latitude: 5}, want: false},
+		{name: "polygon edge", geometry: polygonWithHole, point: Point{Longitude: 10, Latitude: 5}, want: false},
+		{name: "polygon vertex", geometry: polygonWithHole, point: Point{Longitude: 0, Latitude: 0}, want: false},
+		{name: "polygon exterior", geometry: polygonWithHole, point: Point{Longitude: 11, Latitude: 5}, want: false},
+		{name: "multi polygon first", geometry: multiPolygon, point: Point{Longitude: 1, Latitude: 1}, want: true},
+		{name: "multi polygon second", geometry: multiPolygon, point: Point{Longitude: 6, Latitude: 6}, want: true},
+		{name: "multi polygon between", geometry: multiPolygon, point: Point{Longitude: 3, Latitude: 3}, want: false},
+		{name: "multi polygon shared vertex", geometry: multiPolygon, point: Point{Longitude: 2, Latitude: 2}, want: false},
+		{name: "collection point", geometry: collection, point: Point{Longitude: 20, Latitude: 20}, want: true},
+		{name: "collection line interior", geometry: collection, point: Point{Longitude: 31, Latitude: 30}, want: true},
+		{name: "collection line endpoint", geometry: collection, point: Point{Longitude: 30, Latitude: 30}, want: false},
+		{name: "collection polygon", geometry: collection, point: Point{Longitude: 1, Latitude: 1}, want: true},
+		{name: "collection none", geometry: collection, point: Point{Longitude: 10, Latitude: 10}, want: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			geometry := mustGeometry(t, test.geometry)
+			if got := geometry.Contains(test.point); got != test.want {
+				t.Errorf("Contains(%v) = %v, want %v", test.point, got, test.want)
+			}
+		})
+	}
+}
+
+func TestContainsWithMissingPoints(t *testing.T) {
+	ring := &MultiPoint{Points: []*Point{
+		{Longitude: 0, Latitude: 0}, nil, {Longitude: 10, Latitude: 10}, {Longitude: 0, Latitude: 10}, {Longitude: 0, Latitude: 0},
+	}}
+	geometries := []*Geometry{
+		{Polygon: &Polygon{OuterPath: ring}},
+		{LineString: &LineString{Points: ring.Points}},
+		{MultiPoint: ring},
+	}
+
+	for _, geometry := range geometries {
+		if geometry.Contains(Point{Longitude: 2, Latitude: 5}) {
+			t.Errorf("geometry with a missing position contains the point")
+		}
+
+		err := geometry.Validate()
+		if err == nil {
+			t.Errorf("Validate = nil, want the missing position reported")
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package geojson_v2
+
+// IntersectsBBox reports whether the geometry touches or overlaps the box.
+func (g *Geometry) IntersectsBBox(bbox BBox) bool {
+	bounds := g.BBox()
+	if bounds == nil || !bounds.Intersects(bbox) {
+		return false
+	}
+
+	paths := geometryPaths(g)
+	for _, part := range bbox.Split() {
+		if g.intersectsPlanarBBox(paths, part) {
+			return true
+		}
+	}
+	return false
+}
+
+// intersectsPlanarBBox reports whether the paths of the geometry touch or overlap a box that does not cross the
+// antimeridian.
+func (g *Geometry) intersectsPlanarBBox(paths [][]*Point, bbox BBox) bool {
+	for _, path := range paths {
+		for _, point := range path {
+			if bbox.Contains(*point) {
+				return true
+			}
+		}
+	}
+
+	corners := []Point{
+		{Longitude: bbox.MinLongitude, Latitude: bbox.MinLatitude},
+		{Longitude: bbox.MaxLongitude, Latitude: bbox.MinLatitude},
+		{Longitude: bbox.MaxLongitude, Latitude: bbox.MaxLatitude},
+		{Longitude: bbox.MinLongitude, Latitude: bbox.MaxLatitude},
+	}
+	for _, path := range paths {
+		for i := 1; i < len(path); i++ {
+			for j := range corners {
+				if segmentsIntersect(*path[i-1], *path[i], corners[j], corners[(j+1)%len(corners)]) {
+					return true
+				}
+			}
+		}
+	}
+
+	return g.Contains(corners[0])
+}
+
+// geometryPaths returns every line and ring of the geometry, with each single position returned as a path of one.
+func geometryPaths(g *Geometry) [][]*Point {
+	if g == nil {
+		return nil
+	}
+
+	var paths [][]*Point
+	if g.Point != nil {
+		paths = append(paths, []*Point{g.Point})
+	} else if g.MultiPoint != nil {
+		for _, point := range g.MultiPoint.Points {
+			paths = append(paths, []*Point{point})
+		}
+	} else if g.LineString != nil {
+		paths = append(paths, g.LineString.Points)
+	} else if g.MultiLineString != nil {
+		for _, lineString := range g.MultiLineString.LineStrings {
+			paths = append(paths, lineString.Points)
+		}
+	} else if g.Polygon != nil {
+		paths = append(paths, polygonPaths(g.Polygon)...)
+	} else if g.MultiPolygon != nil {
+		for _, polygon := range g.MultiPolygon.Polygons {
+			paths = append(paths, polygonPaths(polygon)...)
+		}
+	} else if g.GeometryCollection != nil {
+		for _, geometry := range g.GeometryCollection.Geometries {
+			paths = append(paths, geometryPaths(geometry)...)
+		}
+	}
+
+	return paths
+}
+
+func polygonPaths(polygon *Polygon) [][]*Point {
+	var paths [][]*Point
+	if polygon.OuterPath != nil {
+		paths = append(paths, closedRingPoints(polygon.OuterPath))
+	}
+	for _, innerPath := range polygon.InnerPaths {
+		paths = append(paths, closedRingPoints(innerPath))
+	}
+	return paths
+}
@@ -0,0 +1,73 @@
+package geojson_v2
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+type LineString struct {
+	Points []*Point
+}
+
+func (ls *LineString) MarshalJSON() ([]byte, error) {
+	return json.Marshal(ls.encodeLineString())
+}
+
+func (ls *LineString) UnmarshalJSON(data []byte) error {
+	var coordinates [][]float64
+	if err := json.Unmarshal(data, &coordinates); err != nil {
+		return err
+	}
+
+	return ls.decodeLineString(coordinates)
+}
+
+// Contains reports whether the point lies on the interior of the line. The endpoints of an open line are its
+// boundary and are not contained.
+func (ls *LineString) Contains(point Point) bool {
+	if len(ls.Points) < 2 || hasMissingPoint(ls.Points) {
+		return false
+	}
+
+	first := ls.Points[0]
+	last := ls.Points[len(ls.Points)-1]
+	if !first.Equal(*last) && (first.Equal(point) || last.Equal(point)) {
+		return false
+	}
+
+	for i := 1; i < len(ls.Points); i++ {
+		if pointOnSegment(point, *ls.Points[i-1], *ls.Points[i]) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (ls *LineString) encodeLineString() [][]float64 {
+	coordinates := make([][]float64, len(ls.Points))
+	for i, point := range ls.Points {
+		coordinates[i] = point.encodePoint()
+	}
+	return coordinates
+}
+
+func (ls *LineString) decodeLineString(lineString [][]float64) error {
+	if len(lineString) < 2 {
+		return fmt.Errorf("invalid number of points for LineString: %v", lineString)
+	}
+
+	points := make([]*Point, len(lineString))
+	for i, point := range lineString {
+		pt := &Point{}
+		err := pt.decodePoint(point)
+		if err != nil {
+			return err
+		}
+
+		points[i] = pt
+	}
+	ls.Points = points
+
+	return nil
+}
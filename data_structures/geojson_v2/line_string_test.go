@@ -0,0 +1,72 @@
+package geojson_v2
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestLineStringRoundTrip(t *testing.T) {
+	for _, data := range []string{
+		`{"type":"LineString","coordinates":[[-96.05,41.26],[-96.2,41.1]]}`,
+		`{"type":"LineString","coordinates":[[-96.05,41.26,300],[-96.2,41.1,310],[-96.4,41]]}`,
+		`{"type":"MultiLineString","coordinates":[[[-96.05,41.26],[-96.2,41.1]],[[-97,40],[-97.5,40.5],[-98,40]]]}`,
+		`{"type":"MultiLineString","coordinates":[]}`,
+	} {
+		geometry := mustGeometry(t, data)
+
+		marshalled, err := json.Marshal(geometry)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(marshalled) != data {
+			t.Errorf("round trip\n got: %s\nwant: %s", marshalled, data)
+		}
+	}
+}
+
+func TestLineStringRejectsShortLines(t *testing.T) {
+	for _, data := range []string{
+		`{"type":"LineString","coordinates":[]}`,
+		`{"type":"LineString","coordinates":[[-96.05,41.26]]}`,
+		`{"type":"MultiLineString","coordinates":[[[-96.05,41.26],[-96.2,41.1]],[[-97,40]]]}`,
+		`{"type":"LineString","coordinates":[[-96.05],[-96.2,41.1]]}`,
+	} {
+		var geometry Geometry
+		err := json.Unmarshal([]byte(data), &geometry)
+		if err == nil {
+			t.Errorf("%s: err = nil, want an error", data)
+		}
+	}
+}
+
+func TestLineStringContains(t *testing.T) {
+	line := `{"type":"LineString","coordinates":[[0,0],[2,0],[2,2]]}`
+	ring := `{"type":"LineString","coordinates":[[0,0],[2,0],[2,2],[0,0]]}`
+	multiLine := `{"type":"MultiLineString","coordinates":[[[0,0],[2,0]],[[5,5],[5,7]]]}`
+
+	tests := []struct {
+		name     string
+		geometry string
+		point    Point
+		want     bool
+	}{
+		{name: "segment interior", geometry: line, point: Point{Longitude: 1, Latitude: 0}, want: true},
+		{name: "inner vertex", geometry: line, point: Point{Longitude: 2, Latitude: 0}, want: true},
+		{name: "start", geometry: line, point: Point{Longitude: 0, Latitude: 0}, want: false},
+		{name: "end", geometry: line, point: Point{Longitude: 2, Latitude: 2}, want: false},
+		{name: "off the line", geometry: line, point: Point{Longitude: 1, Latitude: 1}, want: false},
+		{name: "closed line start", geometry: ring, point: Point{Longitude: 0, Latitude: 0}, want: true},
+		{name: "closed line diagonal", geometry: ring, point: Point{Longitude: 1, Latitude: 1}, want: true},
+		{name: "multi line second", geometry: multiLine, point: Point{Longitude: 5, Latitude: 6}, want: true},
+		{name: "multi line endpoint", geometry: multiLine, point: Point{Longitude: 5, Latitude: 7}, want: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			geometry := mustGeometry(t, test.geometry)
+			if got := geometry.Contains(test.point); got != test.want {
+				t.Errorf("Contains(%v) = %v, want %v", test.point, got, test.want)
+			}
+		})
+	}
+}
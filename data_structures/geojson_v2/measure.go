@@ -0,0 +1,143 @@
+package geojson_v2
+
+// Area returns the geodesic area of the geometry in square meters. Only polygons have an area, and inner paths are
+// subtracted from the outer path.
+func (g *Geometry) Area() float64 {
+	if g == nil {
+		return 0
+	}
+
+	if g.Polygon != nil {
+		return g.Polygon.Area()
+	} else if g.MultiPolygon != nil {
+		return g.MultiPolygon.Area()
+	} else if g.GeometryCollection != nil {
+		return g.GeometryCollection.Area()
+	}
+
+	return 0
+}
+
+// Perimeter returns the geodesic length of every path of the polygons in the geometry in meters. Points and lines have
+// no perimeter, matching PostGIS ST_Perimeter.
+func (g *Geometry) Perimeter() float64 {
+	if g == nil {
+		return 0
+	}
+
+	if g.Polygon != nil {
+		return g.Polygon.Perimeter()
+	} else if g.MultiPolygon != nil {
+		return g.MultiPolygon.Perimeter()
+	} else if g.GeometryCollection != nil {
+		return g.GeometryCollection.Perimeter()
+	}
+
+	return 0
+}
+
+// Area always returns 0.
+func (p *Point) Area() float64 {
+	return 0
+}
+
+// Perimeter always returns 0.
+func (p *Point) Perimeter() float64 {
+	return 0
+}
+
+// Area always returns 0.
+func (mp *MultiPoint) Area() float64 {
+	return 0
+}
+
+// Perimeter always returns 0.
+func (mp *MultiPoint) Perimeter() float64 {
+	return 0
+}
+
+// Area always returns 0.
+func (ls *LineString) Area() float64 {
+	return 0
+}
+
+// Perimeter always returns 0.
+func (ls *LineString) Perimeter() float64 {
+	return 0
+}
+
+// Area always returns 0.
+func (mls *MultiLineString) Area() float64 {
+	return 0
+}
+
+// Perimeter always returns 0.
+func (mls *MultiLineString) Perimeter() float64 {
+	return 0
+}
+
+// Area returns the geodesic area of the outer path minus the inner paths in square meters.
+func (pg *Polygon) Area() float64 {
+	area := ringGeodesicArea(pg.OuterPath)
+	for _, innerPath := range pg.InnerPaths {
+		area -= ringGeodesicArea(innerPath)
+	}
+	return max(area, 0)
+}
+
+// Perimeter returns the geodesic length of the outer and inner paths in meters.
+func (pg *Polygon) Perimeter() float64 {
+	perimeter := 0.0
+	if pg.OuterPath != nil {
+		perimeter += pathLength(closedRingPoints(pg.OuterPath))
+	}
+	for _, innerPath := range pg.InnerPaths {
+		perimeter += pathLength(closedRingPoints(innerPath))
+	}
+	return perimeter
+}
+
+// Area returns the sum of the geodesic areas of the polygons in square meters.
+func (mpg *MultiPolygon) Area() float64 {
+	area := 0.0
+	for _, polygon := range mpg.Polygons {
+		area += polygon.Area()
+	}
+	return area
+}
+
+// Perimeter returns the sum of the perimeters of the polygons in meters.
+func (mpg *MultiPolygon) Perimeter() float64 {
+	perimeter := 0.0
+	for _, polygon := range mpg.Polygons {
+		perimeter += polygon.Perimeter()
+	}
+	return perimeter
+}
+
+// Area returns the sum of the areas of the geometries in the collection in square meters.
+func (gc *GeometryCollection) Area() float64 {
+	area := 0.0
+	for _, geometry := range gc.Geometries {
+		area += geometry.Area()
+	}
+	return area
+}
+
+// Perimeter returns the sum of the perimeters of the geometries in the collection in meters.
+func (gc *GeometryCollection) Perimeter() float64 {
+	perimeter := 0.0
+	for _, geometry := range gc.Geometries {
+		perimeter += geometry.Perimeter()
+	}
+	return perimeter
+}
+
+// closedRingPoints returns the positions of the ring with the first position repeated at the end when the ring is not
+// explicitly closed.
+func closedRingPoints(ring *MultiPoint) []*Point {
+	if len(ring.Points) == 0 || ringIsClosed(ring) {
+		return ring.Points
+	}
+	return append(ring.Points[:len(ring.Points):len(ring.Points)], ring.Points[0])
+}
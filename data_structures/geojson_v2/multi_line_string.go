@@ -0,0 +1,49 @@
+package geojson_v2
+
+import "encoding/json"
+
+type MultiLineString struct {
+	LineStrings []*LineString
+}
+
+func (mls *MultiLineString) MarshalJSON() ([]byte, error) {
+	coordinates := make([][][]float64, len(mls.LineStrings))
+	for i, lineString := range mls.LineStrings {
+		coordinates[i] = lineString.encodeLineString()
+	}
+	return json.Marshal(coordinates)
+}
+
+func (mls *MultiLineString) UnmarshalJSON(data []byte) error {
+	var coordinates [][][]float64
+	if err := json.Unmarshal(data, &coordinates); err != nil {
+		return err
+	}
+	return mls.decode(coordinates)
+}
+
+// Contains reports whether the point lies on the interior of any of the lines.
+func (mls *MultiLineString) Contains(point Point) bool {
+	for _, lineString := range mls.LineStrings {
+		if lineString.Contains(point) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (mls *MultiLineString) decode(coordinates [][][]float64) error {
+	lineStrings := make([]*LineString, len(coordinates))
+	for i, lineStringCoords := range coordinates {
+		lineString := &LineString{}
+		err := lineString.decodeLineString(lineStringCoords)
+		if err != nil {
+			return err
+		}
+
+		lineStrings[i] = lineString
+	}
+	mls.LineStrings = lineStrings
+	return nil
+}
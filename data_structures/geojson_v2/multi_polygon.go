@@ -36,3 +36,14 @@ func (mpg *MultiPolygon) decode(coordinates [][][][]float64) error {
 	mpg.Polygons = polygons
 	return nil
 }
+
+// Contains reports whether the point lies in the interior of any of the polygons.
+func (mpg *MultiPolygon) Contains(point Point) bool {
+	for _, polygon := range mpg.Polygons {
+		if polygon.Contains(point) {
+			return true
+		}
+	}
+
+	return false
+}
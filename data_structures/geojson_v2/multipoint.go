@@ -44,3 +44,14 @@ func (mp *MultiPoint) decodeMultiPoint(multiPoint [][]float64) error {
 
 	return nil
 }
+
+// Contains reports whether the point is one of the points in the MultiPoint.
+func (mp *MultiPoint) Contains(point Point) bool {
+	for _, p := range mp.Points {
+		if p != nil && p.Equal(point) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,46 @@
+package geojson_v2
+
+import "slices"
+
+// Normalize closes open polygon paths and rewinds them to the RFC 7946 order: counterclockwise outer paths and
+// clockwise inner paths. The geometry is modified in place.
+func (g *Geometry) Normalize() {
+	if g == nil {
+		return
+	}
+
+	if g.Polygon != nil {
+		g.Polygon.Normalize()
+	} else if g.MultiPolygon != nil {
+		for _, polygon := range g.MultiPolygon.Polygons {
+			polygon.Normalize()
+		}
+	} else if g.GeometryCollection != nil {
+		for _, geometry := range g.GeometryCollection.Geometries {
+			geometry.Normalize()
+		}
+	}
+}
+
+// Normalize closes open paths and rewinds them to the RFC 7946 order. The polygon is modified in place.
+func (pg *Polygon) Normalize() {
+	normalizeRing(pg.OuterPath, true)
+	for _, innerPath := range pg.InnerPaths {
+		normalizeRing(innerPath, false)
+	}
+}
+
+func normalizeRing(ring *MultiPoint, counterclockwise bool) {
+	if ring == nil || len(ring.Points) == 0 {
+		return
+	}
+
+	if !ringIsClosed(ring) {
+		ring.Points = append(ring.Points, ring.Points[0].Clone())
+	}
+
+	area := ringSignedArea(ring)
+	if (counterclockwise && area < 0) || (!counterclockwise && area > 0) {
+		slices.Reverse(ring.Points)
+	}
+}
@@ -0,0 +1,61 @@
+package geojson_v2
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestNormalize(t *testing.T) {
+	tests := []struct {
+		name     string
+		geometry string
+		want     string
+	}{
+		{name: "closes open outer path",
+			geometry: `{"type":"Polygon","coordinates":[[[0,0],[10,0],[10,10],[0,10]]]}`,
+			want:     `{"type":"Polygon","coordinates":[[[0,0],[10,0],[10,10],[0,10],[0,0]]]}`},
+		{name: "rewinds clockwise outer path",
+			geometry: `{"type":"Polygon","coordinates":[[[0,0],[0,10],[10,10],[10,0],[0,0]]]}`,
+			want:     `{"type":"Polygon","coordinates":[[[0,0],[10,0],[10,10],[0,10],[0,0]]]}`},
+		{name: "rewinds counterclockwise inner path",
+			geometry: `{"type":"Polygon","coordinates":[[[0,0],[10,0],[10,10],[0,10],[0,0]],[[4,4],[6,4],[6,6],[4,6],[4,4]]]}`,
+			want:     `{"type":"Polygon","coordinates":[[[0,0],[10,0],[10,10],[0,10],[0,0]],[[4,4],[4,6],[6,6],[6,4],[4,4]]]}`},
+		{name: "closes and rewinds with altitude",
+			geometry: `{"type":"MultiPolygon","coordinates":[[[[0,0,5],[0,10,5],[10,10,5],[10,0,5]]]]}`,
+			want:     `{"type":"MultiPolygon","coordinates":[[[[0,0,5],[10,0,5],[10,10,5],[0,10,5],[0,0,5]]]]}`},
+		{name: "collection member",
+			geometry: `{"type":"GeometryCollection","geometries":[{"type":"Polygon","coordinates":[[[0,0],[0,10],[10,10]]]}]}`,
+			want:     `{"type":"GeometryCollection","geometries":[{"type":"Polygon","coordinates":[[[0,0],[10,10],[0,10],[0,0]]]}]}`},
+		{name: "leaves lines alone",
+			geometry: `{"type":"LineString","coordinates":[[0,10],[0,0]]}`,
+			want:     `{"type":"LineString","coordinates":[[0,10],[0,0]]}`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			geometry := mustGeometry(t, test.geometry)
+			geometry.Normalize()
+
+			marshalled, err := json.Marshal(geometry)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(marshalled) != test.want {
+				t.Errorf("got %s, want %s", marshalled, test.want)
+			}
+			if err := geometry.Validate(); err != nil {
+				t.Errorf("normalized geometry is invalid: %v", err)
+			}
+		})
+	}
+}
+
+func TestNormalizeClosingPositionIsCopied(t *testing.T) {
+	geometry := mustGeometry(t, `{"type":"Polygon","coordinates":[[[0,0],[10,0],[10,10],[0,10]]]}`)
+	geometry.Normalize()
+
+	points := geometry.Polygon.OuterPath.Points
+	if points[0] == points[len(points)-1] {
+		t.Error("closing position shares the first position")
+	}
+}
@@ -0,0 +1,260 @@
+package geojson_v2
+
+import "math"
+
+type overlayOperation int8
+
+const (
+	overlayOperation_Intersection overlayOperation = 0
+	overlayOperation_Union        overlayOperation = 1
+	overlayOperation_Difference   overlayOperation = 2
+)
+
+// includes reports whether a position with the given membership in each operand belongs to the result.
+func (o overlayOperation) includes(inA, inB bool) bool {
+	switch o {
+	case overlayOperation_Intersection:
+		return inA && inB
+	case overlayOperation_Union:
+		return inA || inB
+	default:
+		return inA && !inB
+	}
+}
+
+// Intersection returns the area covered by both geometries as a Polygon or MultiPolygon, or an empty MultiPolygon when
+// they do not overlap. Only polygons contribute to either operand, and overlapping polygons within an operand are
+// treated as their union. Coordinates are treated as planar longitude and latitude, as PostGIS does for geometry
+// columns.
+func (g *Geometry) Intersection(other *Geometry) *Geometry {
+	return overlay(g, other, overlayOperation_Intersection)
+}
+
+// Union returns the area covered by either geometry as a Polygon or MultiPolygon, merging overlapping and adjacent
+// polygons. Only polygons contribute to either operand.
+func (g *Geometry) Union(other *Geometry) *Geometry {
+	return overlay(g, other, overlayOperation_Union)
+}
+
+// Difference returns the area covered by the geometry but not by the other geometry as a Polygon or MultiPolygon. Only
+// polygons contribute to either operand.
+func (g *Geometry) Difference(other *Geometry) *Geometry {
+	return overlay(g, other, overlayOperation_Difference)
+}
+
+// Intersection returns the area covered by both polygons.
+func (pg *Polygon) Intersection(other *Polygon) *MultiPolygon {
+	return (&Geometry{Polygon: pg}).Intersection(&Geometry{Polygon: other}).polygons()
+}
+
+// Union returns the area covered by either polygon.
+func (pg *Polygon) Union(other *Polygon) *MultiPolygon {
+	return (&Geometry{Polygon: pg}).Union(&Geometry{Polygon: other}).polygons()
+}
+
+// Difference returns the area covered by the polygon but not by the other polygon.
+func (pg *Polygon) Difference(other *Polygon) *MultiPolygon {
+	return (&Geometry{Polygon: pg}).Difference(&Geometry{Polygon: other}).polygons()
+}
+
+// Intersection returns the area covered by both sets of polygons.
+func (mpg *MultiPolygon) Intersection(other *MultiPolygon) *MultiPolygon {
+	return (&Geometry{MultiPolygon: mpg}).Intersection(&Geometry{MultiPolygon: other}).polygons()
+}
+
+// Union returns the area covered by either set of polygons.
+func (mpg *MultiPolygon) Union(other *MultiPolygon) *MultiPolygon {
+	return (&Geometry{MultiPolygon: mpg}).Union(&Geometry{MultiPolygon: other}).polygons()
+}
+
+// Difference returns the area covered by the polygons but not by the other polygons.
+func (mpg *MultiPolygon) Difference(other *MultiPolygon) *MultiPolygon {
+	return (&Geometry{MultiPolygon: mpg}).Difference(&Geometry{MultiPolygon: other}).polygons()
+}
+
+// Dissolve returns the union of the polygons, merging any that overlap or share edges.
+func (mpg *MultiPolygon) Dissolve() *MultiPolygon {
+	return mpg.Union(&MultiPolygon{})
+}
+
+// polygons returns the polygonal result of an overlay as a MultiPolygon.
+func (g *Geometry) polygons() *MultiPolygon {
+	if g.Polygon != nil {
+		return &MultiPolygon{Polygons: []*Polygon{g.Polygon}}
+	}
+	return g.MultiPolygon
+}
+
+// overlay computes a boolean operation by splitting the edges of both operands at their intersections and keeping every
+// edge that separates the result from its complement, oriented with the result on its left.
+func overlay(a, b *Geometry, operation overlayOperation) *Geometry {
+	projection := snappingProjection{planarProjection: plateCarreeProjection{}, inputs: make(map[vec]Point)}
+	shapesA := &planarShapes{}
+	shapesA.add(projection, a)
+	shapesB := &planarShapes{}
+	shapesB.add(projection, b)
+
+	original := make(map[vec]bool)
+	var edges []planarEdge
+	for _, polygons := range [][][][]vec{shapesA.polygons, shapesB.polygons} {
+		for _, polygon := range polygons {
+			for _, ring := range polygon {
+				for i, v := range ring {
+					original[v] = true
+					edges = append(edges, planarEdge{a: v, b: ring[(i+1)%len(ring)]})
+				}
+			}
+		}
+	}
+
+	windingA := newWindingIndex(shapesA.polygons)
+	windingB := newWindingIndex(shapesB.polygons)
+
+	seen := make(map[planarEdge]bool)
+	var boundary []planarEdge
+	for _, edge := range nodeEdges(edges) {
+		if seen[edge] || seen[planarEdge{a: edge.b, b: edge.a}] {
+			continue
+		}
+		seen[edge] = true
+
+		direction := edge.b.sub(edge.a)
+		length := direction.length()
+		offset := vec{x: -direction.y, y: direction.x}.scale(math.Min(length/4, 10*planarTolerance) / length)
+		midpoint := edge.midpoint()
+		left := midpoint.add(offset)
+		right := midpoint.sub(offset)
+
+		leftIncluded := operation.includes(windingA.inside(left), windingB.inside(left))
+		rightIncluded := operation.includes(windingA.inside(right), windingB.inside(right))
+		if leftIncluded && !rightIncluded {
+			boundary = append(boundary, edge)
+		} else if rightIncluded && !leftIncluded {
+			boundary = append(boundary, planarEdge{a: edge.b, b: edge.a})
+		}
+	}
+
+	var rings [][]vec
+	for _, ring := range assembleRings(boundary) {
+		ring = removeNodedVertices(ring, original)
+		if len(ring) >= 3 {
+			rings = append(rings, ring)
+		}
+	}
+
+	return inversePolygons(projection, polygonsFromRings(rings))
+}
+
+// snappingProjection unprojects positions back to the input coordinates they match, so the result does not pick up
+// noise from the round trip through the projection.
+type snappingProjection struct {
+	planarProjection
+	inputs map[vec]Point
+}
+
+func (p snappingProjection) forward(point Point) vec {
+	v := p.planarProjection.forward(point)
+	if _, ok := p.inputs[v]; !ok {
+		p.inputs[v] = Point{Latitude: point.Latitude, Longitude: point.Longitude}
+	}
+	return v
+}
+
+func (p snappingProjection) inverse(v vec) Point {
+	if point, ok := p.inputs[v]; ok {
+		return point
+	}
+
+	point := p.planarProjection.inverse(v)
+	tolerance := planarTolerance / metersPerDegree
+	for _, input := range p.inputs {
+		if math.Abs(input.Latitude-point.Latitude) <= tolerance {
+			point.Latitude = input.Latitude
+		}
+		if math.Abs(input.Longitude-point.Longitude) <= tolerance {
+			point.Longitude = input.Longitude
+		}
+	}
+	return point
+}
+
+// removeNodedVertices drops vertices that were introduced by splitting an edge and lie on a straight line between their
+// neighbors.
+func removeNodedVertices(ring []vec, original map[vec]bool) []vec {
+	var kept []vec
+	for i, v := range ring {
+		previous := ring[(i+len(ring)-1)%len(ring)]
+		next := ring[(i+1)%len(ring)]
+		if !original[v] && vecSegmentDistance(v, previous, next) <= planarTolerance {
+			continue
+		}
+		kept = append(kept, v)
+	}
+	return kept
+}
+
+// windingIndex answers nonzero winding queries against a set of oriented polygons, bucketing their edges into rows of
+// latitude so that each query only visits the edges spanning its row.
+type windingIndex struct {
+	minY      float64
+	rowHeight float64
+	rows      [][]planarEdge
+}
+
+func newWindingIndex(polygons [][][]vec) *windingIndex {
+	var edges []planarEdge
+	minY, maxY := math.Inf(1), math.Inf(-1)
+	for _, polygon := range polygons {
+		for _, ring := range polygon {
+			for i, v := range ring {
+				edges = append(edges, planarEdge{a: v, b: ring[(i+1)%len(ring)]})
+				minY = math.Min(minY, v.y)
+				maxY = math.Max(maxY, v.y)
+			}
+		}
+	}
+
+	index := &windingIndex{}
+	if len(edges) == 0 {
+		return index
+	}
+
+	rowCount := int(math.Ceil(math.Sqrt(float64(len(edges)))))
+	index.minY = minY
+	index.rowHeight = math.Max((maxY-minY)/float64(rowCount), planarTolerance)
+	index.rows = make([][]planarEdge, rowCount)
+	for _, edge := range edges {
+		first := index.row(math.Min(edge.a.y, edge.b.y))
+		last := index.row(math.Max(edge.a.y, edge.b.y))
+		for row := first; row <= last; row++ {
+			index.rows[row] = append(index.rows[row], edge)
+		}
+	}
+
+	return index
+}
+
+func (w *windingIndex) row(y float64) int {
+	return max(0, min(len(w.rows)-1, int((y-w.minY)/w.rowHeight)))
+}
+
+// inside reports whether the position has a nonzero winding number, so it is inside at least one polygon and not in
+// one of its holes.
+func (w *windingIndex) inside(v vec) bool {
+	if len(w.rows) == 0 {
+		return false
+	}
+
+	winding := 0
+	for _, edge := range w.rows[w.row(v.y)] {
+		side := edge.b.sub(edge.a).cross(v.sub(edge.a))
+		if edge.a.y <= v.y {
+			if edge.b.y > v.y && side > 0 {
+				winding++
+			}
+		} else if edge.b.y <= v.y && side < 0 {
+			winding--
+		}
+	}
+	return winding != 0
+}
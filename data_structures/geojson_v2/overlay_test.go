@@ -0,0 +1,115 @@
+package geojson_v2
+
+import (
+	"math"
+	"testing"
+)
+
+// planarArea returns the area of the polygons in square degrees, shells less holes.
+func planarArea(g *Geometry) float64 {
+	ringArea := func(ring *MultiPoint) float64 {
+		return math.Abs(ringSignedArea(ring))
+	}
+
+	area := 0.0
+	for _, polygon := range g.polygons().Polygons {
+		area += ringArea(polygon.OuterPath)
+		for _, innerPath := range polygon.InnerPaths {
+			area -= ringArea(innerPath)
+		}
+	}
+	return area
+}
+
+func TestOverlay(t *testing.T) {
+	square := `{"type":"Polygon","coordinates":[[[0,0],[2,0],[2,2],[0,2],[0,0]]]}`
+	overlapping := `{"type":"Polygon","coordinates":[[[1,1],[3,1],[3,3],[1,3],[1,1]]]}`
+	adjacent := `{"type":"Polygon","coordinates":[[[2,0],[4,0],[4,2],[2,2],[2,0]]]}`
+	disjoint := `{"type":"Polygon","coordinates":[[[5,5],[6,5],[6,6],[5,6],[5,5]]]}`
+	holed := `{"type":"Polygon","coordinates":[[[0,0],[4,0],[4,4],[0,4],[0,0]],[[1,1],[3,1],[3,3],[1,3],[1,1]]]}`
+	leftHalf := `{"type":"Polygon","coordinates":[[[0,0],[2,0],[2,4],[0,4],[0,0]]]}`
+	hole := `{"type":"Polygon","coordinates":[[[1,1],[3,1],[3,3],[1,3],[1,1]]]}`
+
+	tests := []struct {
+		name      string
+		a, b      string
+		operation func(a, b *Geometry) *Geometry
+		area      float64
+		polygons  int
+		holes     int
+	}{
+		{name: "overlapping intersection", a: square, b: overlapping, operation: (*Geometry).Intersection, area: 1, polygons: 1},
+		{name: "overlapping union", a: square, b: overlapping, operation: (*Geometry).Union, area: 7, polygons: 1},
+		{name: "overlapping difference", a: square, b: overlapping, operation: (*Geometry).Difference, area: 3, polygons: 1},
+		{name: "shared edge intersection", a: square, b: adjacent, operation: (*Geometry).Intersection, area: 0, polygons: 0},
+		{name: "shared edge union", a: square, b: adjacent, operation: (*Geometry).Union, area: 8, polygons: 1},
+		{name: "shared edge difference", a: square, b: adjacent, operation: (*Geometry).Difference, area: 4, polygons: 1},
+		{name: "hole intersection", a: holed, b: leftHalf, operation: (*Geometry).Intersection, area: 6, polygons: 1},
+		{name: "hole union filling the hole", a: holed, b: hole, operation: (*Geometry).Union, area: 16, polygons: 1},
+		{name: "hole difference", a: leftHalf, b: holed, operation: (*Geometry).Difference, area: 2, polygons: 1},
+		{name: "hole kept by union", a: holed, b: disjoint, operation: (*Geometry).Union, area: 13, polygons: 2, holes: 1},
+		{name: "disjoint intersection", a: square, b: disjoint, operation: (*Geometry).Intersection, area: 0, polygons: 0},
+		{name: "disjoint union", a: square, b: disjoint, operation: (*Geometry).Union, area: 5, polygons: 2},
+		{name: "disjoint difference", a: square, b: disjoint, operation: (*Geometry).Difference, area: 4, polygons: 1},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			result := test.operation(mustGeometry(t, test.a), mustGeometry(t, test.b))
+
+			if area := planarArea(result); math.Abs(area-test.area) > 1e-9 {
+				t.Errorf("area = %v, want %v", area, test.area)
+			}
+
+			polygons := result.polygons().Polygons
+			if len(polygons) != test.polygons {
+				t.Fatalf("polygons = %d, want %d", len(polygons), test.polygons)
+			}
+
+			holes := 0
+			for _, polygon := range polygons {
+				holes += len(polygon.InnerPaths)
+			}
+			if holes != test.holes {
+				t.Errorf("holes = %d, want %d", holes, test.holes)
+			}
+
+			err := result.Validate()
+			if len(polygons) > 0 && err != nil {
+				t.Errorf("invalid result: %v", err)
+			}
+		})
+	}
+}
+
+func TestOverlaySnapsToInputCoordinates(t *testing.T) {
+	a := mustGeometry(t, `{"type":"Polygon","coordinates":[[[0.1,0.3],[1.5,0.3],[1.5,1.7],[0.1,1.7],[0.1,0.3]]]}`)
+	b := mustGeometry(t, `{"type":"Polygon","coordinates":[[[0.7,0.9],[2.9,0.9],[2.9,2.1],[0.7,2.1],[0.7,0.9]]]}`)
+
+	inputs := map[float64]bool{0.1: true, 0.3: true, 0.7: true, 0.9: true, 1.5: true, 1.7: true, 2.1: true, 2.9: true}
+	for _, result := range []*Geometry{a.Intersection(b), a.Union(b), a.Difference(b)} {
+		for _, polygon := range result.polygons().Polygons {
+			for _, point := range polygon.OuterPath.Points {
+				if !inputs[point.Longitude] || !inputs[point.Latitude] {
+					t.Errorf("vertex %v,%v is not made of input coordinates", point.Longitude, point.Latitude)
+				}
+			}
+		}
+	}
+}
+
+func TestDissolve(t *testing.T) {
+	multiPolygon := mustGeometry(t, `{"type":"MultiPolygon","coordinates":[
+		[[[0,0],[2,0],[2,2],[0,2],[0,0]]],
+		[[[1,1],[3,1],[3,3],[1,3],[1,1]]],
+		[[[5,5],[6,5],[6,6],[5,6],[5,5]]]
+	]}`).MultiPolygon
+
+	dissolved := &Geometry{MultiPolygon: multiPolygon.Dissolve()}
+	if len(dissolved.MultiPolygon.Polygons) != 2 {
+		t.Fatalf("polygons = %d, want 2", len(dissolved.MultiPolygon.Polygons))
+	}
+	if area := planarArea(dissolved); math.Abs(area-8) > 1e-9 {
+		t.Errorf("area = %v, want 8", area)
+	}
+}
@@ -0,0 +1,196 @@
+package geojson_v2
+
+import "math"
+
+// planarTolerance is the distance in meters under which two projected positions are treated as the same position.
+const planarTolerance = 1e-3
+
+// vec is a position or direction in a local planar projection, in meters.
+type vec struct {
+	x float64
+	y float64
+}
+
+func (v vec) add(other vec) vec {
+	return vec{x: v.x + other.x, y: v.y + other.y}
+}
+
+func (v vec) sub(other vec) vec {
+	return vec{x: v.x - other.x, y: v.y - other.y}
+}
+
+func (v vec) scale(factor float64) vec {
+	return vec{x: v.x * factor, y: v.y * factor}
+}
+
+func (v vec) dot(other vec) float64 {
+	return v.x*other.x + v.y*other.y
+}
+
+func (v vec) cross(other vec) float64 {
+	return v.x*other.y - v.y*other.x
+}
+
+func (v vec) length() float64 {
+	return math.Hypot(v.x, v.y)
+}
+
+// planarProjection maps positions onto a plane measured in meters.
+type planarProjection interface {
+	forward(point Point) vec
+	inverse(v vec) Point
+}
+
+// azimuthalProjection is a spherical azimuthal equidistant projection, which keeps distances and bearings from its
+// center and distorts little within a few hundred kilometers of it.
+type azimuthalProjection struct {
+	center Point
+}
+
+func newAzimuthalProjection(bbox BBox) azimuthalProjection {
+	return azimuthalProjection{center: bbox.Center()}
+}
+
+func (p azimuthalProjection) forward(point Point) vec {
+	distance := haversineDistance(p.center, point)
+	bearing := degreesToRadians(p.center.BearingTo(point))
+	return vec{x: distance * math.Sin(bearing), y: distance * math.Cos(bearing)}
+}
+
+func (p azimuthalProjection) inverse(v vec) Point {
+	return destinationPoint(p.center, radiansToDegrees(math.Atan2(v.x, v.y)), v.length())
+}
+
+// plateCarreeProjection scales longitude and latitude by the length of a degree at the equator. Straight lines in
+// longitude and latitude stay straight, so planar operations on it match operations on the raw coordinates.
+type plateCarreeProjection struct{}
+
+func (p plateCarreeProjection) forward(point Point) vec {
+	return vec{x: point.Longitude * metersPerDegree, y: point.Latitude * metersPerDegree}
+}
+
+func (p plateCarreeProjection) inverse(v vec) Point {
+	return Point{Latitude: v.y / metersPerDegree, Longitude: v.x / metersPerDegree}
+}
+
+// forwardRing projects the ring, dropping repeated positions and the closing position.
+func forwardRing(projection planarProjection, points []*Point) []vec {
+	path := forwardPath(projection, points)
+	if len(path) > 1 && path[0].sub(path[len(path)-1]).length() <= planarTolerance {
+		path = path[:len(path)-1]
+	}
+	return path
+}
+
+// forwardPath projects the path, dropping repeated positions.
+func forwardPath(projection planarProjection, points []*Point) []vec {
+	var path []vec
+	for _, point := range points {
+		projected := projection.forward(*point)
+		if len(path) > 0 && path[len(path)-1].sub(projected).length() <= planarTolerance {
+			continue
+		}
+		path = append(path, projected)
+	}
+	return path
+}
+
+// inverseRing unprojects the ring into an explicitly closed path.
+func inverseRing(projection planarProjection, ring []vec) *MultiPoint {
+	points := make([]*Point, 0, len(ring)+1)
+	for _, v := range ring {
+		point := projection.inverse(v)
+		points = append(points, &point)
+	}
+	points = append(points, points[0].Clone())
+	return &MultiPoint{Points: points}
+}
+
+// inversePolygons unprojects polygons given as a shell followed by its holes into a Polygon, a MultiPolygon, or an empty
+// MultiPolygon when there are none.
+func inversePolygons(projection planarProjection, planarPolygons [][][]vec) *Geometry {
+	polygons := make([]*Polygon, len(planarPolygons))
+	for i, rings := range planarPolygons {
+		polygon := &Polygon{OuterPath: inverseRing(projection, rings[0])}
+		for _, hole := range rings[1:] {
+			polygon.InnerPaths = append(polygon.InnerPaths, inverseRing(projection, hole))
+		}
+		polygons[i] = polygon
+	}
+
+	if len(polygons) == 1 {
+		return &Geometry{Polygon: polygons[0]}
+	}
+	return &Geometry{MultiPolygon: &MultiPolygon{Polygons: polygons}}
+}
+
+// vecRingSignedArea returns the signed area of the implicitly closed ring. Counterclockwise rings have a positive area.
+func vecRingSignedArea(ring []vec) float64 {
+	area := 0.0
+	for i, j := 0, len(ring)-1; i < len(ring); j, i = i, i+1 {
+		area += ring[j].cross(ring[i])
+	}
+	return area / 2
+}
+
+// locateVecInRing determines whether the position is inside, outside or within planarTolerance of the boundary of the
+// implicitly closed ring.
+func locateVecInRing(ring []vec, v vec) ringLocation {
+	if len(ring) < 3 {
+		return ringLocation_Exterior
+	}
+
+	inside := false
+	for i, j := 0, len(ring)-1; i < len(ring); j, i = i, i+1 {
+		a := ring[j]
+		b := ring[i]
+
+		if vecSegmentDistance(v, a, b) <= planarTolerance {
+			return ringLocation_Boundary
+		}
+
+		if (b.y > v.y) != (a.y > v.y) {
+			crossing := (a.x-b.x)*(v.y-b.y)/(a.y-b.y) + b.x
+			if v.x < crossing {
+				inside = !inside
+			}
+		}
+	}
+
+	if inside {
+		return ringLocation_Interior
+	}
+	return ringLocation_Exterior
+}
+
+// locateVecInPolygon determines whether the position is inside, outside or on the boundary of the polygon given as a
+// shell followed by its holes.
+func locateVecInPolygon(rings [][]vec, v vec) ringLocation {
+	location := locateVecInRing(rings[0], v)
+	if location != ringLocation_Interior {
+		return location
+	}
+
+	for _, hole := range rings[1:] {
+		switch locateVecInRing(hole, v) {
+		case ringLocation_Interior:
+			return ringLocation_Exterior
+		case ringLocation_Boundary:
+			return ringLocation_Boundary
+		}
+	}
+
+	return ringLocation_Interior
+}
+
+// vecSegmentDistance returns the distance from p to the segment between a and b.
+func vecSegmentDistance(p, a, b vec) float64 {
+	ab := b.sub(a)
+	lengthSq := ab.dot(ab)
+	if lengthSq == 0 {
+		return p.sub(a).length()
+	}
+
+	t := math.Max(0, math.Min(1, p.sub(a).dot(ab)/lengthSq))
+	return p.sub(a.add(ab.scale(t))).length()
+}
@@ -0,0 +1,22 @@
+package geojson_v2
+
+import (
+	"math"
+	"testing"
+)
+
+func TestAzimuthalProjection(t *testing.T) {
+	projection := newAzimuthalProjection(BBox{MinLongitude: -97, MinLatitude: 40, MaxLongitude: -95, MaxLatitude: 42})
+
+	for _, point := range []Point{{Latitude: 41, Longitude: -96}, {Latitude: 40, Longitude: -97}, {Latitude: 42.5, Longitude: -94}} {
+		v := projection.forward(point)
+		if distance := haversineDistance(projection.center, point); math.Abs(v.length()-distance) > 1e-6 {
+			t.Errorf("projected distance of %+v = %v, want %v", point, v.length(), distance)
+		}
+
+		inverse := projection.inverse(v)
+		if math.Abs(inverse.Latitude-point.Latitude) > 1e-9 || math.Abs(inverse.Longitude-point.Longitude) > 1e-9 {
+			t.Errorf("inverse of %+v = %+v", point, inverse)
+		}
+	}
+}
@@ -8,10 +8,11 @@ import (
 type Point struct {
 	Latitude  float64
 	Longitude float64
+	Altitude  *float64
 }
 
 func (p *Point) MarshalJSON() ([]byte, error) {
-	return json.Marshal([]float64{p.Longitude, p.Latitude})
+	return json.Marshal(p.encodePoint())
 }
 
 func (p *Point) UnmarshalJSON(data []byte) error {
@@ -24,15 +25,28 @@ func (p *Point) UnmarshalJSON(data []byte) error {
 }
 
 func (p *Point) encodePoint() []float64 {
+	if p.Altitude != nil {
+		return []float64{p.Longitude, p.Latitude, *p.Altitude}
+	}
 	return []float64{p.Longitude, p.Latitude}
 }
 
 func (p *Point) decodePoint(point []float64) error {
-	if len(point) != 2 {
+	if len(point) != 2 && len(point) != 3 {
 		return fmt.Errorf("invalid number of coordinates for Point: %v", point)
 	}
 	p.Longitude = point[0]
 	p.Latitude = point[1]
+	p.Altitude = nil
+	if len(point) == 3 {
+		altitude := point[2]
+		p.Altitude = &altitude
+	}
 
 	return nil
 }
+
+// Equal reports whether both points share the same coordinates.
+func (p *Point) Equal(other Point) bool {
+	return p.Latitude == other.Latitude && p.Longitude == other.Longitude
+}
@@ -19,9 +19,6 @@ func (pg *Polygon) UnmarshalJSON(data []byte) error {
 	if err := json.Unmarshal(data, &coordinates); err != nil {
 		return err
 	}
-	if len(coordinates) < 1 {
-		return fmt.Errorf("invalid number of paths for Polygon: %v", coordinates)
-	}
 
 	return pg.decodePolygon(coordinates)
 }
@@ -36,6 +33,10 @@ func (pg *Polygon) encodePolygon() [][][]float64 {
 }
 
 func (pg *Polygon) decodePolygon(polygon [][][]float64) error {
+	if len(polygon) < 1 {
+		return fmt.Errorf("invalid number of paths for Polygon: %v", polygon)
+	}
+
 	outerPath := &MultiPoint{}
 	err := outerPath.decodeMultiPoint(polygon[0])
 	if err != nil {
@@ -56,3 +57,19 @@ func (pg *Polygon) decodePolygon(polygon [][][]float64) error {
 
 	return nil
 }
+
+// Contains reports whether the point lies in the interior of the polygon. Points inside any of the InnerPaths, or on
+// the boundary of any path, are not contained, matching the semantics of PostGIS ST_Contains.
+func (pg *Polygon) Contains(point Point) bool {
+	if locatePointInRing(pg.OuterPath, point) != ringLocation_Interior {
+		return false
+	}
+
+	for _, innerPath := range pg.InnerPaths {
+		if locatePointInRing(innerPath, point) != ringLocation_Exterior {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,83 @@
+package geojson_v2
+
+import "slices"
+
+type ringLocation int8
+
+const (
+	ringLocation_Exterior ringLocation = 0
+	ringLocation_Interior ringLocation = 1
+	ringLocation_Boundary ringLocation = 2
+)
+
+// locatePointInRing determines whether the point is inside, outside or on the boundary of the ring using the even-odd
+// rule. The ring does not need to be explicitly closed.
+func locatePointInRing(ring *MultiPoint, point Point) ringLocation {
+	if ring == nil || len(ring.Points) < 3 || hasMissingPoint(ring.Points) {
+		return ringLocation_Exterior
+	}
+
+	inside := false
+	points := ring.Points
+	for i, j := 0, len(points)-1; i < len(points); j, i = i, i+1 {
+		a := points[j]
+		b := points[i]
+
+		if pointOnSegment(point, *a, *b) {
+			return ringLocation_Boundary
+		}
+
+		if (b.Latitude > point.Latitude) != (a.Latitude > point.Latitude) {
+			crossing := (a.Longitude-b.Longitude)*(point.Latitude-b.Latitude)/(a.Latitude-b.Latitude) + b.Longitude
+			if point.Longitude < crossing {
+				inside = !inside
+			}
+		}
+	}
+
+	if inside {
+		return ringLocation_Interior
+	}
+	return ringLocation_Exterior
+}
+
+// pointOnSegment reports whether p lies on the segment between a and b.
+func pointOnSegment(p, a, b Point) bool {
+	cross := (b.Longitude-a.Longitude)*(p.Latitude-a.Latitude) - (b.Latitude-a.Latitude)*(p.Longitude-a.Longitude)
+	if cross != 0 {
+		return false
+	}
+
+	return p.Longitude >= min(a.Longitude, b.Longitude) && p.Longitude <= max(a.Longitude, b.Longitude) &&
+		p.Latitude >= min(a.Latitude, b.Latitude) && p.Latitude <= max(a.Latitude, b.Latitude)
+}
+
+// ringSignedArea returns the planar signed area of the ring in square degrees. Counterclockwise rings have a positive
+// area.
+func ringSignedArea(ring *MultiPoint) float64 {
+	if ring == nil || len(ring.Points) < 3 || hasMissingPoint(ring.Points) {
+		return 0
+	}
+
+	area := 0.0
+	points := ring.Points
+	for i, j := 0, len(points)-1; i < len(points); j, i = i, i+1 {
+		area += points[j].Longitude*points[i].Latitude - points[i].Longitude*points[j].Latitude
+	}
+
+	return area / 2
+}
+
+// ringIsClosed reports whether the first and last positions of the ring are equal.
+func ringIsClosed(ring *MultiPoint) bool {
+	if ring == nil || len(ring.Points) == 0 || ring.Points[0] == nil || ring.Points[len(ring.Points)-1] == nil {
+		return false
+	}
+
+	return ring.Points[0].Equal(*ring.Points[len(ring.Points)-1])
+}
+
+// hasMissingPoint reports whether any of the positions is nil, which no path decoded from JSON has.
+func hasMissingPoint(points []*Point) bool {
+	return slices.Contains(points, nil)
+}
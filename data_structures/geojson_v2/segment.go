@@ -0,0 +1,104 @@
+package geojson_v2
+
+import (
+	"cmp"
+	"slices"
+)
+
+// orientation returns a positive value when c is to the left of the directed line from a to b, a negative value when
+// it is to the right, and zero when the three points are collinear.
+func orientation(a, b, c Point) float64 {
+	return (b.Longitude-a.Longitude)*(c.Latitude-a.Latitude) - (b.Latitude-a.Latitude)*(c.Longitude-a.Longitude)
+}
+
+// segmentsIntersect reports whether the segment from a1 to a2 touches or crosses the segment from b1 to b2.
+func segmentsIntersect(a1, a2, b1, b2 Point) bool {
+	o1 := orientation(a1, a2, b1)
+	o2 := orientation(a1, a2, b2)
+	o3 := orientation(b1, b2, a1)
+	o4 := orientation(b1, b2, a2)
+
+	if ((o1 > 0 && o2 < 0) || (o1 < 0 && o2 > 0)) && ((o3 > 0 && o4 < 0) || (o3 < 0 && o4 > 0)) {
+		return true
+	}
+
+	return (o1 == 0 && pointOnSegment(b1, a1, a2)) ||
+		(o2 == 0 && pointOnSegment(b2, a1, a2)) ||
+		(o3 == 0 && pointOnSegment(a1, b1, b2)) ||
+		(o4 == 0 && pointOnSegment(a2, b1, b2))
+}
+
+type indexedSegment struct {
+	ring  int
+	index int
+	start Point
+	end   Point
+	minX  float64
+	maxX  float64
+}
+
+// pathSegments returns the segments of the path tagged with the ring they came from, sorted by their minimum
+// longitude.
+func pathSegments(paths ...[]*Point) []indexedSegment {
+	var segments []indexedSegment
+	for ring, points := range paths {
+		for i := 1; i < len(points); i++ {
+			segments = append(segments, indexedSegment{
+				ring:  ring,
+				index: i - 1,
+				start: *points[i-1],
+				end:   *points[i],
+				minX:  min(points[i-1].Longitude, points[i].Longitude),
+				maxX:  max(points[i-1].Longitude, points[i].Longitude),
+			})
+		}
+	}
+
+	slices.SortFunc(segments, func(a, b indexedSegment) int {
+		return cmp.Compare(a.minX, b.minX)
+	})
+	return segments
+}
+
+// sweepSegments calls visit for every pair of segments whose longitude ranges overlap, stopping when visit returns
+// true. It reports whether visit stopped the sweep.
+func sweepSegments(segments []indexedSegment, visit func(a, b indexedSegment) bool) bool {
+	for i := range segments {
+		for j := i + 1; j < len(segments) && segments[j].minX <= segments[i].maxX; j++ {
+			if visit(segments[i], segments[j]) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ringSelfIntersects reports whether any two non-adjacent edges of the closed ring touch or cross.
+func ringSelfIntersects(points []*Point) bool {
+	edges := len(points) - 1
+	return sweepSegments(pathSegments(points), func(a, b indexedSegment) bool {
+		i, j := min(a.index, b.index), max(a.index, b.index)
+		if j == i+1 || (i == 0 && j == edges-1) {
+			return false
+		}
+
+		return segmentsIntersect(a.start, a.end, b.start, b.end)
+	})
+}
+
+// ringsIntersect reports whether any edge of one closed ring touches or crosses any edge of the other.
+func ringsIntersect(a, b []*Point) bool {
+	aBBox := pointsBBox(a)
+	bBBox := pointsBBox(b)
+	if aBBox == nil || bBBox == nil || !aBBox.Intersects(*bBBox) {
+		return false
+	}
+
+	return sweepSegments(pathSegments(a, b), func(a, b indexedSegment) bool {
+		if a.ring == b.ring {
+			return false
+		}
+
+		return segmentsIntersect(a.start, a.end, b.start, b.end)
+	})
+}
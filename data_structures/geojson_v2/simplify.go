@@ -0,0 +1,311 @@
+package geojson_v2
+
+import (
+	"container/heap"
+	"math"
+)
+
+type SimplificationAlgorithm int8
+
+const (
+	// SimplificationAlgorithm_DouglasPeucker removes positions closer than the tolerance, in degrees, to the
+	// simplified path.
+	SimplificationAlgorithm_DouglasPeucker SimplificationAlgorithm = 0
+	// SimplificationAlgorithm_Visvalingam removes positions whose effective triangle area is smaller than the
+	// tolerance, in square degrees.
+	SimplificationAlgorithm_Visvalingam SimplificationAlgorithm = 1
+)
+
+// maxSimplificationAttempts bounds how many times the tolerance is halved before the original geometry is kept.
+const maxSimplificationAttempts = 8
+
+// Simplify returns a simplified copy of the geometry. Polygons are simplified without introducing self intersections,
+// crossings between paths, or inner paths outside of their outer path; other geometry types are returned unchanged.
+func (g *Geometry) Simplify(tolerance float64, algorithm SimplificationAlgorithm) *Geometry {
+	if g == nil {
+		return nil
+	}
+
+	simplified := g.Clone()
+	if g.Polygon != nil {
+		simplified.Polygon = g.Polygon.Simplify(tolerance, algorithm)
+	} else if g.MultiPolygon != nil {
+		simplified.MultiPolygon = g.MultiPolygon.Simplify(tolerance, algorithm)
+	} else if g.GeometryCollection != nil {
+		for i, geometry := range g.GeometryCollection.Geometries {
+			simplified.GeometryCollection.Geometries[i] = geometry.Simplify(tolerance, algorithm)
+		}
+	}
+
+	return simplified
+}
+
+// Simplify returns a simplified copy of the polygon that keeps its topology. When no valid simplification is found the
+// tolerance is repeatedly halved, falling back to a copy of the original polygon. Polygons that are already invalid
+// are simplified without checks.
+func (pg *Polygon) Simplify(tolerance float64, algorithm SimplificationAlgorithm) *Polygon {
+	validate := polygonTopologyValid(pg)
+	for attempt := 0; attempt < maxSimplificationAttempts; attempt++ {
+		simplified := simplifyPolygon(pg, tolerance, algorithm)
+		if !validate || polygonTopologyValid(simplified) {
+			return simplified
+		}
+
+		tolerance /= 2
+	}
+
+	return pg.Clone()
+}
+
+// Simplify returns a simplified copy of the polygons that keeps their topology, including keeping polygons that were
+// apart from touching each other.
+func (mpg *MultiPolygon) Simplify(tolerance float64, algorithm SimplificationAlgorithm) *MultiPolygon {
+	validatePolygons := make([]bool, len(mpg.Polygons))
+	for i, polygon := range mpg.Polygons {
+		validatePolygons[i] = polygonTopologyValid(polygon)
+	}
+	validateDisjoint := !polygonsIntersect(mpg.Polygons)
+
+	for attempt := 0; attempt < maxSimplificationAttempts; attempt++ {
+		simplified := &MultiPolygon{Polygons: make([]*Polygon, len(mpg.Polygons))}
+		valid := true
+		for i, polygon := range mpg.Polygons {
+			simplified.Polygons[i] = simplifyPolygon(polygon, tolerance, algorithm)
+			if validatePolygons[i] && !polygonTopologyValid(simplified.Polygons[i]) {
+				valid = false
+				break
+			}
+		}
+
+		if valid && (!validateDisjoint || !polygonsIntersect(simplified.Polygons)) {
+			return simplified
+		}
+
+		tolerance /= 2
+	}
+
+	return mpg.Clone()
+}
+
+func simplifyPolygon(polygon *Polygon, tolerance float64, algorithm SimplificationAlgorithm) *Polygon {
+	simplified := &Polygon{InnerPaths: make([]*MultiPoint, len(polygon.InnerPaths))}
+	if polygon.OuterPath != nil {
+		simplified.OuterPath = &MultiPoint{Points: simplifyRing(polygon.OuterPath, tolerance, algorithm)}
+	}
+	for i, innerPath := range polygon.InnerPaths {
+		simplified.InnerPaths[i] = &MultiPoint{Points: simplifyRing(innerPath, tolerance, algorithm)}
+	}
+	return simplified
+}
+
+// simplifyRing simplifies the closed path. When simplification would leave fewer than four positions the tolerance is
+// halved, falling back to a copy of the original path.
+func simplifyRing(ring *MultiPoint, tolerance float64, algorithm SimplificationAlgorithm) []*Point {
+	points := closedRingPoints(ring)
+	if len(points) <= 4 {
+		return clonePoints(points)
+	}
+
+	for attempt := 0; attempt < maxSimplificationAttempts; attempt++ {
+		var keep []bool
+		switch algorithm {
+		case SimplificationAlgorithm_Visvalingam:
+			keep = visvalingam(points, tolerance)
+		default:
+			// Split the ring at the position farthest from the start, so both halves are open paths.
+			farthest := 0
+			farthestDistance := -1.0
+			for i, point := range points {
+				distance := math.Hypot(point.Longitude-points[0].Longitude, point.Latitude-points[0].Latitude)
+				if distance > farthestDistance {
+					farthest = i
+					farthestDistance = distance
+				}
+			}
+
+			keep = make([]bool, len(points))
+			douglasPeucker(points, 0, farthest, tolerance, keep)
+			douglasPeucker(points, farthest, len(points)-1, tolerance, keep)
+		}
+
+		var simplified []*Point
+		for i, point := range points {
+			if keep[i] {
+				simplified = append(simplified, point.Clone())
+			}
+		}
+
+		if len(simplified) >= 4 {
+			return simplified
+		}
+
+		tolerance /= 2
+	}
+
+	return clonePoints(points)
+}
+
+func douglasPeucker(points []*Point, first, last int, tolerance float64, keep []bool) {
+	keep[first] = true
+	keep[last] = true
+
+	farthest := -1
+	farthestDistance := tolerance
+	for i := first + 1; i < last; i++ {
+		distance := planarSegmentDistance(*points[i], *points[first], *points[last])
+		if distance > farthestDistance {
+			farthest = i
+			farthestDistance = distance
+		}
+	}
+
+	if farthest >= 0 {
+		douglasPeucker(points, first, farthest, tolerance, keep)
+		douglasPeucker(points, farthest, last, tolerance, keep)
+	}
+}
+
+// planarSegmentDistance returns the distance in degrees from p to the segment between a and b.
+func planarSegmentDistance(p, a, b Point) float64 {
+	dx := b.Longitude - a.Longitude
+	dy := b.Latitude - a.Latitude
+	if dx == 0 && dy == 0 {
+		return math.Hypot(p.Longitude-a.Longitude, p.Latitude-a.Latitude)
+	}
+
+	t := ((p.Longitude-a.Longitude)*dx + (p.Latitude-a.Latitude)*dy) / (dx*dx + dy*dy)
+	t = math.Max(0, math.Min(1, t))
+	return math.Hypot(p.Longitude-(a.Longitude+t*dx), p.Latitude-(a.Latitude+t*dy))
+}
+
+type visvalingamVertex struct {
+	index    int
+	area     float64
+	previous int
+	next     int
+	heap     int
+}
+
+type visvalingamHeap []*visvalingamVertex
+
+func (h visvalingamHeap) Len() int           { return len(h) }
+func (h visvalingamHeap) Less(i, j int) bool { return h[i].area < h[j].area }
+func (h visvalingamHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].heap = i
+	h[j].heap = j
+}
+func (h *visvalingamHeap) Push(x interface{}) {
+	vertex := x.(*visvalingamVertex)
+	vertex.heap = len(*h)
+	*h = append(*h, vertex)
+}
+func (h *visvalingamHeap) Pop() interface{} {
+	old := *h
+	vertex := old[len(old)-1]
+	*h = old[:len(old)-1]
+	vertex.heap = -1
+	return vertex
+}
+
+// visvalingam marks the positions kept by Visvalingam-Whyatt simplification of the closed ring. The closing position
+// is always kept, and at least four positions remain.
+func visvalingam(points []*Point, tolerance float64) []bool {
+	keep := make([]bool, len(points))
+	for i := range keep {
+		keep[i] = true
+	}
+
+	vertices := make([]*visvalingamVertex, len(points))
+	for i := range points {
+		vertices[i] = &visvalingamVertex{index: i, previous: i - 1, next: i + 1, heap: -1}
+	}
+
+	triangleArea := func(vertex *visvalingamVertex) float64 {
+		return math.Abs(orientation(*points[vertex.previous], *points[vertex.index], *points[vertex.next])) / 2
+	}
+
+	vertexHeap := &visvalingamHeap{}
+	for i := 1; i < len(points)-1; i++ {
+		vertices[i].area = triangleArea(vertices[i])
+		heap.Push(vertexHeap, vertices[i])
+	}
+
+	remaining := len(points)
+	maxArea := 0.0
+	for vertexHeap.Len() > 0 && remaining > 4 {
+		vertex := heap.Pop(vertexHeap).(*visvalingamVertex)
+
+		// Areas never decrease, so a neighbour left with a smaller triangle is eliminated at the current area.
+		maxArea = math.Max(maxArea, vertex.area)
+		if maxArea >= tolerance {
+			break
+		}
+
+		keep[vertex.index] = false
+		remaining--
+
+		previous := vertices[vertex.previous]
+		next := vertices[vertex.next]
+		previous.next = next.index
+		next.previous = previous.index
+
+		for _, neighbour := range []*visvalingamVertex{previous, next} {
+			if neighbour.heap >= 0 {
+				neighbour.area = triangleArea(neighbour)
+				heap.Fix(vertexHeap, neighbour.heap)
+			}
+		}
+	}
+
+	return keep
+}
+
+// polygonTopologyValid reports whether the paths are simple, do not touch each other, and the inner paths lie inside
+// the outer path.
+func polygonTopologyValid(polygon *Polygon) bool {
+	if polygon.OuterPath == nil {
+		return true
+	}
+
+	outer := polygon.OuterPath.Points
+	if ringSelfIntersects(outer) {
+		return false
+	}
+
+	for i, innerPath := range polygon.InnerPaths {
+		inner := innerPath.Points
+		if ringSelfIntersects(inner) || ringsIntersect(outer, inner) {
+			return false
+		}
+
+		if len(inner) > 0 && locatePointInRing(polygon.OuterPath, *inner[0]) != ringLocation_Interior {
+			return false
+		}
+
+		for _, otherInnerPath := range polygon.InnerPaths[i+1:] {
+			if ringsIntersect(inner, otherInnerPath.Points) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// polygonsIntersect reports whether the outer paths of any two polygons touch or cross.
+func polygonsIntersect(polygons []*Polygon) bool {
+	for i, polygon := range polygons {
+		if polygon.OuterPath == nil {
+			continue
+		}
+
+		for _, other := range polygons[i+1:] {
+			if other.OuterPath != nil && ringsIntersect(polygon.OuterPath.Points, other.OuterPath.Points) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
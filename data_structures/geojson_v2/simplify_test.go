@@ -0,0 +1,121 @@
+package geojson_v2
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func containsPosition(points []*Point, longitude, latitude float64) bool {
+	for _, point := range points {
+		if point.Longitude == longitude && point.Latitude == latitude {
+			return true
+		}
+	}
+	return false
+}
+
+func TestSimplify(t *testing.T) {
+	data := `{"type":"Polygon","coordinates":[[[0,0],[5,0.01],[10,0],[10.01,5],[10,10],[5,9.99],[0,10],[0,0]]]}`
+	want := `{"type":"Polygon","coordinates":[[[0,0],[10,0],[10,10],[0,10],[0,0]]]}`
+
+	for name, test := range map[string]struct {
+		algorithm SimplificationAlgorithm
+		tolerance float64
+	}{
+		"Douglas-Peucker": {algorithm: SimplificationAlgorithm_DouglasPeucker, tolerance: 0.1},
+		"Visvalingam":     {algorithm: SimplificationAlgorithm_Visvalingam, tolerance: 0.1},
+	} {
+		t.Run(name, func(t *testing.T) {
+			geometry := mustGeometry(t, data)
+			simplified := geometry.Simplify(test.tolerance, test.algorithm)
+
+			marshalled, err := json.Marshal(simplified)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(marshalled) != want {
+				t.Errorf("got %s, want %s", marshalled, want)
+			}
+
+			original, err := json.Marshal(geometry)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(original) != data {
+				t.Errorf("input was modified: %s", original)
+			}
+		})
+	}
+}
+
+func TestSimplifyKeepsInnerPathInside(t *testing.T) {
+	// The hole sits in a dip of the bottom edge; removing the dip would leave the hole outside the polygon.
+	outer := `[[0,0],[5,-2],[10,0],[10,10],[0,10],[0,0]]`
+	hole := `[[4.8,-1.2],[4.8,-0.8],[5.2,-0.8],[5.2,-1.2],[4.8,-1.2]]`
+
+	withoutHole := mustGeometry(t, `{"type":"Polygon","coordinates":[`+outer+`]}`).
+		Simplify(3, SimplificationAlgorithm_DouglasPeucker)
+	if containsPosition(withoutHole.Polygon.OuterPath.Points, 5, -2) {
+		t.Fatal("expected the dip to be removed without a hole")
+	}
+
+	for _, algorithm := range []SimplificationAlgorithm{SimplificationAlgorithm_DouglasPeucker, SimplificationAlgorithm_Visvalingam} {
+		tolerance := 3.0
+		if algorithm == SimplificationAlgorithm_Visvalingam {
+			tolerance = 20
+		}
+
+		simplified := mustGeometry(t, `{"type":"Polygon","coordinates":[`+outer+`,`+hole+`]}`).Simplify(tolerance, algorithm)
+		if !containsPosition(simplified.Polygon.OuterPath.Points, 5, -2) {
+			t.Errorf("algorithm %d removed the dip around the hole", algorithm)
+		}
+		if !polygonTopologyValid(simplified.Polygon) {
+			t.Errorf("algorithm %d produced an invalid polygon", algorithm)
+		}
+	}
+}
+
+func TestSimplifyKeepsPolygonsApart(t *testing.T) {
+	// The second polygon reaches into a notch in the right edge of the first; removing the notch would make them
+	// overlap.
+	data := `{"type":"MultiPolygon","coordinates":[` +
+		`[[[0,0],[10,0],[10,4],[8,5],[10,6],[10,10],[0,10],[0,0]]],` +
+		`[[[9,4.95],[11,4.95],[11,5.05],[9,5.05],[9,4.95]]]]}`
+
+	alone := mustGeometry(t, `{"type":"Polygon","coordinates":[[[0,0],[10,0],[10,4],[8,5],[10,6],[10,10],[0,10],[0,0]]]}`).
+		Simplify(3, SimplificationAlgorithm_DouglasPeucker)
+	if containsPosition(alone.Polygon.OuterPath.Points, 8, 5) {
+		t.Fatal("expected the notch to be removed from a lone polygon")
+	}
+
+	simplified := mustGeometry(t, data).Simplify(3, SimplificationAlgorithm_DouglasPeucker)
+	if !containsPosition(simplified.MultiPolygon.Polygons[0].OuterPath.Points, 8, 5) {
+		t.Error("notch was removed")
+	}
+	if polygonsIntersect(simplified.MultiPolygon.Polygons) {
+		t.Error("simplified polygons intersect")
+	}
+}
+
+func TestSimplifyKeepsFourPositions(t *testing.T) {
+	geometry := mustGeometry(t, `{"type":"Polygon","coordinates":[[[0,0],[1,0.001],[2,0],[1,0.002],[0,0]]]}`)
+
+	for _, algorithm := range []SimplificationAlgorithm{SimplificationAlgorithm_DouglasPeucker, SimplificationAlgorithm_Visvalingam} {
+		simplified := geometry.Simplify(100, algorithm)
+		if len(simplified.Polygon.OuterPath.Points) < 4 {
+			t.Errorf("algorithm %d left %d positions", algorithm, len(simplified.Polygon.OuterPath.Points))
+		}
+	}
+}
+
+func TestSimplifyLeavesOtherTypes(t *testing.T) {
+	data := `{"type":"LineString","coordinates":[[0,0],[5,0.01],[10,0]]}`
+
+	marshalled, err := json.Marshal(mustGeometry(t, data).Simplify(1, SimplificationAlgorithm_DouglasPeucker))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(marshalled) != data {
+		t.Errorf("got %s, want %s", marshalled, data)
+	}
+}
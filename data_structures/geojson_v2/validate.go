@@ -0,0 +1,136 @@
+package geojson_v2
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// ValidationError describes a single problem with a geometry. Path locates the offending member, for example
+// "polygons[1].innerPaths[0]".
+type ValidationError struct {
+	Path   string
+	Reason string
+}
+
+func (e *ValidationError) Error() string {
+	if e.Path == "" {
+		return e.Reason
+	}
+	return e.Path + ": " + e.Reason
+}
+
+// ValidationErrors is every problem found by Validate.
+type ValidationErrors []*ValidationError
+
+func (e ValidationErrors) Error() string {
+	messages := make([]string, len(e))
+	for i, err := range e {
+		messages[i] = err.Error()
+	}
+	return strings.Join(messages, "; ")
+}
+
+// Validate checks that coordinates are within WGS84 bounds, lines have at least two positions, and polygon paths are
+// closed, have at least four positions and follow the RFC 7946 winding order. It returns ValidationErrors, or nil when
+// the geometry is valid.
+func (g *Geometry) Validate() error {
+	var errs ValidationErrors
+	g.validate("", &errs)
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+func (g *Geometry) validate(path string, errs *ValidationErrors) {
+	if g == nil {
+		return
+	}
+
+	if g.Point != nil {
+		validatePoint(joinPath(path, "point"), g.Point, errs)
+	} else if g.MultiPoint != nil {
+		validatePoints(joinPath(path, "multiPoint"), g.MultiPoint.Points, errs)
+	} else if g.LineString != nil {
+		validateLineString(joinPath(path, "lineString"), g.LineString, errs)
+	} else if g.MultiLineString != nil {
+		for i, lineString := range g.MultiLineString.LineStrings {
+			validateLineString(joinPath(path, fmt.Sprintf("lineStrings[%d]", i)), lineString, errs)
+		}
+	} else if g.Polygon != nil {
+		validatePolygon(joinPath(path, "polygon"), g.Polygon, errs)
+	} else if g.MultiPolygon != nil {
+		for i, polygon := range g.MultiPolygon.Polygons {
+			validatePolygon(joinPath(path, fmt.Sprintf("polygons[%d]", i)), polygon, errs)
+		}
+	} else if g.GeometryCollection != nil {
+		for i, geometry := range g.GeometryCollection.Geometries {
+			geometry.validate(joinPath(path, fmt.Sprintf("geometries[%d]", i)), errs)
+		}
+	}
+}
+
+func validatePoint(path string, point *Point, errs *ValidationErrors) {
+	if point == nil {
+		*errs = append(*errs, &ValidationError{Path: path, Reason: "missing position"})
+		return
+	}
+
+	if math.IsNaN(point.Latitude) || point.Latitude < -90 || point.Latitude > 90 {
+		*errs = append(*errs, &ValidationError{Path: path, Reason: fmt.Sprintf("latitude %v is out of range", point.Latitude)})
+	}
+	if math.IsNaN(point.Longitude) || point.Longitude < -180 || point.Longitude > 180 {
+		*errs = append(*errs, &ValidationError{Path: path, Reason: fmt.Sprintf("longitude %v is out of range", point.Longitude)})
+	}
+}
+
+func validatePoints(path string, points []*Point, errs *ValidationErrors) {
+	for i, point := range points {
+		validatePoint(fmt.Sprintf("%s.points[%d]", path, i), point, errs)
+	}
+}
+
+func validateLineString(path string, lineString *LineString, errs *ValidationErrors) {
+	if len(lineString.Points) < 2 {
+		*errs = append(*errs, &ValidationError{Path: path, Reason: "line must have at least 2 positions"})
+	}
+	validatePoints(path, lineString.Points, errs)
+}
+
+func validatePolygon(path string, polygon *Polygon, errs *ValidationErrors) {
+	if polygon.OuterPath == nil {
+		*errs = append(*errs, &ValidationError{Path: path, Reason: "missing outer path"})
+		return
+	}
+
+	validateRing(path+".outerPath", polygon.OuterPath, true, errs)
+	for i, innerPath := range polygon.InnerPaths {
+		validateRing(fmt.Sprintf("%s.innerPaths[%d]", path, i), innerPath, false, errs)
+	}
+}
+
+func validateRing(path string, ring *MultiPoint, outer bool, errs *ValidationErrors) {
+	if len(ring.Points) < 4 {
+		*errs = append(*errs, &ValidationError{Path: path, Reason: "path must have at least 4 positions"})
+	}
+	if !ringIsClosed(ring) {
+		*errs = append(*errs, &ValidationError{Path: path, Reason: "path is not closed"})
+	}
+
+	area := ringSignedArea(ring)
+	if outer && area < 0 {
+		*errs = append(*errs, &ValidationError{Path: path, Reason: "outer path must be counterclockwise"})
+	} else if !outer && area > 0 {
+		*errs = append(*errs, &ValidationError{Path: path, Reason: "inner path must be clockwise"})
+	}
+
+	validatePoints(path, ring.Points, errs)
+}
+
+func joinPath(path, member string) string {
+	if path == "" {
+		return member
+	}
+	return path + "." + member
+}
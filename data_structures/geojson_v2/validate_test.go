@@ -0,0 +1,96 @@
+package geojson_v2
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		geometry string
+		want     []string
+	}{
+		{name: "valid polygon with hole",
+			geometry: `{"type":"Polygon","coordinates":[[[0,0],[10,0],[10,10],[0,10],[0,0]],[[4,4],[4,6],[6,6],[6,4],[4,4]]]}`},
+		{name: "valid point with altitude", geometry: `{"type":"Point","coordinates":[-96.05,41.26,300]}`},
+		{name: "latitude out of range", geometry: `{"type":"Point","coordinates":[0,91]}`,
+			want: []string{"point: latitude 91 is out of range"}},
+		{name: "longitude out of range", geometry: `{"type":"MultiPoint","coordinates":[[0,0],[-181,0]]}`,
+			want: []string{"multiPoint.points[1]: longitude -181 is out of range"}},
+		{name: "open outer path", geometry: `{"type":"Polygon","coordinates":[[[0,0],[10,0],[10,10],[0,10]]]}`,
+			want: []string{"polygon.outerPath: path is not closed"}},
+		{name: "too few positions", geometry: `{"type":"Polygon","coordinates":[[[0,0],[10,0],[0,0]]]}`,
+			want: []string{"polygon.outerPath: path must have at least 4 positions"}},
+		{name: "clockwise outer path", geometry: `{"type":"Polygon","coordinates":[[[0,0],[0,10],[10,10],[10,0],[0,0]]]}`,
+			want: []string{"polygon.outerPath: outer path must be counterclockwise"}},
+		{name: "counterclockwise inner path",
+			geometry: `{"type":"MultiPolygon","coordinates":[[[[0,0],[10,0],[10,10],[0,10],[0,0]],[[4,4],[6,4],[6,6],[4,6],[4,4]]]]}`,
+			want:     []string{"polygons[0].innerPaths[0]: inner path must be clockwise"}},
+		{name: "collection member",
+			geometry: `{"type":"GeometryCollection","geometries":[{"type":"Point","coordinates":[0,0]},{"type":"Point","coordinates":[200,-100]}]}`,
+			want: []string{"geometries[1].point: latitude -100 is out of range",
+				"geometries[1].point: longitude 200 is out of range"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := mustGeometry(t, test.geometry).Validate()
+			if len(test.want) == 0 {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				return
+			}
+
+			var errs ValidationErrors
+			if !errors.As(err, &errs) {
+				t.Fatalf("err = %v, want ValidationErrors", err)
+			}
+			if len(errs) != len(test.want) {
+				t.Fatalf("errors = %v, want %v", errs, test.want)
+			}
+			for i, want := range test.want {
+				if errs[i].Error() != want {
+					t.Errorf("error %d = %q, want %q", i, errs[i].Error(), want)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateMissingMembers(t *testing.T) {
+	geometry := &Geometry{GeometryCollection: &GeometryCollection{Geometries: []*Geometry{
+		{MultiLineString: &MultiLineString{LineStrings: []*LineString{{Points: []*Point{{}}}}}},
+		{MultiPolygon: &MultiPolygon{Polygons: []*Polygon{
+			{},
+			{OuterPath: &MultiPoint{Points: []*Point{{}, nil, {Longitude: 1, Latitude: 1}, {}}}},
+		}}},
+	}}}
+	want := []string{
+		"geometries[0].lineStrings[0]: line must have at least 2 positions",
+		"geometries[1].polygons[0]: missing outer path",
+		"geometries[1].polygons[1].outerPath.points[1]: missing position",
+	}
+
+	var errs ValidationErrors
+	if !errors.As(geometry.Validate(), &errs) {
+		t.Fatal("expected ValidationErrors")
+	}
+	if len(errs) != len(want) {
+		t.Fatalf("errors = %v, want %v", errs, want)
+	}
+	for i := range want {
+		if errs[i].Error() != want[i] {
+			t.Errorf("error %d = %q, want %q", i, errs[i].Error(), want[i])
+		}
+	}
+}
+
+func TestValidateNaN(t *testing.T) {
+	geometry := &Geometry{Point: &Point{Longitude: math.NaN(), Latitude: 0}}
+	if geometry.Validate() == nil {
+		t.Error("expected a NaN longitude to be invalid")
+	}
+}
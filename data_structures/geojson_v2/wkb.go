@@ -0,0 +1,466 @@
+package geojson_v2
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+)
+
+// WGS84SRID is the spatial reference id every geometry is stored with.
+const WGS84SRID uint32 = 4326
+
+const (
+	wkbPoint              uint32 = 1
+	wkbLineString         uint32 = 2
+	wkbPolygon            uint32 = 3
+	wkbMultiPoint         uint32 = 4
+	wkbMultiLineString    uint32 = 5
+	wkbMultiPolygon       uint32 = 6
+	wkbGeometryCollection uint32 = 7
+
+	ewkbZFlag    uint32 = 0x80000000
+	ewkbMFlag    uint32 = 0x40000000
+	ewkbSRIDFlag uint32 = 0x20000000
+
+	wkbXDR byte = 0
+	wkbNDR byte = 1
+)
+
+var errWKBTruncated = errors.New("unexpected end of WKB")
+
+// MarshalWKB encodes the geometry as little endian Well-Known Binary. The geometry is written with a Z ordinate when
+// any of its positions has an altitude, using the ISO Z geometry types.
+func (g *Geometry) MarshalWKB() ([]byte, error) {
+	return g.appendWKB(nil, wkbWriter{z: g.hasAltitude()}, 0)
+}
+
+// MarshalEWKB encodes the geometry as PostGIS Extended Well-Known Binary tagged with the given SRID. The geometry is
+// written with a Z ordinate when any of its positions has an altitude.
+func (g *Geometry) MarshalEWKB(srid uint32) ([]byte, error) {
+	return g.appendWKB(nil, wkbWriter{extended: true, z: g.hasAltitude()}, srid)
+}
+
+// UnmarshalWKB decodes Well-Known Binary or PostGIS Extended Well-Known Binary into the geometry. A Z ordinate is read
+// as the altitude, while M ordinates and any SRID are accepted and discarded.
+func (g *Geometry) UnmarshalWKB(data []byte) error {
+	reader := &wkbReader{data: data}
+	geometry, err := reader.readGeometry()
+	if err != nil {
+		return err
+	}
+
+	if reader.offset != len(data) {
+		return fmt.Errorf("unexpected trailing WKB bytes: %d", len(data)-reader.offset)
+	}
+
+	*g = *geometry
+	return nil
+}
+
+// wkbWriter holds the layout shared by every geometry in one encoding. WKB requires all members of a collection to
+// have the same dimensions, so a position without an altitude in a Z geometry is written with a NaN Z ordinate.
+type wkbWriter struct {
+	extended bool
+	z        bool
+}
+
+func (g *Geometry) appendWKB(data []byte, writer wkbWriter, srid uint32) ([]byte, error) {
+	if g == nil {
+		return nil, errors.New("cannot encode nil geometry as WKB")
+	}
+
+	if g.Point != nil {
+		data = writer.appendHeader(data, wkbPoint, srid)
+		data = writer.appendPoint(data, g.Point)
+	} else if g.MultiPoint != nil {
+		data = writer.appendHeader(data, wkbMultiPoint, srid)
+		data = binary.LittleEndian.AppendUint32(data, uint32(len(g.MultiPoint.Points)))
+		for _, point := range g.MultiPoint.Points {
+			data = writer.appendHeader(data, wkbPoint, 0)
+			data = writer.appendPoint(data, point)
+		}
+	} else if g.LineString != nil {
+		data = writer.appendHeader(data, wkbLineString, srid)
+		data = writer.appendPath(data, g.LineString.Points)
+	} else if g.MultiLineString != nil {
+		data = writer.appendHeader(data, wkbMultiLineString, srid)
+		data = binary.LittleEndian.AppendUint32(data, uint32(len(g.MultiLineString.LineStrings)))
+		for _, lineString := range g.MultiLineString.LineStrings {
+			data = writer.appendHeader(data, wkbLineString, 0)
+			data = writer.appendPath(data, lineString.Points)
+		}
+	} else if g.Polygon != nil {
+		data = writer.appendHeader(data, wkbPolygon, srid)
+		data = writer.appendPolygon(data, g.Polygon)
+	} else if g.MultiPolygon != nil {
+		data = writer.appendHeader(data, wkbMultiPolygon, srid)
+		data = binary.LittleEndian.AppendUint32(data, uint32(len(g.MultiPolygon.Polygons)))
+		for _, polygon := range g.MultiPolygon.Polygons {
+			data = writer.appendHeader(data, wkbPolygon, 0)
+			data = writer.appendPolygon(data, polygon)
+		}
+	} else if g.GeometryCollection != nil {
+		data = writer.appendHeader(data, wkbGeometryCollection, srid)
+		data = binary.LittleEndian.AppendUint32(data, uint32(len(g.GeometryCollection.Geometries)))
+		for _, geometry := range g.GeometryCollection.Geometries {
+			var err error
+			data, err = geometry.appendWKB(data, writer, 0)
+			if err != nil {
+				return nil, err
+			}
+		}
+	} else {
+		return nil, errors.New("cannot encode empty geometry as WKB")
+	}
+
+	return data, nil
+}
+
+func (w wkbWriter) appendHeader(data []byte, geometryType uint32, srid uint32) []byte {
+	data = append(data, wkbNDR)
+	if w.z && w.extended {
+		geometryType |= ewkbZFlag
+	} else if w.z {
+		geometryType += 1000
+	}
+
+	if srid != 0 {
+		data = binary.LittleEndian.AppendUint32(data, geometryType|ewkbSRIDFlag)
+		return binary.LittleEndian.AppendUint32(data, srid)
+	}
+	return binary.LittleEndian.AppendUint32(data, geometryType)
+}
+
+func (w wkbWriter) appendPoint(data []byte, point *Point) []byte {
+	data = binary.LittleEndian.AppendUint64(data, math.Float64bits(point.Longitude))
+	data = binary.LittleEndian.AppendUint64(data, math.Float64bits(point.Latitude))
+	if !w.z {
+		return data
+	}
+
+	altitude := math.NaN()
+	if point.Altitude != nil {
+		altitude = *point.Altitude
+	}
+	return binary.LittleEndian.AppendUint64(data, math.Float64bits(altitude))
+}
+
+func (w wkbWriter) appendPath(data []byte, points []*Point) []byte {
+	data = binary.LittleEndian.AppendUint32(data, uint32(len(points)))
+	for _, point := range points {
+		data = w.appendPoint(data, point)
+	}
+	return data
+}
+
+func (w wkbWriter) appendPolygon(data []byte, polygon *Polygon) []byte {
+	if polygon.OuterPath == nil || len(polygon.OuterPath.Points) == 0 {
+		return binary.LittleEndian.AppendUint32(data, 0)
+	}
+
+	data = binary.LittleEndian.AppendUint32(data, uint32(1+len(polygon.InnerPaths)))
+	data = w.appendPath(data, polygon.OuterPath.Points)
+	for _, innerPath := range polygon.InnerPaths {
+		data = w.appendPath(data, innerPath.Points)
+	}
+	return data
+}
+
+// hasAltitude reports whether any position of the geometry has an altitude.
+func (g *Geometry) hasAltitude() bool {
+	if g == nil {
+		return false
+	}
+
+	if g.Point != nil {
+		return g.Point.Altitude != nil
+	} else if g.MultiPoint != nil {
+		return pathHasAltitude(g.MultiPoint.Points)
+	} else if g.LineString != nil {
+		return pathHasAltitude(g.LineString.Points)
+	} else if g.MultiLineString != nil {
+		for _, lineString := range g.MultiLineString.LineStrings {
+			if pathHasAltitude(lineString.Points) {
+				return true
+			}
+		}
+	} else if g.Polygon != nil {
+		return polygonHasAltitude(g.Polygon)
+	} else if g.MultiPolygon != nil {
+		for _, polygon := range g.MultiPolygon.Polygons {
+			if polygonHasAltitude(polygon) {
+				return true
+			}
+		}
+	} else if g.GeometryCollection != nil {
+		for _, geometry := range g.GeometryCollection.Geometries {
+			if geometry.hasAltitude() {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func polygonHasAltitude(polygon *Polygon) bool {
+	if polygon.OuterPath != nil && pathHasAltitude(polygon.OuterPath.Points) {
+		return true
+	}
+	for _, innerPath := range polygon.InnerPaths {
+		if pathHasAltitude(innerPath.Points) {
+			return true
+		}
+	}
+	return false
+}
+
+func pathHasAltitude(points []*Point) bool {
+	for _, point := range points {
+		if point != nil && point.Altitude != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// wkbLayout records which optional ordinates follow the longitude and latitude of every position.
+type wkbLayout struct {
+	z bool
+	m bool
+}
+
+func (l wkbLayout) dimensions() int {
+	dimensions := 2
+	if l.z {
+		dimensions++
+	}
+	if l.m {
+		dimensions++
+	}
+	return dimensions
+}
+
+type wkbReader struct {
+	data   []byte
+	offset int
+}
+
+func (r *wkbReader) readByte() (byte, error) {
+	if r.offset+1 > len(r.data) {
+		return 0, errWKBTruncated
+	}
+
+	value := r.data[r.offset]
+	r.offset++
+	return value, nil
+}
+
+func (r *wkbReader) readUint32(byteOrder binary.ByteOrder) (uint32, error) {
+	if r.offset+4 > len(r.data) {
+		return 0, errWKBTruncated
+	}
+
+	value := byteOrder.Uint32(r.data[r.offset:])
+	r.offset += 4
+	return value, nil
+}
+
+func (r *wkbReader) readFloat64(byteOrder binary.ByteOrder) (float64, error) {
+	if r.offset+8 > len(r.data) {
+		return 0, errWKBTruncated
+	}
+
+	value := math.Float64frombits(byteOrder.Uint64(r.data[r.offset:]))
+	r.offset += 8
+	return value, nil
+}
+
+func (r *wkbReader) readGeometry() (*Geometry, error) {
+	order, err := r.readByte()
+	if err != nil {
+		return nil, err
+	}
+
+	var byteOrder binary.ByteOrder
+	switch order {
+	case wkbXDR:
+		byteOrder = binary.BigEndian
+	case wkbNDR:
+		byteOrder = binary.LittleEndian
+	default:
+		return nil, fmt.Errorf("invalid WKB byte order: %d", order)
+	}
+
+	geometryType, err := r.readUint32(byteOrder)
+	if err != nil {
+		return nil, err
+	}
+
+	layout := wkbLayout{z: geometryType&ewkbZFlag != 0, m: geometryType&ewkbMFlag != 0}
+	if geometryType&ewkbSRIDFlag != 0 {
+		_, err := r.readUint32(byteOrder)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	geometryType &^= ewkbZFlag | ewkbMFlag | ewkbSRIDFlag
+	switch geometryType / 1000 {
+	case 1:
+		layout.z = true
+	case 2:
+		layout.m = true
+	case 3:
+		layout.z = true
+		layout.m = true
+	}
+	geometryType %= 1000
+
+	switch geometryType {
+	case wkbPoint:
+		point, err := r.readPoint(byteOrder, layout)
+		if err != nil {
+			return nil, err
+		}
+
+		return &Geometry{Point: point}, nil
+	case wkbLineString:
+		points, err := r.readPath(byteOrder, layout)
+		if err != nil {
+			return nil, err
+		}
+
+		return &Geometry{LineString: &LineString{Points: points}}, nil
+	case wkbPolygon:
+		polygon, err := r.readPolygon(byteOrder, layout)
+		if err != nil {
+			return nil, err
+		}
+
+		return &Geometry{Polygon: polygon}, nil
+	case wkbMultiPoint, wkbMultiLineString, wkbMultiPolygon, wkbGeometryCollection:
+		count, err := r.readUint32(byteOrder)
+		if err != nil {
+			return nil, err
+		}
+
+		geometries := make([]*Geometry, 0, min(int(count), len(r.data)))
+		for i := uint32(0); i < count; i++ {
+			geometry, err := r.readGeometry()
+			if err != nil {
+				return nil, err
+			}
+
+			geometries = append(geometries, geometry)
+		}
+
+		return collectWKBGeometries(geometryType, geometries)
+	default:
+		return nil, fmt.Errorf("unsupported WKB geometry type: %d", geometryType)
+	}
+}
+
+func (r *wkbReader) readPoint(byteOrder binary.ByteOrder, layout wkbLayout) (*Point, error) {
+	ordinates := make([]float64, layout.dimensions())
+	for i := range ordinates {
+		ordinate, err := r.readFloat64(byteOrder)
+		if err != nil {
+			return nil, err
+		}
+
+		ordinates[i] = ordinate
+	}
+
+	point := &Point{Longitude: ordinates[0], Latitude: ordinates[1]}
+	if layout.z && !math.IsNaN(ordinates[2]) {
+		altitude := ordinates[2]
+		point.Altitude = &altitude
+	}
+
+	return point, nil
+}
+
+func (r *wkbReader) readPath(byteOrder binary.ByteOrder, layout wkbLayout) ([]*Point, error) {
+	count, err := r.readUint32(byteOrder)
+	if err != nil {
+		return nil, err
+	}
+
+	if int(count) > (len(r.data)-r.offset)/(8*layout.dimensions()) {
+		return nil, errWKBTruncated
+	}
+
+	points := make([]*Point, count)
+	for i := range points {
+		point, err := r.readPoint(byteOrder, layout)
+		if err != nil {
+			return nil, err
+		}
+
+		points[i] = point
+	}
+
+	return points, nil
+}
+
+func (r *wkbReader) readPolygon(byteOrder binary.ByteOrder, layout wkbLayout) (*Polygon, error) {
+	count, err := r.readUint32(byteOrder)
+	if err != nil {
+		return nil, err
+	}
+
+	if count == 0 {
+		return &Polygon{OuterPath: &MultiPoint{}}, nil
+	}
+
+	var paths []*MultiPoint
+	for i := uint32(0); i < count; i++ {
+		points, err := r.readPath(byteOrder, layout)
+		if err != nil {
+			return nil, err
+		}
+
+		paths = append(paths, &MultiPoint{Points: points})
+	}
+
+	return &Polygon{OuterPath: paths[0], InnerPaths: paths[1:]}, nil
+}
+
+func collectWKBGeometries(geometryType uint32, geometries []*Geometry) (*Geometry, error) {
+	switch geometryType {
+	case wkbMultiPoint:
+		multiPoint := &MultiPoint{Points: make([]*Point, 0, len(geometries))}
+		for _, geometry := range geometries {
+			if geometry.Point == nil {
+				return nil, errors.New("invalid WKB MultiPoint member")
+			}
+			multiPoint.Points = append(multiPoint.Points, geometry.Point)
+		}
+
+		return &Geometry{MultiPoint: multiPoint}, nil
+	case wkbMultiLineString:
+		multiLineString := &MultiLineString{LineStrings: make([]*LineString, 0, len(geometries))}
+		for _, geometry := range geometries {
+			if geometry.LineString == nil {
+				return nil, errors.New("invalid WKB MultiLineString member")
+			}
+			multiLineString.LineStrings = append(multiLineString.LineStrings, geometry.LineString)
+		}
+
+		return &Geometry{MultiLineString: multiLineString}, nil
+	case wkbMultiPolygon:
+		multiPolygon := &MultiPolygon{Polygons: make([]*Polygon, 0, len(geometries))}
+		for _, geometry := range geometries {
+			if geometry.Polygon == nil {
+				return nil, errors.New("invalid WKB MultiPolygon member")
+			}
+			multiPolygon.Polygons = append(multiPolygon.Polygons, geometry.Polygon)
+		}
+
+		return &Geometry{MultiPolygon: multiPolygon}, nil
+	default:
+		return &Geometry{GeometryCollection: &GeometryCollection{Geometries: geometries}}, nil
+	}
+}
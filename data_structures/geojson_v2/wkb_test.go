@@ -0,0 +1,244 @@
+package geojson_v2
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestWKBRoundTrip(t *testing.T) {
+	for _, data := range []string{
+		`{"type":"Point","coordinates":[-96.05,41.26]}`,
+		`{"type":"Point","coordinates":[-96.05,41.26,300.5]}`,
+		`{"type":"MultiPoint","coordinates":[[-97,38],[-96,39,12]]}`,
+		`{"type":"LineString","coordinates":[[-96.05,41.26,1],[-96.2,41.1,2]]}`,
+		`{"type":"MultiLineString","coordinates":[[[-96.05,41.26],[-96.2,41.1]],[[-97,38],[-96,39]]]}`,
+		`{"type":"Polygon","coordinates":[[[0,0],[10,0],[10,10],[0,10],[0,0]],[[4,4],[4,6],[6,6],[6,4],[4,4]]]}`,
+		`{"type":"MultiPolygon","coordinates":[[[[0,0,5],[2,0,5],[2,2,5],[0,2,5],[0,0,5]]],[[[5,5],[7,5],[7,7],[5,7],[5,5]]]]}`,
+		`{"type":"GeometryCollection","geometries":[]}`,
+		`{"type":"GeometryCollection","geometries":[{"type":"Point","coordinates":[-96.05,41.26,300]},{"type":"GeometryCollection","geometries":[{"type":"LineString","coordinates":[[-96.05,41.26],[-96.2,41.1]]}]}]}`,
+	} {
+		geometry := mustGeometry(t, data)
+
+		for name, marshal := range map[string]func() ([]byte, error){
+			"WKB":  geometry.MarshalWKB,
+			"EWKB": func() ([]byte, error) { return geometry.MarshalEWKB(WGS84SRID) },
+		} {
+			wkb, err := marshal()
+			if err != nil {
+				t.Fatalf("%s %s: %v", name, data, err)
+			}
+
+			var decoded Geometry
+			err = decoded.UnmarshalWKB(wkb)
+			if err != nil {
+				t.Fatalf("%s %s: %v", name, data, err)
+			}
+
+			marshalled, err := json.Marshal(&decoded)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(marshalled) != data {
+				t.Errorf("%s round trip\n got: %s\nwant: %s", name, marshalled, data)
+			}
+		}
+	}
+}
+
+func TestWKBHeaders(t *testing.T) {
+	point := mustGeometry(t, `{"type":"Point","coordinates":[1,2]}`)
+	pointZ := mustGeometry(t, `{"type":"Point","coordinates":[1,2,3]}`)
+
+	tests := []struct {
+		name   string
+		encode func() ([]byte, error)
+		want   string
+	}{
+		{name: "WKB", encode: point.MarshalWKB,
+			want: "0101000000000000000000f03f0000000000000040"},
+		{name: "ISO WKB Z", encode: pointZ.MarshalWKB,
+			want: "01e9030000000000000000f03f00000000000000400000000000000840"},
+		{name: "EWKB SRID", encode: func() ([]byte, error) { return point.MarshalEWKB(WGS84SRID) },
+			want: "0101000020e6100000000000000000f03f0000000000000040"},
+		{name: "EWKB SRID Z", encode: func() ([]byte, error) { return pointZ.MarshalEWKB(WGS84SRID) },
+			want: "01010000a0e6100000000000000000f03f00000000000000400000000000000840"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			wkb, err := test.encode()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := hex.EncodeToString(wkb); got != test.want {
+				t.Errorf("got %s, want %s", got, test.want)
+			}
+		})
+	}
+}
+
+func TestWKBMissingAltitudeInZGeometry(t *testing.T) {
+	geometry := mustGeometry(t, `{"type":"LineString","coordinates":[[1,2,3],[4,5]]}`)
+
+	wkb, err := geometry.MarshalEWKB(WGS84SRID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var decoded Geometry
+	err = decoded.UnmarshalWKB(wkb)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	points := decoded.LineString.Points
+	if points[0].Altitude == nil || *points[0].Altitude != 3 {
+		t.Errorf("first altitude = %v, want 3", points[0].Altitude)
+	}
+	if points[1].Altitude != nil {
+		t.Errorf("second altitude = %v, want none", *points[1].Altitude)
+	}
+}
+
+func TestUnmarshalWKB(t *testing.T) {
+	tests := []struct {
+		name    string
+		wkb     string
+		want    string
+		wantErr string
+	}{
+		// SRID=4326;POINT M (1 2 7)
+		{name: "EWKB M", wkb: "0101000060e6100000000000000000f03f00000000000000400000000000001c40",
+			want: `{"type":"Point","coordinates":[1,2]}`},
+		// POINT ZM (1 2 3 7) as ISO WKB
+		{name: "ISO WKB ZM", wkb: "01b90b0000000000000000f03f000000000000004000000000000008400000000000001c40",
+			want: `{"type":"Point","coordinates":[1,2,3]}`},
+		// POINT (1 2) as big endian WKB
+		{name: "big endian", wkb: "00000000013ff00000000000004000000000000000",
+			want: `{"type":"Point","coordinates":[1,2]}`},
+		{name: "truncated", wkb: "0101000000000000000000f03f", wantErr: "unexpected end of WKB"},
+		{name: "trailing bytes", wkb: "0101000000000000000000f03f000000000000004000", wantErr: "trailing WKB bytes"},
+		{name: "byte order", wkb: "0201000000", wantErr: "invalid WKB byte order"},
+		{name: "geometry type", wkb: "0108000000", wantErr: "unsupported WKB geometry type"},
+		// MULTIPOINT containing a LINESTRING
+		{name: "multi point member", wkb: "010400000001000000010200000000000000", wantErr: "invalid WKB MultiPoint member"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			wkb, err := hex.DecodeString(test.wkb)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var geometry Geometry
+			err = geometry.UnmarshalWKB(wkb)
+			if test.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), test.wantErr) {
+					t.Fatalf("err = %v, want %q", err, test.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			marshalled, err := json.Marshal(&geometry)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(marshalled) != test.want {
+				t.Errorf("got %s, want %s", marshalled, test.want)
+			}
+		})
+	}
+}
+
+func TestWKBEmptyGeometries(t *testing.T) {
+	for _, wkt := range []string{
+		"MULTIPOINT EMPTY",
+		"LINESTRING EMPTY",
+		"MULTILINESTRING EMPTY",
+		"POLYGON EMPTY",
+		"MULTIPOLYGON EMPTY",
+		"GEOMETRYCOLLECTION EMPTY",
+	} {
+		var geometry Geometry
+		err := geometry.UnmarshalWKT(wkt)
+		if err != nil {
+			t.Fatalf("%s: %v", wkt, err)
+		}
+
+		wkb, err := geometry.MarshalEWKB(WGS84SRID)
+		if err != nil {
+			t.Fatalf("%s: %v", wkt, err)
+		}
+
+		var decoded Geometry
+		err = decoded.UnmarshalWKB(wkb)
+		if err != nil {
+			t.Fatalf("%s: %v", wkt, err)
+		}
+
+		got, err := decoded.MarshalWKT()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != wkt {
+			t.Errorf("got %s, want %s", got, wkt)
+		}
+	}
+
+	_, err := (&Geometry{}).MarshalWKB()
+	if err == nil {
+		t.Error("expected an error encoding a geometry without a type")
+	}
+}
+
+func TestGeometryScanAndValue(t *testing.T) {
+	geometry := mustGeometry(t, `{"type":"Point","coordinates":[1,2,3]}`)
+
+	value, err := geometry.Value()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if value != "01010000a0e6100000000000000000f03f00000000000000400000000000000840" {
+		t.Errorf("value = %v", value)
+	}
+
+	for _, src := range []interface{}{value, []byte(value.(string))} {
+		var scanned Geometry
+		err = scanned.Scan(src)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if scanned.Point == nil || scanned.Point.Altitude == nil || *scanned.Point.Altitude != 3 {
+			t.Errorf("scanned %T = %+v", src, scanned.Point)
+		}
+	}
+
+	raw, err := geometry.MarshalWKB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var scanned Geometry
+	err = scanned.Scan(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if scanned.Point == nil || *scanned.Point.Altitude != 3 {
+		t.Errorf("scanned raw WKB = %+v", scanned.Point)
+	}
+
+	err = scanned.Scan(nil)
+	if err != nil || !scanned.IsEmpty() {
+		t.Errorf("scanned NULL = %+v, %v", scanned, err)
+	}
+
+	value, err = (&Geometry{}).Value()
+	if err != nil || value != nil {
+		t.Errorf("empty geometry value = %v, %v", value, err)
+	}
+}
@@ -0,0 +1,497 @@
+package geojson_v2
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// MarshalWKT encodes the geometry as Well-Known Text. Coordinates are written with full precision, and the geometry is
+// tagged Z with a third ordinate when any of its positions has an altitude.
+func (g *Geometry) MarshalWKT() (string, error) {
+	var builder strings.Builder
+	err := g.writeWKT(&builder, g.hasAltitude())
+	if err != nil {
+		return "", err
+	}
+
+	return builder.String(), nil
+}
+
+// UnmarshalWKT decodes Well-Known Text, or PostGIS Extended Well-Known Text with a leading SRID, into the geometry.
+// A Z ordinate is read as the altitude, while M ordinates are accepted and discarded.
+func (g *Geometry) UnmarshalWKT(data string) error {
+	if strings.HasPrefix(strings.ToUpper(strings.TrimSpace(data)), "SRID=") {
+		_, data, _ = strings.Cut(data, ";")
+	}
+
+	parser := &wktParser{tokens: tokenizeWKT(data)}
+	geometry, err := parser.parseGeometry()
+	if err != nil {
+		return err
+	}
+
+	if parser.position != len(parser.tokens) {
+		return fmt.Errorf("unexpected WKT token: %s", parser.tokens[parser.position])
+	}
+
+	*g = *geometry
+	return nil
+}
+
+func (g *Geometry) writeWKT(builder *strings.Builder, z bool) error {
+	if g == nil {
+		return errors.New("cannot encode nil geometry as WKT")
+	}
+
+	if g.Point != nil {
+		writeWKTType(builder, "POINT", z)
+		builder.WriteString("(")
+		writeWKTPoint(builder, g.Point, z)
+		builder.WriteString(")")
+	} else if g.MultiPoint != nil {
+		writeWKTType(builder, "MULTIPOINT", z)
+		if len(g.MultiPoint.Points) == 0 {
+			builder.WriteString("EMPTY")
+			return nil
+		}
+
+		builder.WriteString("(")
+		for i, point := range g.MultiPoint.Points {
+			if i > 0 {
+				builder.WriteString(", ")
+			}
+			builder.WriteString("(")
+			writeWKTPoint(builder, point, z)
+			builder.WriteString(")")
+		}
+		builder.WriteString(")")
+	} else if g.LineString != nil {
+		writeWKTType(builder, "LINESTRING", z)
+		writeWKTPath(builder, g.LineString.Points, z)
+	} else if g.MultiLineString != nil {
+		writeWKTType(builder, "MULTILINESTRING", z)
+		if len(g.MultiLineString.LineStrings) == 0 {
+			builder.WriteString("EMPTY")
+			return nil
+		}
+
+		builder.WriteString("(")
+		for i, lineString := range g.MultiLineString.LineStrings {
+			if i > 0 {
+				builder.WriteString(", ")
+			}
+			writeWKTPath(builder, lineString.Points, z)
+		}
+		builder.WriteString(")")
+	} else if g.Polygon != nil {
+		writeWKTType(builder, "POLYGON", z)
+		writeWKTPolygon(builder, g.Polygon, z)
+	} else if g.MultiPolygon != nil {
+		writeWKTType(builder, "MULTIPOLYGON", z)
+		if len(g.MultiPolygon.Polygons) == 0 {
+			builder.WriteString("EMPTY")
+			return nil
+		}
+
+		builder.WriteString("(")
+		for i, polygon := range g.MultiPolygon.Polygons {
+			if i > 0 {
+				builder.WriteString(", ")
+			}
+			writeWKTPolygon(builder, polygon, z)
+		}
+		builder.WriteString(")")
+	} else if g.GeometryCollection != nil {
+		writeWKTType(builder, "GEOMETRYCOLLECTION", z)
+		if len(g.GeometryCollection.Geometries) == 0 {
+			builder.WriteString("EMPTY")
+			return nil
+		}
+
+		builder.WriteString("(")
+		for i, geometry := range g.GeometryCollection.Geometries {
+			if i > 0 {
+				builder.WriteString(", ")
+			}
+			err := geometry.writeWKT(builder, z)
+			if err != nil {
+				return err
+			}
+		}
+		builder.WriteString(")")
+	} else {
+		builder.WriteString("GEOMETRYCOLLECTION EMPTY")
+	}
+
+	return nil
+}
+
+// writeWKTType writes the geometry type keyword, tagged Z when positions carry a third ordinate.
+func writeWKTType(builder *strings.Builder, geometryType string, z bool) {
+	builder.WriteString(geometryType)
+	if z {
+		builder.WriteString(" Z")
+	}
+	builder.WriteString(" ")
+}
+
+// writeWKTPoint writes the ordinates of a position. A position without an altitude in a Z geometry is written with a
+// NaN Z ordinate, which is read back as no altitude.
+func writeWKTPoint(builder *strings.Builder, point *Point, z bool) {
+	builder.WriteString(strconv.FormatFloat(point.Longitude, 'f', -1, 64))
+	builder.WriteString(" ")
+	builder.WriteString(strconv.FormatFloat(point.Latitude, 'f', -1, 64))
+	if !z {
+		return
+	}
+
+	altitude := math.NaN()
+	if point.Altitude != nil {
+		altitude = *point.Altitude
+	}
+	builder.WriteString(" ")
+	builder.WriteString(strconv.FormatFloat(altitude, 'f', -1, 64))
+}
+
+func writeWKTPath(builder *strings.Builder, points []*Point, z bool) {
+	if len(points) == 0 {
+		builder.WriteString("EMPTY")
+		return
+	}
+
+	builder.WriteString("(")
+	for i, point := range points {
+		if i > 0 {
+			builder.WriteString(", ")
+		}
+		writeWKTPoint(builder, point, z)
+	}
+	builder.WriteString(")")
+}
+
+func writeWKTPolygon(builder *strings.Builder, polygon *Polygon, z bool) {
+	if polygon.OuterPath == nil || len(polygon.OuterPath.Points) == 0 {
+		builder.WriteString("EMPTY")
+		return
+	}
+
+	builder.WriteString("(")
+	writeWKTPath(builder, polygon.OuterPath.Points, z)
+	for _, innerPath := range polygon.InnerPaths {
+		builder.WriteString(", ")
+		writeWKTPath(builder, innerPath.Points, z)
+	}
+	builder.WriteString(")")
+}
+
+func tokenizeWKT(data string) []string {
+	var tokens []string
+	start := -1
+	for i, r := range data {
+		switch {
+		case r == '(' || r == ')' || r == ',':
+			if start >= 0 {
+				tokens = append(tokens, data[start:i])
+				start = -1
+			}
+			tokens = append(tokens, string(r))
+		case r == ' ' || r == '\t' || r == '\n' || r == '\r':
+			if start >= 0 {
+				tokens = append(tokens, data[start:i])
+				start = -1
+			}
+		default:
+			if start < 0 {
+				start = i
+			}
+		}
+	}
+	if start >= 0 {
+		tokens = append(tokens, data[start:])
+	}
+
+	return tokens
+}
+
+type wktParser struct {
+	tokens   []string
+	position int
+	// measured records an M tag, after which a third ordinate is a measure rather than an altitude.
+	measured bool
+}
+
+func (p *wktParser) peek() string {
+	if p.position >= len(p.tokens) {
+		return ""
+	}
+	return strings.ToUpper(p.tokens[p.position])
+}
+
+func (p *wktParser) next() string {
+	token := p.peek()
+	if p.position < len(p.tokens) {
+		p.position++
+	}
+	return token
+}
+
+func (p *wktParser) expect(token string) error {
+	actual := p.next()
+	if actual != token {
+		if actual == "" {
+			return fmt.Errorf("unexpected end of WKT, expected %s", token)
+		}
+		return fmt.Errorf("unexpected WKT token %s, expected %s", actual, token)
+	}
+	return nil
+}
+
+// empty consumes an EMPTY token, reporting whether one was present.
+func (p *wktParser) empty() bool {
+	if p.peek() == "EMPTY" {
+		p.position++
+		return true
+	}
+	return false
+}
+
+func (p *wktParser) parseGeometry() (*Geometry, error) {
+	geometryType := p.next()
+	switch p.peek() {
+	case "M":
+		p.measured = true
+		p.position++
+	case "Z", "ZM":
+		p.position++
+	}
+
+	switch geometryType {
+	case "POINT":
+		if p.empty() {
+			return nil, errors.New("empty WKT POINT is not supported")
+		}
+
+		err := p.expect("(")
+		if err != nil {
+			return nil, err
+		}
+		point, err := p.parsePoint()
+		if err != nil {
+			return nil, err
+		}
+		err = p.expect(")")
+		if err != nil {
+			return nil, err
+		}
+
+		return &Geometry{Point: point}, nil
+	case "MULTIPOINT":
+		multiPoint := &MultiPoint{}
+		if p.empty() {
+			return &Geometry{MultiPoint: multiPoint}, nil
+		}
+
+		err := p.parseList(func() error {
+			parenthesized := p.peek() == "("
+			if parenthesized {
+				p.position++
+			}
+			point, err := p.parsePoint()
+			if err != nil {
+				return err
+			}
+			if parenthesized {
+				err = p.expect(")")
+				if err != nil {
+					return err
+				}
+			}
+
+			multiPoint.Points = append(multiPoint.Points, point)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return &Geometry{MultiPoint: multiPoint}, nil
+	case "LINESTRING":
+		points, err := p.parsePath()
+		if err != nil {
+			return nil, err
+		}
+
+		return &Geometry{LineString: &LineString{Points: points}}, nil
+	case "MULTILINESTRING":
+		multiLineString := &MultiLineString{}
+		if p.empty() {
+			return &Geometry{MultiLineString: multiLineString}, nil
+		}
+
+		err := p.parseList(func() error {
+			points, err := p.parsePath()
+			if err != nil {
+				return err
+			}
+
+			multiLineString.LineStrings = append(multiLineString.LineStrings, &LineString{Points: points})
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return &Geometry{MultiLineString: multiLineString}, nil
+	case "POLYGON":
+		if p.empty() {
+			return &Geometry{Polygon: &Polygon{OuterPath: &MultiPoint{}}}, nil
+		}
+
+		polygon, err := p.parsePolygon()
+		if err != nil {
+			return nil, err
+		}
+
+		return &Geometry{Polygon: polygon}, nil
+	case "MULTIPOLYGON":
+		multiPolygon := &MultiPolygon{}
+		if p.empty() {
+			return &Geometry{MultiPolygon: multiPolygon}, nil
+		}
+
+		err := p.parseList(func() error {
+			polygon, err := p.parsePolygon()
+			if err != nil {
+				return err
+			}
+
+			multiPolygon.Polygons = append(multiPolygon.Polygons, polygon)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return &Geometry{MultiPolygon: multiPolygon}, nil
+	case "GEOMETRYCOLLECTION":
+		geometryCollection := &GeometryCollection{}
+		if p.empty() {
+			return &Geometry{GeometryCollection: geometryCollection}, nil
+		}
+
+		err := p.parseList(func() error {
+			geometry, err := p.parseGeometry()
+			if err != nil {
+				return err
+			}
+
+			geometryCollection.Geometries = append(geometryCollection.Geometries, geometry)
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		return &Geometry{GeometryCollection: geometryCollection}, nil
+	case "":
+		return nil, errors.New("unexpected end of WKT")
+	default:
+		return nil, fmt.Errorf("unsupported geometry type: %s", geometryType)
+	}
+}
+
+// parseList parses a parenthesized, comma separated list, calling parseItem for every item.
+func (p *wktParser) parseList(parseItem func() error) error {
+	err := p.expect("(")
+	if err != nil {
+		return err
+	}
+
+	for {
+		err := parseItem()
+		if err != nil {
+			return err
+		}
+
+		token := p.next()
+		if token == ")" {
+			return nil
+		} else if token != "," {
+			return fmt.Errorf("unexpected WKT token %s, expected , or )", token)
+		}
+	}
+}
+
+func (p *wktParser) parsePoint() (*Point, error) {
+	var ordinates []float64
+	for {
+		token := p.peek()
+		if token == "," || token == ")" || token == "" {
+			break
+		}
+
+		ordinate, err := strconv.ParseFloat(token, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid WKT coordinate: %s", token)
+		}
+
+		ordinates = append(ordinates, ordinate)
+		p.position++
+	}
+
+	if len(ordinates) < 2 || len(ordinates) > 4 {
+		return nil, fmt.Errorf("invalid number of coordinates for Point: %v", ordinates)
+	}
+
+	point := &Point{Longitude: ordinates[0], Latitude: ordinates[1]}
+	hasZ := len(ordinates) == 4 || (len(ordinates) == 3 && !p.measured)
+	if hasZ && !math.IsNaN(ordinates[2]) {
+		altitude := ordinates[2]
+		point.Altitude = &altitude
+	}
+
+	return point, nil
+}
+
+func (p *wktParser) parsePath() ([]*Point, error) {
+	var points []*Point
+	if p.empty() {
+		return points, nil
+	}
+
+	err := p.parseList(func() error {
+		point, err := p.parsePoint()
+		if err != nil {
+			return err
+		}
+
+		points = append(points, point)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return points, nil
+}
+
+func (p *wktParser) parsePolygon() (*Polygon, error) {
+	var paths []*MultiPoint
+	err := p.parseList(func() error {
+		points, err := p.parsePath()
+		if err != nil {
+			return err
+		}
+
+		paths = append(paths, &MultiPoint{Points: points})
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Polygon{OuterPath: paths[0], InnerPaths: paths[1:]}, nil
+}
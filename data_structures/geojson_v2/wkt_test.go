@@ -0,0 +1,103 @@
+package geojson_v2
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestWKTRoundTrip(t *testing.T) {
+	for _, wkt := range []string{
+		"POINT (-96.05 41.26)",
+		"POINT Z (-96.05 41.26 300.5)",
+		"MULTIPOINT ((-97 38), (-96 39))",
+		"LINESTRING Z (-96.05 41.26 1, -96.2 41.1 NaN)",
+		"MULTILINESTRING ((-96.05 41.26, -96.2 41.1), (-97 38, -96 39))",
+		"POLYGON ((0 0, 10 0, 10 10, 0 10, 0 0), (4 4, 4 6, 6 6, 6 4, 4 4))",
+		"MULTIPOLYGON Z (((0 0 5, 2 0 5, 2 2 5, 0 2 5, 0 0 5)))",
+		"GEOMETRYCOLLECTION Z (POINT Z (1 2 3), GEOMETRYCOLLECTION Z (LINESTRING Z (0 0 NaN, 1 1 NaN)))",
+		"MULTIPOINT EMPTY",
+		"LINESTRING EMPTY",
+		"MULTILINESTRING EMPTY",
+		"POLYGON EMPTY",
+		"MULTIPOLYGON EMPTY",
+		"GEOMETRYCOLLECTION EMPTY",
+	} {
+		var geometry Geometry
+		err := geometry.UnmarshalWKT(wkt)
+		if err != nil {
+			t.Fatalf("%s: %v", wkt, err)
+		}
+
+		got, err := geometry.MarshalWKT()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != wkt {
+			t.Errorf("round trip\n got: %s\nwant: %s", got, wkt)
+		}
+	}
+}
+
+func TestUnmarshalWKT(t *testing.T) {
+	tests := []struct {
+		name    string
+		wkt     string
+		want    string
+		wantErr string
+	}{
+		{name: "SRID", wkt: "SRID=4326;POINT(1 2)", want: `{"type":"Point","coordinates":[1,2]}`},
+		{name: "untagged Z", wkt: "POINT (1 2 3)", want: `{"type":"Point","coordinates":[1,2,3]}`},
+		{name: "M", wkt: "POINT M (1 2 7)", want: `{"type":"Point","coordinates":[1,2]}`},
+		{name: "ZM", wkt: "point zm (1 2 3 7)", want: `{"type":"Point","coordinates":[1,2,3]}`},
+		{name: "unparenthesized multi point", wkt: "MULTIPOINT (1 2, 3 4)",
+			want: `{"type":"MultiPoint","coordinates":[[1,2],[3,4]]}`},
+		{name: "empty point", wkt: "POINT EMPTY", wantErr: "empty WKT POINT"},
+		{name: "unknown type", wkt: "CIRCLE (1 2)", wantErr: "unsupported geometry type"},
+		{name: "coordinate", wkt: "POINT (1 x)", wantErr: "invalid WKT coordinate"},
+		{name: "one ordinate", wkt: "POINT (1)", wantErr: "invalid number of coordinates"},
+		{name: "unclosed", wkt: "LINESTRING (1 2, 3 4", wantErr: "expected , or )"},
+		{name: "trailing", wkt: "POINT (1 2) POINT (3 4)", wantErr: "unexpected WKT token"},
+		{name: "empty", wkt: "", wantErr: "unexpected end of WKT"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var geometry Geometry
+			err := geometry.UnmarshalWKT(test.wkt)
+			if test.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), test.wantErr) {
+					t.Fatalf("err = %v, want %q", err, test.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			marshalled, err := json.Marshal(&geometry)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(marshalled) != test.want {
+				t.Errorf("got %s, want %s", marshalled, test.want)
+			}
+		})
+	}
+}
+
+func TestMarshalWKTMixedAltitudes(t *testing.T) {
+	geometry := mustGeometry(t, `{"type":"MultiPoint","coordinates":[[-97,38],[-96,39,12]]}`)
+
+	got, err := geometry.MarshalWKT()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "MULTIPOINT Z ((-97 38 NaN), (-96 39 12))"; got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+
+	if got, _ := (&Geometry{}).MarshalWKT(); got != "GEOMETRYCOLLECTION EMPTY" {
+		t.Errorf("empty geometry = %s", got)
+	}
+}
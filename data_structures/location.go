@@ -3,6 +3,8 @@ package data_structures
 import (
 	"time"
 
+	"github.com/cmeyer18/weather-common/v6/data_structures/same"
+	"github.com/cmeyer18/weather-common/v6/data_structures/ugc"
 	"github.com/cmeyer18/weather-common/v6/generative/golang"
 )
 
@@ -16,6 +18,7 @@ type Location struct {
 	Latitude                         float64
 	Longitude                        float64
 	LocationName                     string
+	ProximityRadiusMeters            float64
 	ConvectiveOutlookOptions         []golang.ConvectiveOutlookType
 	AlertOptions                     []golang.AlertType
 	MesoscaleDiscussionNotifications bool
@@ -28,3 +31,14 @@ const (
 	LocationType_UserLocation   LocationType = 1
 	LocationType_DeviceLocaiton LocationType = 2
 )
+
+// SAMECode maps the county code of the location to the SAME code of the entire county, for matching alerts the way a
+// NOAA Weather Radio receiver does.
+func (l *Location) SAMECode() (same.Code, error) {
+	code, err := ugc.ParseCode(l.CountyCode)
+	if err != nil {
+		return same.Code{}, err
+	}
+
+	return same.FromUGC(code)
+}
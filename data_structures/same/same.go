@@ -0,0 +1,165 @@
+package same
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/cmeyer18/weather-common/v6/data_structures/ugc"
+)
+
+// Subdivision is the part of a county a SAME code covers, as the ninths NOAA Weather Radio divides a county into.
+type Subdivision int
+
+const (
+	Subdivision_EntireCounty Subdivision = 0
+	Subdivision_Northwest    Subdivision = 1
+	Subdivision_North        Subdivision = 2
+	Subdivision_Northeast    Subdivision = 3
+	Subdivision_West         Subdivision = 4
+	Subdivision_Central      Subdivision = 5
+	Subdivision_East         Subdivision = 6
+	Subdivision_Southwest    Subdivision = 7
+	Subdivision_South        Subdivision = 8
+	Subdivision_Southeast    Subdivision = 9
+)
+
+// stateFIPS maps the state prefix of UGC codes to the FIPS state code used in SAME codes.
+var stateFIPS = map[string]int{
+	"AL": 1, "AK": 2, "AZ": 4, "AR": 5, "CA": 6, "CO": 8, "CT": 9, "DE": 10, "DC": 11, "FL": 12, "GA": 13, "HI": 15,
+	"ID": 16, "IL": 17, "IN": 18, "IA": 19, "KS": 20, "KY": 21, "LA": 22, "ME": 23, "MD": 24, "MA": 25, "MI": 26,
+	"MN": 27, "MS": 28, "MO": 29, "MT": 30, "NE": 31, "NV": 32, "NH": 33, "NJ": 34, "NM": 35, "NY": 36, "NC": 37,
+	"ND": 38, "OH": 39, "OK": 40, "OR": 41, "PA": 42, "RI": 44, "SC": 45, "SD": 46, "TN": 47, "TX": 48, "UT": 49,
+	"VT": 50, "VA": 51, "WA": 53, "WV": 54, "WI": 55, "WY": 56, "AS": 60, "FM": 64, "GU": 66, "MH": 68, "MP": 69,
+	"PW": 70, "PR": 72, "UM": 74, "VI": 78,
+}
+
+var codePattern = regexp.MustCompile(`^(\d)(\d{2})(\d{3})$`)
+
+// Code is a six digit Specific Area Message Encoding location code, such as 020177, made of a subdivision digit
+// followed by the FIPS state and county codes.
+type Code struct {
+	Subdivision Subdivision
+	StateFIPS   int
+	CountyFIPS  int
+}
+
+// Parse parses a SAME code, ignoring surrounding whitespace.
+func Parse(value string) (Code, error) {
+	matches := codePattern.FindStringSubmatch(strings.TrimSpace(value))
+	if matches == nil {
+		return Code{}, fmt.Errorf("invalid SAME code: %s", value)
+	}
+
+	subdivision, err := strconv.Atoi(matches[1])
+	if err != nil {
+		return Code{}, fmt.Errorf("invalid SAME subdivision: %w", err)
+	}
+
+	state, err := strconv.Atoi(matches[2])
+	if err != nil {
+		return Code{}, fmt.Errorf("invalid SAME state: %w", err)
+	}
+
+	county, err := strconv.Atoi(matches[3])
+	if err != nil {
+		return Code{}, fmt.Errorf("invalid SAME county: %w", err)
+	}
+
+	return Code{Subdivision: Subdivision(subdivision), StateFIPS: state, CountyFIPS: county}, nil
+}
+
+// FromUGC maps a county UGC code, such as KSC177, to the SAME code of the entire county. Zone codes do not follow
+// county lines and can not be mapped.
+func FromUGC(code ugc.Code) (Code, error) {
+	if !code.IsCounty() {
+		return Code{}, fmt.Errorf("unsupported UGC type for SAME: %s", code.Type)
+	}
+
+	state, ok := stateFIPS[code.State]
+	if !ok {
+		return Code{}, fmt.Errorf("unsupported UGC state prefix for SAME: %s", code.State)
+	}
+
+	return Code{Subdivision: Subdivision_EntireCounty, StateFIPS: state, CountyFIPS: code.Number}, nil
+}
+
+// String formats the code as six digits.
+func (c Code) String() string {
+	return fmt.Sprintf("%d%02d%03d", c.Subdivision, c.StateFIPS, c.CountyFIPS)
+}
+
+// County returns the code of the entire county the code is in.
+func (c Code) County() Code {
+	c.Subdivision = Subdivision_EntireCounty
+	return c
+}
+
+// Matches reports whether an alert for one code applies to the other, the way a NOAA Weather Radio receiver matches
+// them: the codes must be in the same county, and either one covers the entire county or both cover the same part.
+func (c Code) Matches(other Code) bool {
+	if c.County() != other.County() {
+		return false
+	}
+
+	return c.Subdivision == Subdivision_EntireCounty || other.Subdivision == Subdivision_EntireCounty ||
+		c.Subdivision == other.Subdivision
+}
+
+// Matching returns every code that Matches the code.
+func (c Code) Matching() []Code {
+	if c.Subdivision != Subdivision_EntireCounty {
+		return []Code{c.County(), c}
+	}
+
+	codes := make([]Code, 0, Subdivision_Southeast+1)
+	for subdivision := Subdivision_EntireCounty; subdivision <= Subdivision_Southeast; subdivision++ {
+		code := c
+		code.Subdivision = subdivision
+		codes = append(codes, code)
+	}
+	return codes
+}
+
+// Normalize parses each of the codes and returns them formatted the same way, with duplicates removed.
+func Normalize(values []string) ([]string, error) {
+	var normalized []string
+	seen := make(map[Code]bool)
+	for _, value := range values {
+		code, err := Parse(value)
+		if err != nil {
+			return nil, err
+		}
+
+		if seen[code] {
+			continue
+		}
+
+		seen[code] = true
+		normalized = append(normalized, code.String())
+	}
+
+	return normalized, nil
+}
+
+// NormalizeLenient normalizes the values like Normalize, keeping the values that do not parse as they are rather than
+// failing, so that unusual codes are not lost.
+func NormalizeLenient(values []string) []string {
+	var normalized []string
+	seen := make(map[string]bool)
+	for _, value := range values {
+		if code, err := Parse(value); err == nil {
+			value = code.String()
+		}
+
+		if seen[value] {
+			continue
+		}
+
+		seen[value] = true
+		normalized = append(normalized, value)
+	}
+
+	return normalized
+}
@@ -0,0 +1,122 @@
+package same
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/cmeyer18/weather-common/v6/data_structures/ugc"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		value string
+		want  Code
+	}{
+		{value: "031055", want: Code{Subdivision: Subdivision_EntireCounty, StateFIPS: 31, CountyFIPS: 55}},
+		{value: " 020177 ", want: Code{Subdivision: Subdivision_EntireCounty, StateFIPS: 20, CountyFIPS: 177}},
+		{value: "131055", want: Code{Subdivision: Subdivision_Northwest, StateFIPS: 31, CountyFIPS: 55}},
+		{value: "531055", want: Code{Subdivision: Subdivision_Central, StateFIPS: 31, CountyFIPS: 55}},
+		{value: "902001", want: Code{Subdivision: Subdivision_Southeast, StateFIPS: 2, CountyFIPS: 1}},
+	}
+
+	for _, test := range tests {
+		code, err := Parse(test.value)
+		if err != nil {
+			t.Fatalf("%s: %v", test.value, err)
+		}
+		if code != test.want {
+			t.Errorf("%s: code = %+v, want %+v", test.value, code, test.want)
+		}
+		if code.String() != test.value && " "+code.String()+" " != test.value {
+			t.Errorf("%s: String = %s", test.value, code.String())
+		}
+	}
+
+	for _, value := range []string{"", "31055", "0310555", "A31055", "031-55"} {
+		_, err := Parse(value)
+		if err == nil {
+			t.Errorf("%q: err = nil, want an error", value)
+		}
+	}
+}
+
+func TestFromUGC(t *testing.T) {
+	county, err := ugc.ParseCode("KSC177")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	code, err := FromUGC(county)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if code.String() != "020177" {
+		t.Errorf("code = %s, want 020177", code)
+	}
+
+	zone, err := ugc.ParseCode("KSZ001")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = FromUGC(zone)
+	if err == nil {
+		t.Error("err = nil, want zone codes rejected")
+	}
+}
+
+func TestMatches(t *testing.T) {
+	entire := Code{Subdivision: Subdivision_EntireCounty, StateFIPS: 31, CountyFIPS: 55}
+	northwest := Code{Subdivision: Subdivision_Northwest, StateFIPS: 31, CountyFIPS: 55}
+	south := Code{Subdivision: Subdivision_South, StateFIPS: 31, CountyFIPS: 55}
+	otherCounty := Code{Subdivision: Subdivision_Northwest, StateFIPS: 31, CountyFIPS: 153}
+
+	tests := []struct {
+		name string
+		a, b Code
+		want bool
+	}{
+		{name: "same part", a: northwest, b: northwest, want: true},
+		{name: "entire county alert", a: entire, b: northwest, want: true},
+		{name: "entire county location", a: south, b: entire, want: true},
+		{name: "different parts", a: northwest, b: south, want: false},
+		{name: "different counties", a: northwest, b: otherCounty, want: false},
+	}
+
+	for _, test := range tests {
+		if got := test.a.Matches(test.b); got != test.want {
+			t.Errorf("%s: Matches = %v, want %v", test.name, got, test.want)
+		}
+	}
+
+	matching := northwest.Matching()
+	if len(matching) != 2 || matching[0] != entire || matching[1] != northwest {
+		t.Errorf("Matching = %v, want the entire county and the part", matching)
+	}
+	if len(entire.Matching()) != 10 {
+		t.Errorf("Matching = %v, want the entire county and its nine parts", entire.Matching())
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	normalized, err := Normalize([]string{" 031055", "031055", "131055"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"031055", "131055"}; !slices.Equal(normalized, want) {
+		t.Errorf("normalized = %v, want %v", normalized, want)
+	}
+
+	_, err = Normalize([]string{"031055", "31055"})
+	if err == nil {
+		t.Error("err = nil, want malformed codes rejected")
+	}
+}
+
+func TestNormalizeLenient(t *testing.T) {
+	normalized := NormalizeLenient([]string{" 031055", "31055", "031055", "31055", "531055"})
+
+	want := []string{"031055", "31055", "531055"}
+	if !slices.Equal(normalized, want) {
+		t.Errorf("normalized = %v, want %v", normalized, want)
+	}
+}
@@ -0,0 +1,212 @@
+package ugc
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Type tells county codes, numbered by FIPS county, from zone codes, numbered by forecast or marine zone.
+type Type string
+
+const (
+	Type_County Type = "C"
+	Type_Zone   Type = "Z"
+)
+
+// statePrefixes are the states, territories and District of Columbia, which may have county and zone codes.
+var statePrefixes = []string{
+	"AK", "AL", "AR", "AS", "AZ", "CA", "CO", "CT", "DC", "DE", "FL", "FM", "GA", "GU", "HI", "IA", "ID", "IL", "IN",
+	"KS", "KY", "LA", "MA", "MD", "ME", "MH", "MI", "MN", "MO", "MP", "MS", "MT", "NC", "ND", "NE", "NH", "NJ", "NM",
+	"NV", "NY", "OH", "OK", "OR", "PA", "PR", "PW", "RI", "SC", "SD", "TN", "TX", "UM", "UT", "VA", "VI", "VT", "WA",
+	"WI", "WV", "WY",
+}
+
+// marinePrefixes are the coastal and offshore waters and Great Lakes, which only have zone codes.
+var marinePrefixes = []string{
+	"AM", "AN", "GM", "LC", "LE", "LH", "LM", "LO", "LS", "PH", "PK", "PM", "PS", "PZ", "SL",
+}
+
+var (
+	codePattern       = regexp.MustCompile(`^([A-Z]{2})([CZ])(\d{3})$`)
+	groupPattern      = regexp.MustCompile(`^([A-Z]{2})([CZ])(\d{3})(?:>(\d{3}))?$`)
+	numberPattern     = regexp.MustCompile(`^(\d{3})(?:>(\d{3}))?$`)
+	expirationPattern = regexp.MustCompile(`^\d{6}$`)
+)
+
+// Code is a single Universal Geographic Code, such as KSZ001 or MOC095.
+type Code struct {
+	State  string
+	Type   Type
+	Number int
+}
+
+// String formats the code as its state, type and three digit number.
+func (c Code) String() string {
+	return fmt.Sprintf("%s%s%03d", c.State, c.Type, c.Number)
+}
+
+// IsCounty reports whether the code is a county code.
+func (c Code) IsCounty() bool {
+	return c.Type == Type_County
+}
+
+// IsZone reports whether the code is a zone code.
+func (c Code) IsZone() bool {
+	return c.Type == Type_Zone
+}
+
+// ParseCode parses a single code, ignoring case and surrounding whitespace.
+func ParseCode(value string) (Code, error) {
+	matches := codePattern.FindStringSubmatch(strings.ToUpper(strings.TrimSpace(value)))
+	if matches == nil {
+		return Code{}, fmt.Errorf("invalid UGC code: %s", value)
+	}
+
+	return newCode(matches[1], Type(matches[2]), matches[3])
+}
+
+func newCode(state string, codeType Type, number string) (Code, error) {
+	if slices.Contains(marinePrefixes, state) {
+		if codeType != Type_Zone {
+			return Code{}, fmt.Errorf("invalid UGC type for marine prefix %s: %s", state, codeType)
+		}
+	} else if !slices.Contains(statePrefixes, state) {
+		return Code{}, fmt.Errorf("invalid UGC state prefix: %s", state)
+	}
+
+	parsed, err := strconv.Atoi(number)
+	if err != nil {
+		return Code{}, fmt.Errorf("invalid UGC number: %w", err)
+	}
+
+	return Code{State: state, Type: codeType, Number: parsed}, nil
+}
+
+// Parse parses a UGC string as it appears in raw products, such as KSZ001>005-010-MOC001-161200-, expanding ranges
+// into every code they cover. Each group of numbers takes the state and type of the last code before it, and the
+// trailing product expiration time is ignored. Line breaks within the string are allowed.
+func Parse(value string) ([]Code, error) {
+	value = strings.Join(strings.Fields(strings.ToUpper(value)), "")
+
+	var codes []Code
+	var state string
+	var codeType Type
+	for _, group := range strings.Split(value, "-") {
+		if group == "" {
+			continue
+		}
+
+		var first, last string
+		if matches := groupPattern.FindStringSubmatch(group); matches != nil {
+			state, codeType = matches[1], Type(matches[2])
+			first, last = matches[3], matches[4]
+		} else if matches := numberPattern.FindStringSubmatch(group); matches != nil && state != "" {
+			first, last = matches[1], matches[2]
+		} else if expirationPattern.MatchString(group) {
+			continue
+		} else {
+			return nil, fmt.Errorf("invalid UGC group: %s", group)
+		}
+
+		if last == "" {
+			last = first
+		}
+
+		firstCode, err := newCode(state, codeType, first)
+		if err != nil {
+			return nil, err
+		}
+
+		lastCode, err := newCode(state, codeType, last)
+		if err != nil {
+			return nil, err
+		}
+
+		if lastCode.Number < firstCode.Number {
+			return nil, fmt.Errorf("invalid UGC range: %s", group)
+		}
+
+		for number := firstCode.Number; number <= lastCode.Number; number++ {
+			codes = append(codes, Code{State: state, Type: codeType, Number: number})
+		}
+	}
+
+	return codes, nil
+}
+
+// Normalize expands each of the values, which may be single codes or UGC strings with ranges, and returns the codes
+// formatted the same way with duplicates removed, in the order they first appear.
+func Normalize(values []string) ([]string, error) {
+	var normalized []string
+	seen := make(map[Code]bool)
+	for _, value := range values {
+		codes, err := Parse(value)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, code := range codes {
+			if seen[code] {
+				continue
+			}
+
+			seen[code] = true
+			normalized = append(normalized, code.String())
+		}
+	}
+
+	return normalized, nil
+}
+
+// NormalizeLenient normalizes the values like Normalize, keeping the values that do not parse as they are rather than
+// failing, so that codes from unlisted prefixes are not lost.
+func NormalizeLenient(values []string) []string {
+	var normalized []string
+	seen := make(map[string]bool)
+	for _, value := range values {
+		codes, err := Parse(value)
+		if err != nil {
+			if !seen[value] {
+				seen[value] = true
+				normalized = append(normalized, value)
+			}
+			continue
+		}
+
+		for _, code := range codes {
+			if seen[code.String()] {
+				continue
+			}
+
+			seen[code.String()] = true
+			normalized = append(normalized, code.String())
+		}
+	}
+
+	return normalized
+}
+
+// NormalizeCode formats a single code the way Normalize does. An empty value is returned as is.
+func NormalizeCode(value string) (string, error) {
+	if strings.TrimSpace(value) == "" {
+		return "", nil
+	}
+
+	code, err := ParseCode(value)
+	if err != nil {
+		return "", err
+	}
+	return code.String(), nil
+}
+
+// NormalizeCodeLenient normalizes a single code like NormalizeCode, returning a value that does not parse as it is.
+func NormalizeCodeLenient(value string) string {
+	normalized, err := NormalizeCode(value)
+	if err != nil {
+		return value
+	}
+	return normalized
+}
@@ -0,0 +1,118 @@
+package ugc
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+		want  []string
+	}{
+		{name: "single code", value: "KSZ001", want: []string{"KSZ001"}},
+		{name: "range", value: "KSZ001>005", want: []string{"KSZ001", "KSZ002", "KSZ003", "KSZ004", "KSZ005"}},
+		{
+			name:  "range and group",
+			value: "KSZ001>005-010-",
+			want:  []string{"KSZ001", "KSZ002", "KSZ003", "KSZ004", "KSZ005", "KSZ010"},
+		},
+		{
+			name:  "states and types with expiration",
+			value: "KSZ001-MOC001>003-161200-",
+			want:  []string{"KSZ001", "MOC001", "MOC002", "MOC003"},
+		},
+		{name: "line breaks and lower case", value: "nez051-052-\n053-161200-", want: []string{"NEZ051", "NEZ052", "NEZ053"}},
+		{name: "marine zones", value: "LMZ643>646-GMZ850-", want: []string{"LMZ643", "LMZ644", "LMZ645", "LMZ646", "GMZ850"}},
+		{name: "pacific marine zone", value: "PZZ530", want: []string{"PZZ530"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			codes, err := Parse(test.value)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got := make([]string, len(codes))
+			for i, code := range codes {
+				got[i] = code.String()
+			}
+			if !slices.Equal(got, test.want) {
+				t.Errorf("codes = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	for _, value := range []string{
+		"KSZ005>001",
+		"001-KSZ002",
+		"XXZ001",
+		"LMC643",
+		"KSZ01",
+		"KSX001",
+	} {
+		_, err := Parse(value)
+		if err == nil {
+			t.Errorf("%s: err = nil, want an error", value)
+		}
+	}
+}
+
+func TestParseCode(t *testing.T) {
+	code, err := ParseCode(" gmz850 ")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if code.State != "GM" || !code.IsZone() || code.Number != 850 {
+		t.Errorf("code = %+v", code)
+	}
+
+	_, err = ParseCode("GMC850")
+	if err == nil {
+		t.Error("err = nil, want marine county codes rejected")
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	normalized, err := Normalize([]string{"ksz001>003", "KSZ002", "MOC095"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"KSZ001", "KSZ002", "KSZ003", "MOC095"}
+	if !slices.Equal(normalized, want) {
+		t.Errorf("normalized = %v, want %v", normalized, want)
+	}
+
+	_, err = Normalize([]string{"KSZ001", "XXZ001"})
+	if err == nil {
+		t.Error("err = nil, want unlisted prefixes rejected")
+	}
+}
+
+func TestNormalizeLenient(t *testing.T) {
+	normalized := NormalizeLenient([]string{"ksz001>002", "XXZ001", "KSZ002", "XXZ001", "LMZ643"})
+
+	want := []string{"KSZ001", "KSZ002", "XXZ001", "LMZ643"}
+	if !slices.Equal(normalized, want) {
+		t.Errorf("normalized = %v, want %v", normalized, want)
+	}
+}
+
+func TestNormalizeCodeLenient(t *testing.T) {
+	tests := map[string]string{
+		"ksz001": "KSZ001",
+		"XXZ001": "XXZ001",
+		"":       "",
+	}
+
+	for value, want := range tests {
+		if normalized := NormalizeCodeLenient(value); normalized != want {
+			t.Errorf("%q: normalized = %s, want %s", value, normalized, want)
+		}
+	}
+}
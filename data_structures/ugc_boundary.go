@@ -0,0 +1,113 @@
+package data_structures
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/cmeyer18/weather-common/v6/data_structures/geojson_v2"
+	"github.com/cmeyer18/weather-common/v6/data_structures/ugc"
+)
+
+// UGCBoundary is the area covered by a county, forecast zone or marine zone code.
+type UGCBoundary struct {
+	Code     string               `json:"code"`
+	Name     string               `json:"name"`
+	Geometry *geojson_v2.Geometry `json:"geometry"`
+}
+
+// ReadUGCBoundariesFile reads a GeoJSON FeatureCollection converted from an NWS county, zone or marine zone shapefile.
+func ReadUGCBoundariesFile(path string) ([]UGCBoundary, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var featureCollection geojson_v2.FeatureCollection[map[string]interface{}]
+	err = json.Unmarshal(data, &featureCollection)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", path, err)
+	}
+
+	return UGCBoundariesFromFeatureCollection(&featureCollection)
+}
+
+// UGCBoundariesFromFeatureCollection converts the features of an NWS county, zone or marine zone shapefile into
+// boundaries. The code is built from the STATE and FIPS properties of counties, the STATE and ZONE properties of zones
+// and the ID property of marine zones. Features sharing a code, such as the islands of a zone, are combined into one
+// boundary, and features without a geometry are left out.
+func UGCBoundariesFromFeatureCollection(featureCollection *geojson_v2.FeatureCollection[map[string]interface{}]) ([]UGCBoundary, error) {
+	var boundaries []UGCBoundary
+	indexes := make(map[string]int)
+	for _, feature := range featureCollection.Features {
+		if feature.Geometry.IsEmpty() {
+			continue
+		}
+
+		code, name, err := ugcBoundaryCode(feature.Properties)
+		if err != nil {
+			return nil, err
+		}
+
+		index, ok := indexes[code]
+		if !ok {
+			indexes[code] = len(boundaries)
+			boundaries = append(boundaries, UGCBoundary{Code: code, Name: name, Geometry: feature.Geometry})
+			continue
+		}
+
+		boundaries[index].Geometry = combineBoundaryGeometries(boundaries[index].Geometry, feature.Geometry)
+	}
+
+	return boundaries, nil
+}
+
+func ugcBoundaryCode(properties map[string]interface{}) (string, string, error) {
+	property := func(key string) string {
+		value, _ := properties[key].(string)
+		return strings.TrimSpace(value)
+	}
+
+	var value string
+	if id := property("ID"); id != "" {
+		value = id
+	} else if fips := property("FIPS"); fips != "" && len(fips) >= 3 {
+		value = property("STATE") + string(ugc.Type_County) + fips[len(fips)-3:]
+	} else if zone := property("ZONE"); zone != "" {
+		value = property("STATE") + string(ugc.Type_Zone) + zone
+	} else {
+		return "", "", fmt.Errorf("invalid UGC boundary properties: %v", properties)
+	}
+
+	code, err := ugc.NormalizeCode(value)
+	if err != nil {
+		return "", "", err
+	}
+
+	name := property("NAME")
+	if name == "" {
+		name = property("COUNTYNAME")
+	}
+
+	return code, name, nil
+}
+
+// combineBoundaryGeometries combines the polygons of both geometries into a multi polygon, or into a geometry
+// collection when either is not polygonal.
+func combineBoundaryGeometries(geometry *geojson_v2.Geometry, other *geojson_v2.Geometry) *geojson_v2.Geometry {
+	var polygons []*geojson_v2.Polygon
+	for _, g := range []*geojson_v2.Geometry{geometry, other} {
+		if g.Polygon != nil {
+			polygons = append(polygons, g.Polygon)
+		} else if g.MultiPolygon != nil {
+			polygons = append(polygons, g.MultiPolygon.Polygons...)
+		} else {
+			return &geojson_v2.Geometry{
+				GeometryCollection: &geojson_v2.GeometryCollection{Geometries: []*geojson_v2.Geometry{geometry, other}},
+			}
+		}
+	}
+
+	return &geojson_v2.Geometry{MultiPolygon: &geojson_v2.MultiPolygon{Polygons: polygons}}
+}
@@ -0,0 +1,115 @@
+package data_structures
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/cmeyer18/weather-common/v6/data_structures/geojson_v2"
+)
+
+func mustGeometry(t *testing.T, data string) *geojson_v2.Geometry {
+	t.Helper()
+
+	var geometry geojson_v2.Geometry
+	err := json.Unmarshal([]byte(data), &geometry)
+	if err != nil {
+		t.Fatalf("invalid geometry %s: %v", data, err)
+	}
+	return &geometry
+}
+
+func TestUGCBoundaryCode(t *testing.T) {
+	tests := map[string]struct {
+		properties map[string]interface{}
+		code       string
+		name       string
+	}{
+		"county": {
+			properties: map[string]interface{}{"STATE": "NE", "FIPS": "31055", "COUNTYNAME": "Douglas"},
+			code:       "NEC055",
+			name:       "Douglas",
+		},
+		"zone": {
+			properties: map[string]interface{}{"STATE": "NE", "ZONE": "052", "NAME": "Douglas"},
+			code:       "NEZ052",
+			name:       "Douglas",
+		},
+		"marineZone": {
+			properties: map[string]interface{}{"ID": " lmz643 ", "NAME": "Sheboygan to Port Washington WI"},
+			code:       "LMZ643",
+			name:       "Sheboygan to Port Washington WI",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			code, boundaryName, err := ugcBoundaryCode(test.properties)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if code != test.code || boundaryName != test.name {
+				t.Errorf("got %s %s, want %s %s", code, boundaryName, test.code, test.name)
+			}
+		})
+	}
+}
+
+func TestUGCBoundaryCodeErrors(t *testing.T) {
+	tests := map[string]map[string]interface{}{
+		"missing":      {"NAME": "Douglas"},
+		"shortFIPS":    {"STATE": "NE", "FIPS": "55"},
+		"invalidState": {"STATE": "XX", "ZONE": "052"},
+	}
+
+	for name, properties := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, _, err := ugcBoundaryCode(properties)
+			if err == nil {
+				t.Errorf("err = nil, want an error for %v", properties)
+			}
+		})
+	}
+}
+
+func TestCombineBoundaryGeometries(t *testing.T) {
+	polygon := mustGeometry(t, `{"type":"Polygon","coordinates":[[[0,0],[1,0],[1,1],[0,0]]]}`)
+	multiPolygon := mustGeometry(t, `{"type":"MultiPolygon","coordinates":[[[[2,0],[3,0],[3,1],[2,0]]],[[[4,0],[5,0],[5,1],[4,0]]]]}`)
+	point := mustGeometry(t, `{"type":"Point","coordinates":[6,0]}`)
+
+	combined := combineBoundaryGeometries(polygon, multiPolygon)
+	if combined.MultiPolygon == nil || len(combined.MultiPolygon.Polygons) != 3 {
+		t.Errorf("combined = %+v, want a multi polygon of 3 polygons", combined)
+	}
+
+	combined = combineBoundaryGeometries(polygon, point)
+	if combined.GeometryCollection == nil || len(combined.GeometryCollection.Geometries) != 2 {
+		t.Errorf("combined = %+v, want a geometry collection of both geometries", combined)
+	}
+}
+
+func TestUGCBoundariesFromFeatureCollection(t *testing.T) {
+	data := `{"type":"FeatureCollection","features":[
+		{"type":"Feature","properties":{"STATE":"MI","ZONE":"096","NAME":"Mackinac"},"geometry":{"type":"Polygon","coordinates":[[[0,0],[1,0],[1,1],[0,0]]]}},
+		{"type":"Feature","properties":{"STATE":"MI","ZONE":"095","NAME":"Chippewa"},"geometry":null},
+		{"type":"Feature","properties":{"STATE":"MI","ZONE":"096","NAME":"Mackinac"},"geometry":{"type":"Polygon","coordinates":[[[2,0],[3,0],[3,1],[2,0]]]}}
+	]}`
+
+	var featureCollection geojson_v2.FeatureCollection[map[string]interface{}]
+	err := json.Unmarshal([]byte(data), &featureCollection)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	boundaries, err := UGCBoundariesFromFeatureCollection(&featureCollection)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(boundaries) != 1 || boundaries[0].Code != "MIZ096" || boundaries[0].Name != "Mackinac" {
+		t.Fatalf("boundaries = %+v, want the islands of MIZ096 combined", boundaries)
+	}
+	if boundaries[0].Geometry.MultiPolygon == nil || len(boundaries[0].Geometry.MultiPolygon.Polygons) != 2 {
+		t.Errorf("geometry = %+v, want a multi polygon of 2 polygons", boundaries[0].Geometry)
+	}
+}
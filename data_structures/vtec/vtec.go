@@ -0,0 +1,139 @@
+package vtec
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ProductClass is the first element of a P-VTEC string, telling operational products from test and experimental ones.
+type ProductClass string
+
+const (
+	ProductClass_Operational             ProductClass = "O"
+	ProductClass_Test                    ProductClass = "T"
+	ProductClass_Experimental            ProductClass = "E"
+	ProductClass_ExperimentalOperational ProductClass = "X"
+)
+
+// Action is what a product does to the event it references.
+type Action string
+
+const (
+	Action_New            Action = "NEW"
+	Action_Continue       Action = "CON"
+	Action_Extend         Action = "EXT"
+	Action_ExtendArea     Action = "EXA"
+	Action_ExtendAreaTime Action = "EXB"
+	Action_Upgrade        Action = "UPG"
+	Action_Cancel         Action = "CAN"
+	Action_Expire         Action = "EXP"
+	Action_Correct        Action = "COR"
+	Action_Routine        Action = "ROU"
+)
+
+// timeLayout is the layout of the begin and end times of a P-VTEC string, always in UTC.
+const timeLayout = "060102T1504Z"
+
+// unsetTime is written in place of a begin time once the event has started, or an end time that is not yet known.
+const unsetTime = "000000T0000Z"
+
+var pattern = regexp.MustCompile(`^/?([OTEX])\.(NEW|CON|EXT|EXA|EXB|UPG|CAN|EXP|COR|ROU)\.([A-Z0-9]{4})\.([A-Z0-9]{2})\.([A-Z])\.(\d{4})\.(\d{6}T\d{4}Z)-(\d{6}T\d{4}Z)/?$`)
+
+// VTEC is a Primary Valid Time Event Code, such as /O.NEW.KOAX.TO.W.0042.240516T2100Z-240516T2145Z/, identifying
+// the event a watch, warning or advisory product is about.
+type VTEC struct {
+	ProductClass        ProductClass `json:"productClass"`
+	Action              Action       `json:"action"`
+	Office              string       `json:"office"`
+	Phenomena           string       `json:"phenomena"`
+	Significance        string       `json:"significance"`
+	EventTrackingNumber int          `json:"eventTrackingNumber"`
+	Begin               *time.Time   `json:"begin"`
+	End                 *time.Time   `json:"end"`
+}
+
+// Event identifies a single event across the products that issue, update and end it. Event tracking numbers restart
+// every year, so an event is only unique within the year it was issued.
+type Event struct {
+	Office              string `json:"office"`
+	Phenomena           string `json:"phenomena"`
+	Significance        string `json:"significance"`
+	EventTrackingNumber int    `json:"eventTrackingNumber"`
+}
+
+// Parse parses a P-VTEC string. The enclosing slashes are optional, and unset begin and end times are left nil.
+func Parse(value string) (VTEC, error) {
+	matches := pattern.FindStringSubmatch(strings.TrimSpace(value))
+	if matches == nil {
+		return VTEC{}, fmt.Errorf("invalid VTEC: %s", value)
+	}
+
+	eventTrackingNumber, err := strconv.Atoi(matches[6])
+	if err != nil {
+		return VTEC{}, fmt.Errorf("invalid event tracking number: %w", err)
+	}
+
+	begin, err := parseTime(matches[7])
+	if err != nil {
+		return VTEC{}, fmt.Errorf("invalid begin time: %w", err)
+	}
+
+	end, err := parseTime(matches[8])
+	if err != nil {
+		return VTEC{}, fmt.Errorf("invalid end time: %w", err)
+	}
+
+	return VTEC{
+		ProductClass:        ProductClass(matches[1]),
+		Action:              Action(matches[2]),
+		Office:              matches[3],
+		Phenomena:           matches[4],
+		Significance:        matches[5],
+		EventTrackingNumber: eventTrackingNumber,
+		Begin:               begin,
+		End:                 end,
+	}, nil
+}
+
+func parseTime(value string) (*time.Time, error) {
+	if value == unsetTime {
+		return nil, nil
+	}
+
+	parsed, err := time.Parse(timeLayout, value)
+	if err != nil {
+		return nil, err
+	}
+	return &parsed, nil
+}
+
+func formatTime(value *time.Time) string {
+	if value == nil {
+		return unsetTime
+	}
+	return value.UTC().Format(timeLayout)
+}
+
+// String formats the VTEC as a P-VTEC string enclosed in slashes.
+func (v VTEC) String() string {
+	return fmt.Sprintf("/%s.%s.%s.%s.%s.%04d.%s-%s/", v.ProductClass, v.Action, v.Office, v.Phenomena,
+		v.Significance, v.EventTrackingNumber, formatTime(v.Begin), formatTime(v.End))
+}
+
+// Event returns the event the VTEC references.
+func (v VTEC) Event() Event {
+	return Event{
+		Office:              v.Office,
+		Phenomena:           v.Phenomena,
+		Significance:        v.Significance,
+		EventTrackingNumber: v.EventTrackingNumber,
+	}
+}
+
+// String formats the event as office, phenomena, significance and event tracking number, such as KOAX.TO.W.0042.
+func (e Event) String() string {
+	return fmt.Sprintf("%s.%s.%s.%04d", e.Office, e.Phenomena, e.Significance, e.EventTrackingNumber)
+}
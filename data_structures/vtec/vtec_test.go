@@ -0,0 +1,123 @@
+package vtec
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	code, err := Parse("/O.NEW.KOAX.TO.W.0042.240516T2100Z-240516T2145Z/")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if code.ProductClass != ProductClass_Operational || code.Action != Action_New || code.Office != "KOAX" ||
+		code.Phenomena != "TO" || code.Significance != "W" || code.EventTrackingNumber != 42 {
+		t.Errorf("got %+v", code)
+	}
+
+	begin := time.Date(2024, 5, 16, 21, 0, 0, 0, time.UTC)
+	if code.Begin == nil || !code.Begin.Equal(begin) {
+		t.Errorf("got begin %v, want %v", code.Begin, begin)
+	}
+
+	end := time.Date(2024, 5, 16, 21, 45, 0, 0, time.UTC)
+	if code.End == nil || !code.End.Equal(end) {
+		t.Errorf("got end %v, want %v", code.End, end)
+	}
+}
+
+func TestParseUnsetTimes(t *testing.T) {
+	code, err := Parse(" O.CON.KDMX.WS.A.0003.000000T0000Z-000000T0000Z ")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if code.Begin != nil || code.End != nil {
+		t.Errorf("got begin %v and end %v, want both unset", code.Begin, code.End)
+	}
+	if code.Action != Action_Continue || code.Significance != "A" {
+		t.Errorf("got %+v", code)
+	}
+}
+
+func TestParseInvalid(t *testing.T) {
+	tests := map[string]string{
+		"empty":          "",
+		"unknownClass":   "/Z.NEW.KOAX.TO.W.0042.240516T2100Z-240516T2145Z/",
+		"unknownAction":  "/O.ABC.KOAX.TO.W.0042.240516T2100Z-240516T2145Z/",
+		"shortOffice":    "/O.NEW.OAX.TO.W.0042.240516T2100Z-240516T2145Z/",
+		"shortNumber":    "/O.NEW.KOAX.TO.W.42.240516T2100Z-240516T2145Z/",
+		"invalidBegin":   "/O.NEW.KOAX.TO.W.0042.241316T2100Z-240516T2145Z/",
+		"invalidEnd":     "/O.NEW.KOAX.TO.W.0042.240516T2100Z-240516T2560Z/",
+		"hydrologicVTEC": "/00000.0.ER.000000T0000Z.000000T0000Z.000000T0000Z.OO/",
+	}
+
+	for name, value := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := Parse(value)
+			if err == nil {
+				t.Errorf("expected an error for %s", value)
+			}
+		})
+	}
+}
+
+func TestString(t *testing.T) {
+	tests := []string{
+		"/O.NEW.KOAX.TO.W.0042.240516T2100Z-240516T2145Z/",
+		"/O.CAN.KOAX.SV.W.0107.000000T0000Z-240516T2145Z/",
+		"/E.EXB.KDMX.WS.A.0003.000000T0000Z-000000T0000Z/",
+	}
+
+	for _, value := range tests {
+		t.Run(value, func(t *testing.T) {
+			code, err := Parse(value)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if code.String() != value {
+				t.Errorf("got %s, want %s", code.String(), value)
+			}
+		})
+	}
+}
+
+func TestStringUTC(t *testing.T) {
+	begin := time.Date(2024, 5, 16, 16, 0, 0, 0, time.FixedZone("CDT", -5*60*60))
+	code := VTEC{
+		ProductClass:        ProductClass_Test,
+		Action:              Action_Upgrade,
+		Office:              "KOAX",
+		Phenomena:           "TO",
+		Significance:        "A",
+		EventTrackingNumber: 7,
+		Begin:               &begin,
+	}
+
+	want := "/T.UPG.KOAX.TO.A.0007.240516T2100Z-000000T0000Z/"
+	if code.String() != want {
+		t.Errorf("got %s, want %s", code.String(), want)
+	}
+}
+
+func TestEvent(t *testing.T) {
+	issued, err := Parse("/O.NEW.KOAX.TO.W.0042.240516T2100Z-240516T2145Z/")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cancelled, err := Parse("/O.CAN.KOAX.TO.W.0042.000000T0000Z-240516T2145Z/")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if issued.Event() != cancelled.Event() {
+		t.Errorf("got %v and %v, want the same event", issued.Event(), cancelled.Event())
+	}
+
+	if want := "KOAX.TO.W.0042"; issued.Event().String() != want {
+		t.Errorf("got %s, want %s", issued.Event().String(), want)
+	}
+}
@@ -0,0 +1 @@
+ALTER TABLE location DROP proximityRadius;
@@ -0,0 +1 @@
+ALTER TABLE location ADD proximityRadius FLOAT NOT NULL DEFAULT 0;
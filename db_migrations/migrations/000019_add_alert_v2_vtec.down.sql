@@ -0,0 +1 @@
+DROP TABLE alertV2_VTEC;
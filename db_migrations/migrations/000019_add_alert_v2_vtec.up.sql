@@ -0,0 +1,16 @@
+CREATE TABLE alertV2_VTEC (
+    alertId TEXT,
+    productClass CHAR(1),
+    action CHAR(3),
+    office CHAR(4),
+    phenomena CHAR(2),
+    significance CHAR(1),
+    eventTrackingNumber INT,
+    beginTime TIMESTAMP WITH TIME ZONE,
+    endTime TIMESTAMP WITH TIME ZONE,
+    FOREIGN KEY (alertId) REFERENCES alertV2(id) ON DELETE CASCADE
+);
+
+CREATE INDEX alertV2_VTEC_alertId_idx ON alertV2_VTEC (alertId);
+
+CREATE INDEX alertV2_VTEC_event_idx ON alertV2_VTEC (office, phenomena, significance, eventTrackingNumber);
@@ -0,0 +1,3 @@
+DROP INDEX alertV2_SAMECodes_code_idx;
+
+DROP INDEX alertV2_SAMECodes_alertId_idx;
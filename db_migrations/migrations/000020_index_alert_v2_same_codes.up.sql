@@ -0,0 +1,3 @@
+CREATE INDEX alertV2_SAMECodes_code_idx ON alertV2_SAMECodes (code);
+
+CREATE INDEX alertV2_SAMECodes_alertId_idx ON alertV2_SAMECodes (alertId);
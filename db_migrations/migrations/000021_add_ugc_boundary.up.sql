@@ -0,0 +1,8 @@
+CREATE TABLE ugcBoundary (
+    code VARCHAR(20) PRIMARY KEY,
+    type CHAR(1),
+    name TEXT,
+    geometry geometry
+);
+
+CREATE INDEX ugcBoundary_geometry_idx ON ugcBoundary USING GIST (geometry);
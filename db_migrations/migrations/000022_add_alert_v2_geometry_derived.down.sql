@@ -0,0 +1 @@
+ALTER TABLE alertV2 DROP geometryDerived;
@@ -0,0 +1 @@
+ALTER TABLE alertV2 ADD geometryDerived BOOLEAN NOT NULL DEFAULT FALSE;
@@ -0,0 +1,139 @@
+package export
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/cmeyer18/weather-common/v6/data_structures"
+	"github.com/cmeyer18/weather-common/v6/data_structures/geojson_v2"
+)
+
+// wktColumn is the name of the CSV column holding the geometry as Well-Known Text.
+const wktColumn = "wkt"
+
+// WriteAlertsCSV writes the alerts as CSV with a header row, one row per alert and the geometry as Well-Known Text in
+// the last column.
+func WriteAlertsCSV(w io.Writer, alerts []data_structures.AlertV2) error {
+	return writeCSV(w, alertProduct, alerts)
+}
+
+// ReadAlertsCSV reads alerts from CSV written by WriteAlertsCSV.
+func ReadAlertsCSV(r io.Reader) ([]data_structures.AlertV2, error) {
+	return readCSV(r, alertProduct)
+}
+
+// WriteConvectiveOutlooksCSV writes the outlooks as CSV with a header row, one row per outlook and the geometry as
+// Well-Known Text in the last column.
+func WriteConvectiveOutlooksCSV(w io.Writer, outlooks []data_structures.ConvectiveOutlookV2) error {
+	return writeCSV(w, convectiveOutlookProduct, outlooks)
+}
+
+// ReadConvectiveOutlooksCSV reads outlooks from CSV written by WriteConvectiveOutlooksCSV.
+func ReadConvectiveOutlooksCSV(r io.Reader) ([]data_structures.ConvectiveOutlookV2, error) {
+	return readCSV(r, convectiveOutlookProduct)
+}
+
+// WriteMesoscaleDiscussionsCSV writes the mesoscale discussions as CSV with a header row, one row per mesoscale
+// discussion and the geometry as Well-Known Text in the last column.
+func WriteMesoscaleDiscussionsCSV(w io.Writer, mds []data_structures.MesoscaleDiscussionV2) error {
+	return writeCSV(w, mesoscaleDiscussionProduct, mds)
+}
+
+// ReadMesoscaleDiscussionsCSV reads mesoscale discussions from CSV written by WriteMesoscaleDiscussionsCSV.
+func ReadMesoscaleDiscussionsCSV(r io.Reader) ([]data_structures.MesoscaleDiscussionV2, error) {
+	return readCSV(r, mesoscaleDiscussionProduct)
+}
+
+// WriteLocationsCSV writes the locations as CSV with a header row, one row per location and the position as Well-Known
+// Text in the last column.
+func WriteLocationsCSV(w io.Writer, locations []data_structures.Location) error {
+	return writeCSV(w, locationProduct, locations)
+}
+
+// ReadLocationsCSV reads locations from CSV written by WriteLocationsCSV.
+func ReadLocationsCSV(r io.Reader) ([]data_structures.Location, error) {
+	return readCSV(r, locationProduct)
+}
+
+func writeCSV[T any](w io.Writer, p product[T], items []T) error {
+	writer := csv.NewWriter(w)
+
+	err := writer.Write(append(p.header(), wktColumn))
+	if err != nil {
+		return err
+	}
+
+	for i := range items {
+		item := &items[i]
+
+		values, err := p.values(item)
+		if err != nil {
+			return err
+		}
+
+		wkt := ""
+		if geometry := p.geometry(item); !geometry.IsEmpty() {
+			wkt, err = geometry.MarshalWKT()
+			if err != nil {
+				return err
+			}
+		}
+
+		err = writer.Write(append(values, wkt))
+		if err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+func readCSV[T any](r io.Reader, p product[T]) ([]T, error) {
+	reader := csv.NewReader(r)
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, errors.New("missing CSV header")
+	} else if err != nil {
+		return nil, err
+	}
+
+	var items []T
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+
+		attributes := make(map[string]string)
+		for i, name := range header {
+			attributes[name] = record[i]
+		}
+
+		item, err := p.parse(attributes)
+		if err != nil {
+			return nil, err
+		}
+
+		if wkt := attributes[wktColumn]; wkt != "" {
+			geometry := &geojson_v2.Geometry{}
+			err = geometry.UnmarshalWKT(wkt)
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s: %w", wktColumn, err)
+			}
+			err = p.setGeometry(&item, geometry)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		items = append(items, item)
+	}
+
+	return items, nil
+}
@@ -0,0 +1,54 @@
+package export
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestAlertsCSVRoundTrip(t *testing.T) {
+	alerts := testAlerts(t)
+
+	var buffer bytes.Buffer
+	err := WriteAlertsCSV(&buffer, alerts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := ReadAlertsCSV(&buffer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertRoundTrip(t, alerts, got)
+}
+
+func TestConvectiveOutlooksCSVRoundTrip(t *testing.T) {
+	outlooks := testConvectiveOutlooks(t)
+
+	var buffer bytes.Buffer
+	err := WriteConvectiveOutlooksCSV(&buffer, outlooks)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := ReadConvectiveOutlooksCSV(&buffer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertRoundTrip(t, outlooks, got)
+}
+
+func TestLocationsCSVRoundTrip(t *testing.T) {
+	locations := testLocations()
+
+	var buffer bytes.Buffer
+	err := WriteLocationsCSV(&buffer, locations)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := ReadLocationsCSV(&buffer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertRoundTrip(t, locations, got)
+}
@@ -0,0 +1,308 @@
+package export
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+
+	"github.com/cmeyer18/weather-common/v6/data_structures"
+	"github.com/cmeyer18/weather-common/v6/data_structures/geojson_v2"
+)
+
+// gpxCreator names the program that wrote a GPX file.
+const gpxCreator = "weather-common"
+
+type gpxSegmentKind string
+
+const (
+	gpxSegmentKind_Line  gpxSegmentKind = "line"
+	gpxSegmentKind_Shell gpxSegmentKind = "shell"
+	gpxSegmentKind_Hole  gpxSegmentKind = "hole"
+)
+
+type gpxFile struct {
+	XMLName   xml.Name      `xml:"http://www.topografix.com/GPX/1/1 gpx"`
+	Version   string        `xml:"version,attr"`
+	Creator   string        `xml:"creator,attr"`
+	Waypoints []gpxWaypoint `xml:"wpt"`
+	Tracks    []gpxTrack    `xml:"trk"`
+}
+
+type gpxWaypoint struct {
+	Latitude    float64        `xml:"lat,attr"`
+	Longitude   float64        `xml:"lon,attr"`
+	Elevation   *float64       `xml:"ele,omitempty"`
+	Name        string         `xml:"name,omitempty"`
+	Description string         `xml:"desc,omitempty"`
+	Extensions  *gpxExtensions `xml:"extensions,omitempty"`
+}
+
+type gpxTrack struct {
+	Name        string            `xml:"name,omitempty"`
+	Description string            `xml:"desc,omitempty"`
+	Extensions  *gpxExtensions    `xml:"extensions,omitempty"`
+	Segments    []gpxTrackSegment `xml:"trkseg"`
+}
+
+type gpxTrackSegment struct {
+	Points     []gpxWaypoint  `xml:"trkpt"`
+	Extensions *gpxExtensions `xml:"extensions,omitempty"`
+}
+
+// gpxExtensions holds the fields of a product on its track, the track a waypoint belongs to, and the kind of a segment.
+type gpxExtensions struct {
+	Data  []gpxData      `xml:"https://github.com/cmeyer18/weather-common/gpx data"`
+	Track *int           `xml:"https://github.com/cmeyer18/weather-common/gpx track,omitempty"`
+	Kind  gpxSegmentKind `xml:"https://github.com/cmeyer18/weather-common/gpx kind,omitempty"`
+}
+
+type gpxData struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:",chardata"`
+}
+
+// WriteAlertsGPX writes the alerts as GPX for GPS receivers, with one track per alert and one segment per line or
+// ring. Points are written as waypoints.
+func WriteAlertsGPX(w io.Writer, alerts []data_structures.AlertV2) error {
+	return writeGPX(w, alertProduct, alerts)
+}
+
+// ReadAlertsGPX reads alerts from GPX written by WriteAlertsGPX.
+func ReadAlertsGPX(r io.Reader) ([]data_structures.AlertV2, error) {
+	return readGPX(r, alertProduct)
+}
+
+// WriteConvectiveOutlooksGPX writes the outlooks as GPX, with one track per outlook.
+func WriteConvectiveOutlooksGPX(w io.Writer, outlooks []data_structures.ConvectiveOutlookV2) error {
+	return writeGPX(w, convectiveOutlookProduct, outlooks)
+}
+
+// ReadConvectiveOutlooksGPX reads outlooks from GPX written by WriteConvectiveOutlooksGPX.
+func ReadConvectiveOutlooksGPX(r io.Reader) ([]data_structures.ConvectiveOutlookV2, error) {
+	return readGPX(r, convectiveOutlookProduct)
+}
+
+// WriteMesoscaleDiscussionsGPX writes the mesoscale discussions as GPX, with one track per mesoscale discussion.
+func WriteMesoscaleDiscussionsGPX(w io.Writer, mds []data_structures.MesoscaleDiscussionV2) error {
+	return writeGPX(w, mesoscaleDiscussionProduct, mds)
+}
+
+// ReadMesoscaleDiscussionsGPX reads mesoscale discussions from GPX written by WriteMesoscaleDiscussionsGPX.
+func ReadMesoscaleDiscussionsGPX(r io.Reader) ([]data_structures.MesoscaleDiscussionV2, error) {
+	return readGPX(r, mesoscaleDiscussionProduct)
+}
+
+// WriteLocationsGPX writes the locations as GPX waypoints.
+func WriteLocationsGPX(w io.Writer, locations []data_structures.Location) error {
+	return writeGPX(w, locationProduct, locations)
+}
+
+// ReadLocationsGPX reads locations from GPX written by WriteLocationsGPX.
+func ReadLocationsGPX(r io.Reader) ([]data_structures.Location, error) {
+	return readGPX(r, locationProduct)
+}
+
+func writeGPX[T any](w io.Writer, p product[T], items []T) error {
+	file := gpxFile{Version: "1.1", Creator: gpxCreator}
+	for i := range items {
+		item := &items[i]
+
+		values, err := p.values(item)
+		if err != nil {
+			return err
+		}
+
+		track := gpxTrack{Name: p.name(item), Description: p.description(item), Extensions: &gpxExtensions{}}
+		for j, column := range p.columns {
+			track.Extensions.Data = append(track.Extensions.Data, gpxData{Name: column.name, Value: values[j]})
+		}
+
+		addGPXGeometry(&file, &track, len(file.Tracks), p.geometry(item))
+		file.Tracks = append(file.Tracks, track)
+	}
+
+	_, err := io.WriteString(w, xml.Header)
+	if err != nil {
+		return err
+	}
+
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	return encoder.Encode(file)
+}
+
+// readGPX reads one item per track. Files not written by writeGPX are read with closed segments as polygons and the
+// other segments as lines.
+func readGPX[T any](r io.Reader, p product[T]) ([]T, error) {
+	var file gpxFile
+	err := xml.NewDecoder(r).Decode(&file)
+	if err != nil {
+		return nil, err
+	}
+
+	waypoints := make(map[int][]*geojson_v2.Point)
+	for _, waypoint := range file.Waypoints {
+		if waypoint.Extensions == nil || waypoint.Extensions.Track == nil {
+			continue
+		}
+
+		track := *waypoint.Extensions.Track
+		waypoints[track] = append(waypoints[track], waypoint.point())
+	}
+
+	items := make([]T, 0, len(file.Tracks))
+	for i, track := range file.Tracks {
+		attributes := make(map[string]string)
+		if track.Extensions != nil {
+			for _, data := range track.Extensions.Data {
+				attributes[data.Name] = data.Value
+			}
+		}
+
+		item, err := p.parse(attributes)
+		if err != nil {
+			return nil, err
+		}
+
+		geometry, err := decodeGPXGeometry(waypoints[i], track.Segments)
+		if err != nil {
+			return nil, err
+		}
+
+		err = p.setGeometry(&item, geometry)
+		if err != nil {
+			return nil, err
+		}
+
+		items = append(items, item)
+	}
+
+	return items, nil
+}
+
+func addGPXGeometry(file *gpxFile, track *gpxTrack, index int, g *geojson_v2.Geometry) {
+	if g == nil {
+		return
+	}
+
+	if g.Point != nil {
+		addGPXWaypoint(file, track, index, g.Point)
+	} else if g.MultiPoint != nil {
+		for _, point := range g.MultiPoint.Points {
+			addGPXWaypoint(file, track, index, point)
+		}
+	} else if g.LineString != nil {
+		addGPXSegment(track, g.LineString.Points, gpxSegmentKind_Line)
+	} else if g.MultiLineString != nil {
+		for _, lineString := range g.MultiLineString.LineStrings {
+			addGPXSegment(track, lineString.Points, gpxSegmentKind_Line)
+		}
+	} else if g.Polygon != nil {
+		addGPXPolygon(track, g.Polygon)
+	} else if g.MultiPolygon != nil {
+		for _, polygon := range g.MultiPolygon.Polygons {
+			addGPXPolygon(track, polygon)
+		}
+	} else if g.GeometryCollection != nil {
+		for _, geometry := range g.GeometryCollection.Geometries {
+			addGPXGeometry(file, track, index, geometry)
+		}
+	}
+}
+
+func addGPXWaypoint(file *gpxFile, track *gpxTrack, index int, point *geojson_v2.Point) {
+	waypoint := newGPXWaypoint(point)
+	waypoint.Name = track.Name
+	waypoint.Description = track.Description
+	waypoint.Extensions = &gpxExtensions{Track: &index}
+	file.Waypoints = append(file.Waypoints, waypoint)
+}
+
+func addGPXPolygon(track *gpxTrack, polygon *geojson_v2.Polygon) {
+	if polygon.OuterPath != nil {
+		addGPXSegment(track, polygon.OuterPath.Points, gpxSegmentKind_Shell)
+	}
+	for _, innerPath := range polygon.InnerPaths {
+		addGPXSegment(track, innerPath.Points, gpxSegmentKind_Hole)
+	}
+}
+
+func addGPXSegment(track *gpxTrack, points []*geojson_v2.Point, kind gpxSegmentKind) {
+	if len(points) == 0 {
+		return
+	}
+
+	segment := gpxTrackSegment{Extensions: &gpxExtensions{Kind: kind}}
+	for _, point := range points {
+		segment.Points = append(segment.Points, newGPXWaypoint(point))
+	}
+	track.Segments = append(track.Segments, segment)
+}
+
+func newGPXWaypoint(point *geojson_v2.Point) gpxWaypoint {
+	return gpxWaypoint{Latitude: point.Latitude, Longitude: point.Longitude, Elevation: point.Altitude}
+}
+
+func (w gpxWaypoint) point() *geojson_v2.Point {
+	return &geojson_v2.Point{Latitude: w.Latitude, Longitude: w.Longitude, Altitude: w.Elevation}
+}
+
+// decodeGPXGeometry rebuilds the geometry of a track from its waypoints and segments, or returns nil when it has none.
+// A track holding only one type becomes that type, or its multi geometry when there are several, and a mixed track
+// becomes a GeometryCollection.
+func decodeGPXGeometry(waypoints []*geojson_v2.Point, segments []gpxTrackSegment) (*geojson_v2.Geometry, error) {
+	var lines []*geojson_v2.LineString
+	var polygons []*geojson_v2.Polygon
+	for _, segment := range segments {
+		points := make([]*geojson_v2.Point, len(segment.Points))
+		for i, point := range segment.Points {
+			points[i] = point.point()
+		}
+
+		kind := gpxSegmentKind_Line
+		if segment.Extensions != nil && segment.Extensions.Kind != "" {
+			kind = segment.Extensions.Kind
+		} else if len(points) >= 4 && points[0].Equal(*points[len(points)-1]) {
+			kind = gpxSegmentKind_Shell
+		}
+
+		switch kind {
+		case gpxSegmentKind_Line:
+			lines = append(lines, &geojson_v2.LineString{Points: points})
+		case gpxSegmentKind_Shell:
+			polygons = append(polygons, &geojson_v2.Polygon{OuterPath: &geojson_v2.MultiPoint{Points: points}})
+		case gpxSegmentKind_Hole:
+			if len(polygons) == 0 {
+				return nil, fmt.Errorf("invalid GPX segment: hole without shell")
+			}
+			polygon := polygons[len(polygons)-1]
+			polygon.InnerPaths = append(polygon.InnerPaths, &geojson_v2.MultiPoint{Points: points})
+		default:
+			return nil, fmt.Errorf("unsupported GPX segment kind: %s", kind)
+		}
+	}
+
+	var geometries []*geojson_v2.Geometry
+	if len(waypoints) == 1 {
+		geometries = append(geometries, &geojson_v2.Geometry{Point: waypoints[0]})
+	} else if len(waypoints) > 1 {
+		geometries = append(geometries, &geojson_v2.Geometry{MultiPoint: &geojson_v2.MultiPoint{Points: waypoints}})
+	}
+	if len(lines) == 1 {
+		geometries = append(geometries, &geojson_v2.Geometry{LineString: lines[0]})
+	} else if len(lines) > 1 {
+		geometries = append(geometries, &geojson_v2.Geometry{MultiLineString: &geojson_v2.MultiLineString{LineStrings: lines}})
+	}
+	if len(polygons) == 1 {
+		geometries = append(geometries, &geojson_v2.Geometry{Polygon: polygons[0]})
+	} else if len(polygons) > 1 {
+		geometries = append(geometries, &geojson_v2.Geometry{MultiPolygon: &geojson_v2.MultiPolygon{Polygons: polygons}})
+	}
+
+	if len(geometries) == 0 {
+		return nil, nil
+	} else if len(geometries) == 1 {
+		return geometries[0], nil
+	}
+	return &geojson_v2.Geometry{GeometryCollection: &geojson_v2.GeometryCollection{Geometries: geometries}}, nil
+}
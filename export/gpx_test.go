@@ -0,0 +1,89 @@
+package export
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestAlertsGPXRoundTrip(t *testing.T) {
+	alerts := testAlerts(t)
+
+	var buffer bytes.Buffer
+	err := WriteAlertsGPX(&buffer, alerts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := ReadAlertsGPX(&buffer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertRoundTrip(t, alerts, got)
+}
+
+func TestConvectiveOutlooksGPXRoundTrip(t *testing.T) {
+	outlooks := testConvectiveOutlooks(t)
+
+	var buffer bytes.Buffer
+	err := WriteConvectiveOutlooksGPX(&buffer, outlooks)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := ReadConvectiveOutlooksGPX(&buffer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertRoundTrip(t, outlooks, got)
+}
+
+func TestLocationsGPXRoundTrip(t *testing.T) {
+	locations := testLocations()
+
+	var buffer bytes.Buffer
+	err := WriteLocationsGPX(&buffer, locations)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := ReadLocationsGPX(&buffer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertRoundTrip(t, locations, got)
+}
+
+func TestReadGPXWithoutExtensions(t *testing.T) {
+	data := `<?xml version="1.0" encoding="UTF-8"?>
+<gpx xmlns="http://www.topografix.com/GPX/1/1" version="1.1" creator="other">
+  <trk>
+    <name>Outline</name>
+    <trkseg>
+      <trkpt lat="35" lon="-100"></trkpt>
+      <trkpt lat="35" lon="-98"></trkpt>
+      <trkpt lat="37" lon="-98"></trkpt>
+      <trkpt lat="35" lon="-100"></trkpt>
+    </trkseg>
+    <trkseg>
+      <trkpt lat="36" lon="-97"></trkpt>
+      <trkpt lat="36.5" lon="-96"></trkpt>
+    </trkseg>
+  </trk>
+</gpx>`
+
+	outlooks, err := ReadConvectiveOutlooksGPX(bytes.NewBufferString(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(outlooks) != 1 {
+		t.Fatalf("outlooks = %d, want 1", len(outlooks))
+	}
+
+	collection := outlooks[0].Geometry.GeometryCollection
+	if collection == nil || len(collection.Geometries) != 2 {
+		t.Fatalf("geometry = %+v, want a line and a polygon", outlooks[0].Geometry)
+	}
+	if collection.Geometries[0].LineString == nil || collection.Geometries[1].Polygon == nil {
+		t.Errorf("geometry = %+v, want a line and a polygon", collection.Geometries)
+	}
+}
@@ -0,0 +1,469 @@
+package export
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/cmeyer18/weather-common/v6/data_structures"
+	"github.com/cmeyer18/weather-common/v6/data_structures/geojson_v2"
+)
+
+const (
+	// kmlStrokeAlpha is the opacity of outlines, as a KML hex alpha channel.
+	kmlStrokeAlpha = "ff"
+	// kmlFillAlpha is the opacity of polygon fills, as a KML hex alpha channel, leaving the map visible underneath.
+	kmlFillAlpha = "80"
+	// kmlLineWidth is the width of outlines in pixels.
+	kmlLineWidth = 2
+)
+
+type kmlFile struct {
+	XMLName  xml.Name    `xml:"http://www.opengis.net/kml/2.2 kml"`
+	Document kmlDocument `xml:"Document"`
+}
+
+type kmlDocument struct {
+	Name       string         `xml:"name,omitempty"`
+	Styles     []kmlStyle     `xml:"Style"`
+	Placemarks []kmlPlacemark `xml:"Placemark"`
+}
+
+type kmlStyle struct {
+	ID        string        `xml:"id,attr"`
+	LineStyle *kmlLineStyle `xml:"LineStyle,omitempty"`
+	PolyStyle *kmlPolyStyle `xml:"PolyStyle,omitempty"`
+}
+
+type kmlLineStyle struct {
+	Color string  `xml:"color"`
+	Width float64 `xml:"width"`
+}
+
+type kmlPolyStyle struct {
+	Color string `xml:"color"`
+}
+
+type kmlPlacemark struct {
+	Name         string           `xml:"name,omitempty"`
+	Description  string           `xml:"description,omitempty"`
+	StyleURL     string           `xml:"styleUrl,omitempty"`
+	ExtendedData *kmlExtendedData `xml:"ExtendedData,omitempty"`
+	kmlGeometry
+}
+
+type kmlExtendedData struct {
+	Data []kmlData `xml:"Data"`
+}
+
+type kmlData struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value"`
+}
+
+type kmlGeometry struct {
+	Point         *kmlPoint         `xml:"Point,omitempty"`
+	LineString    *kmlLineString    `xml:"LineString,omitempty"`
+	Polygon       *kmlPolygon       `xml:"Polygon,omitempty"`
+	MultiGeometry *kmlMultiGeometry `xml:"MultiGeometry,omitempty"`
+}
+
+type kmlMultiGeometry struct {
+	Points          []kmlPoint         `xml:"Point"`
+	LineStrings     []kmlLineString    `xml:"LineString"`
+	Polygons        []kmlPolygon       `xml:"Polygon"`
+	MultiGeometries []kmlMultiGeometry `xml:"MultiGeometry"`
+}
+
+type kmlPoint struct {
+	Coordinates string `xml:"coordinates"`
+}
+
+type kmlLineString struct {
+	Coordinates string `xml:"coordinates"`
+}
+
+type kmlPolygon struct {
+	OuterBoundary kmlBoundary   `xml:"outerBoundaryIs"`
+	InnerBoundary []kmlBoundary `xml:"innerBoundaryIs"`
+}
+
+type kmlBoundary struct {
+	LinearRing kmlLinearRing `xml:"LinearRing"`
+}
+
+type kmlLinearRing struct {
+	Coordinates string `xml:"coordinates"`
+}
+
+// WriteAlertsKML writes the alerts as a KML document with one placemark per alert.
+func WriteAlertsKML(w io.Writer, name string, alerts []data_structures.AlertV2) error {
+	return writeKML(w, name, alertProduct, alerts)
+}
+
+// ReadAlertsKML reads alerts from a KML document written by WriteAlertsKML.
+func ReadAlertsKML(r io.Reader) ([]data_structures.AlertV2, error) {
+	return readKML(r, alertProduct)
+}
+
+// WriteConvectiveOutlooksKML writes the outlooks as a KML document with one placemark per outlook, outlined and
+// filled with the stroke and fill colors of the outlook.
+func WriteConvectiveOutlooksKML(w io.Writer, name string, outlooks []data_structures.ConvectiveOutlookV2) error {
+	return writeKML(w, name, convectiveOutlookProduct, outlooks)
+}
+
+// ReadConvectiveOutlooksKML reads outlooks from a KML document written by WriteConvectiveOutlooksKML.
+func ReadConvectiveOutlooksKML(r io.Reader) ([]data_structures.ConvectiveOutlookV2, error) {
+	return readKML(r, convectiveOutlookProduct)
+}
+
+// WriteMesoscaleDiscussionsKML writes the mesoscale discussions as a KML document with one placemark per mesoscale
+// discussion.
+func WriteMesoscaleDiscussionsKML(w io.Writer, name string, mds []data_structures.MesoscaleDiscussionV2) error {
+	return writeKML(w, name, mesoscaleDiscussionProduct, mds)
+}
+
+// ReadMesoscaleDiscussionsKML reads mesoscale discussions from a KML document written by WriteMesoscaleDiscussionsKML.
+func ReadMesoscaleDiscussionsKML(r io.Reader) ([]data_structures.MesoscaleDiscussionV2, error) {
+	return readKML(r, mesoscaleDiscussionProduct)
+}
+
+// WriteLocationsKML writes the locations as a KML document with one placemark per location.
+func WriteLocationsKML(w io.Writer, name string, locations []data_structures.Location) error {
+	return writeKML(w, name, locationProduct, locations)
+}
+
+// ReadLocationsKML reads locations from a KML document written by WriteLocationsKML.
+func ReadLocationsKML(r io.Reader) ([]data_structures.Location, error) {
+	return readKML(r, locationProduct)
+}
+
+func writeKML[T any](w io.Writer, name string, p product[T], items []T) error {
+	document := kmlDocument{Name: name}
+	styles := make(map[string]string)
+
+	for i := range items {
+		item := &items[i]
+
+		values, err := p.values(item)
+		if err != nil {
+			return err
+		}
+
+		placemark := kmlPlacemark{
+			Name:         p.name(item),
+			Description:  p.description(item),
+			ExtendedData: &kmlExtendedData{},
+		}
+		for j, column := range p.columns {
+			placemark.ExtendedData.Data = append(placemark.ExtendedData.Data, kmlData{Name: column.name, Value: values[j]})
+		}
+
+		if geometry := p.geometry(item); !geometry.IsEmpty() {
+			placemark.kmlGeometry, err = encodeKMLGeometry(geometry)
+			if err != nil {
+				return err
+			}
+		}
+
+		if p.colors != nil {
+			stroke, fill := p.colors(item)
+			key := stroke + fill
+			id, ok := styles[key]
+			if !ok {
+				id = fmt.Sprintf("style%d", len(styles))
+				styles[key] = id
+				document.Styles = append(document.Styles, newKMLStyle(id, stroke, fill))
+			}
+			placemark.StyleURL = "#" + id
+		}
+
+		document.Placemarks = append(document.Placemarks, placemark)
+	}
+
+	_, err := io.WriteString(w, xml.Header)
+	if err != nil {
+		return err
+	}
+
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	return encoder.Encode(kmlFile{Document: document})
+}
+
+func readKML[T any](r io.Reader, p product[T]) ([]T, error) {
+	var file kmlFile
+	err := xml.NewDecoder(r).Decode(&file)
+	if err != nil {
+		return nil, err
+	}
+
+	var items []T
+	for _, placemark := range file.Document.Placemarks {
+		attributes := make(map[string]string)
+		if placemark.ExtendedData != nil {
+			for _, data := range placemark.ExtendedData.Data {
+				attributes[data.Name] = data.Value
+			}
+		}
+
+		item, err := p.parse(attributes)
+		if err != nil {
+			return nil, err
+		}
+
+		geometry, err := decodeKMLGeometry(placemark.kmlGeometry)
+		if err != nil {
+			return nil, err
+		}
+		err = p.setGeometry(&item, geometry)
+		if err != nil {
+			return nil, err
+		}
+
+		items = append(items, item)
+	}
+
+	return items, nil
+}
+
+// newKMLStyle returns a style drawing outlines in the stroke color and filling polygons with the fill color, both given
+// as "#RRGGBB". Colors that cannot be converted are left to the viewer's defaults.
+func newKMLStyle(id, stroke, fill string) kmlStyle {
+	style := kmlStyle{ID: id}
+	if color, ok := kmlColor(stroke, kmlStrokeAlpha); ok {
+		style.LineStyle = &kmlLineStyle{Color: color, Width: kmlLineWidth}
+	}
+	if color, ok := kmlColor(fill, kmlFillAlpha); ok {
+		style.PolyStyle = &kmlPolyStyle{Color: color}
+	}
+	return style
+}
+
+// kmlColor converts a "#RRGGBB" color into the "aabbggrr" order used by KML.
+func kmlColor(color string, alpha string) (string, bool) {
+	color = strings.TrimPrefix(color, "#")
+	if len(color) != 6 {
+		return "", false
+	}
+
+	_, err := strconv.ParseUint(color, 16, 32)
+	if err != nil {
+		return "", false
+	}
+
+	return strings.ToLower(alpha + color[4:6] + color[2:4] + color[0:2]), true
+}
+
+func encodeKMLGeometry(g *geojson_v2.Geometry) (kmlGeometry, error) {
+	var geometry kmlGeometry
+	if g.Point != nil {
+		geometry.Point = &kmlPoint{Coordinates: encodeKMLCoordinates([]*geojson_v2.Point{g.Point})}
+	} else if g.LineString != nil {
+		geometry.LineString = &kmlLineString{Coordinates: encodeKMLCoordinates(g.LineString.Points)}
+	} else if g.Polygon != nil {
+		geometry.Polygon = encodeKMLPolygon(g.Polygon)
+	} else if g.MultiPoint != nil || g.MultiLineString != nil || g.MultiPolygon != nil || g.GeometryCollection != nil {
+		multiGeometry, err := encodeKMLMultiGeometry(g)
+		if err != nil {
+			return geometry, err
+		}
+		geometry.MultiGeometry = multiGeometry
+	} else {
+		return geometry, errors.New("cannot encode empty geometry as KML")
+	}
+	return geometry, nil
+}
+
+func encodeKMLMultiGeometry(g *geojson_v2.Geometry) (*kmlMultiGeometry, error) {
+	multiGeometry := &kmlMultiGeometry{}
+	if g.MultiPoint != nil {
+		for _, point := range g.MultiPoint.Points {
+			multiGeometry.Points = append(multiGeometry.Points, kmlPoint{Coordinates: encodeKMLCoordinates([]*geojson_v2.Point{point})})
+		}
+	} else if g.MultiLineString != nil {
+		for _, lineString := range g.MultiLineString.LineStrings {
+			multiGeometry.LineStrings = append(multiGeometry.LineStrings, kmlLineString{Coordinates: encodeKMLCoordinates(lineString.Points)})
+		}
+	} else if g.MultiPolygon != nil {
+		for _, polygon := range g.MultiPolygon.Polygons {
+			multiGeometry.Polygons = append(multiGeometry.Polygons, *encodeKMLPolygon(polygon))
+		}
+	} else if g.GeometryCollection != nil {
+		for _, geometry := range g.GeometryCollection.Geometries {
+			encoded, err := encodeKMLGeometry(geometry)
+			if err != nil {
+				return nil, err
+			}
+
+			if encoded.Point != nil {
+				multiGeometry.Points = append(multiGeometry.Points, *encoded.Point)
+			} else if encoded.LineString != nil {
+				multiGeometry.LineStrings = append(multiGeometry.LineStrings, *encoded.LineString)
+			} else if encoded.Polygon != nil {
+				multiGeometry.Polygons = append(multiGeometry.Polygons, *encoded.Polygon)
+			} else if encoded.MultiGeometry != nil {
+				multiGeometry.MultiGeometries = append(multiGeometry.MultiGeometries, *encoded.MultiGeometry)
+			}
+		}
+	}
+	return multiGeometry, nil
+}
+
+func encodeKMLPolygon(polygon *geojson_v2.Polygon) *kmlPolygon {
+	encoded := &kmlPolygon{}
+	if polygon.OuterPath != nil {
+		encoded.OuterBoundary.LinearRing.Coordinates = encodeKMLCoordinates(polygon.OuterPath.Points)
+	}
+	for _, innerPath := range polygon.InnerPaths {
+		encoded.InnerBoundary = append(encoded.InnerBoundary, kmlBoundary{
+			LinearRing: kmlLinearRing{Coordinates: encodeKMLCoordinates(innerPath.Points)},
+		})
+	}
+	return encoded
+}
+
+// encodeKMLCoordinates writes the positions as space separated longitude,latitude[,altitude] tuples.
+func encodeKMLCoordinates(points []*geojson_v2.Point) string {
+	tuples := make([]string, len(points))
+	for i, point := range points {
+		tuple := strconv.FormatFloat(point.Longitude, 'f', -1, 64) + "," + strconv.FormatFloat(point.Latitude, 'f', -1, 64)
+		if point.Altitude != nil {
+			tuple += "," + strconv.FormatFloat(*point.Altitude, 'f', -1, 64)
+		}
+		tuples[i] = tuple
+	}
+	return strings.Join(tuples, " ")
+}
+
+// decodeKMLGeometry converts a KML geometry into GeoJSON, or returns nil when the placemark has none. A MultiGeometry
+// holding only one type becomes the matching multi geometry, and a mixed one becomes a GeometryCollection.
+func decodeKMLGeometry(geometry kmlGeometry) (*geojson_v2.Geometry, error) {
+	if geometry.Point != nil {
+		points, err := decodeKMLCoordinates(geometry.Point.Coordinates)
+		if err != nil {
+			return nil, err
+		} else if len(points) != 1 {
+			return nil, fmt.Errorf("invalid KML point: %s", geometry.Point.Coordinates)
+		}
+		return &geojson_v2.Geometry{Point: points[0]}, nil
+	} else if geometry.LineString != nil {
+		points, err := decodeKMLCoordinates(geometry.LineString.Coordinates)
+		if err != nil {
+			return nil, err
+		}
+		return &geojson_v2.Geometry{LineString: &geojson_v2.LineString{Points: points}}, nil
+	} else if geometry.Polygon != nil {
+		polygon, err := decodeKMLPolygon(*geometry.Polygon)
+		if err != nil {
+			return nil, err
+		}
+		return &geojson_v2.Geometry{Polygon: polygon}, nil
+	} else if geometry.MultiGeometry != nil {
+		return decodeKMLMultiGeometry(*geometry.MultiGeometry)
+	}
+	return nil, nil
+}
+
+func decodeKMLMultiGeometry(multiGeometry kmlMultiGeometry) (*geojson_v2.Geometry, error) {
+	var geometries []*geojson_v2.Geometry
+	for _, point := range multiGeometry.Points {
+		geometry, err := decodeKMLGeometry(kmlGeometry{Point: &point})
+		if err != nil {
+			return nil, err
+		}
+		geometries = append(geometries, geometry)
+	}
+	for _, lineString := range multiGeometry.LineStrings {
+		geometry, err := decodeKMLGeometry(kmlGeometry{LineString: &lineString})
+		if err != nil {
+			return nil, err
+		}
+		geometries = append(geometries, geometry)
+	}
+	for _, polygon := range multiGeometry.Polygons {
+		geometry, err := decodeKMLGeometry(kmlGeometry{Polygon: &polygon})
+		if err != nil {
+			return nil, err
+		}
+		geometries = append(geometries, geometry)
+	}
+	for _, nested := range multiGeometry.MultiGeometries {
+		geometry, err := decodeKMLMultiGeometry(nested)
+		if err != nil {
+			return nil, err
+		}
+		geometries = append(geometries, geometry)
+	}
+
+	if len(multiGeometry.MultiGeometries) == 0 {
+		if len(multiGeometry.Points) == len(geometries) {
+			multiPoint := &geojson_v2.MultiPoint{}
+			for _, geometry := range geometries {
+				multiPoint.Points = append(multiPoint.Points, geometry.Point)
+			}
+			return &geojson_v2.Geometry{MultiPoint: multiPoint}, nil
+		} else if len(multiGeometry.LineStrings) == len(geometries) {
+			multiLineString := &geojson_v2.MultiLineString{}
+			for _, geometry := range geometries {
+				multiLineString.LineStrings = append(multiLineString.LineStrings, geometry.LineString)
+			}
+			return &geojson_v2.Geometry{MultiLineString: multiLineString}, nil
+		} else if len(multiGeometry.Polygons) == len(geometries) {
+			multiPolygon := &geojson_v2.MultiPolygon{}
+			for _, geometry := range geometries {
+				multiPolygon.Polygons = append(multiPolygon.Polygons, geometry.Polygon)
+			}
+			return &geojson_v2.Geometry{MultiPolygon: multiPolygon}, nil
+		}
+	}
+
+	return &geojson_v2.Geometry{GeometryCollection: &geojson_v2.GeometryCollection{Geometries: geometries}}, nil
+}
+
+func decodeKMLPolygon(polygon kmlPolygon) (*geojson_v2.Polygon, error) {
+	outer, err := decodeKMLCoordinates(polygon.OuterBoundary.LinearRing.Coordinates)
+	if err != nil {
+		return nil, err
+	}
+
+	decoded := &geojson_v2.Polygon{OuterPath: &geojson_v2.MultiPoint{Points: outer}}
+	for _, boundary := range polygon.InnerBoundary {
+		inner, err := decodeKMLCoordinates(boundary.LinearRing.Coordinates)
+		if err != nil {
+			return nil, err
+		}
+		decoded.InnerPaths = append(decoded.InnerPaths, &geojson_v2.MultiPoint{Points: inner})
+	}
+	return decoded, nil
+}
+
+// decodeKMLCoordinates parses whitespace separated longitude,latitude[,altitude] tuples.
+func decodeKMLCoordinates(coordinates string) ([]*geojson_v2.Point, error) {
+	var points []*geojson_v2.Point
+	for _, tuple := range strings.Fields(coordinates) {
+		values := strings.Split(tuple, ",")
+		if len(values) != 2 && len(values) != 3 {
+			return nil, fmt.Errorf("invalid KML coordinates: %s", tuple)
+		}
+
+		parsed := make([]float64, len(values))
+		for i, value := range values {
+			number, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid KML coordinates: %s", tuple)
+			}
+			parsed[i] = number
+		}
+
+		point := &geojson_v2.Point{Longitude: parsed[0], Latitude: parsed[1]}
+		if len(parsed) == 3 {
+			point.Altitude = &parsed[2]
+		}
+		points = append(points, point)
+	}
+	return points, nil
+}
@@ -0,0 +1,54 @@
+package export
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestAlertsKMLRoundTrip(t *testing.T) {
+	alerts := testAlerts(t)
+
+	var buffer bytes.Buffer
+	err := WriteAlertsKML(&buffer, "test", alerts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := ReadAlertsKML(&buffer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertRoundTrip(t, alerts, got)
+}
+
+func TestConvectiveOutlooksKMLRoundTrip(t *testing.T) {
+	outlooks := testConvectiveOutlooks(t)
+
+	var buffer bytes.Buffer
+	err := WriteConvectiveOutlooksKML(&buffer, "test", outlooks)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := ReadConvectiveOutlooksKML(&buffer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertRoundTrip(t, outlooks, got)
+}
+
+func TestLocationsKMLRoundTrip(t *testing.T) {
+	locations := testLocations()
+
+	var buffer bytes.Buffer
+	err := WriteLocationsKML(&buffer, "test", locations)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := ReadLocationsKML(&buffer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertRoundTrip(t, locations, got)
+}
@@ -0,0 +1,473 @@
+package export
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cmeyer18/weather-common/v6/data_structures"
+	"github.com/cmeyer18/weather-common/v6/data_structures/geojson_v2"
+	"github.com/cmeyer18/weather-common/v6/generative/golang"
+)
+
+// listSeparator joins the values of list fields into a single attribute.
+const listSeparator = ";"
+
+// column is an attribute of an exported product, written as text and parsed back into the product.
+type column[T any] struct {
+	name  string
+	value func(item *T) (string, error)
+	parse func(item *T, value string) error
+}
+
+// product describes how one type of weather product is exported.
+type product[T any] struct {
+	columns     []column[T]
+	geometry    func(item *T) *geojson_v2.Geometry
+	setGeometry func(item *T, geometry *geojson_v2.Geometry) error
+	name        func(item *T) string
+	description func(item *T) string
+	colors      func(item *T) (stroke string, fill string)
+}
+
+var alertProduct = product[data_structures.AlertV2]{
+	columns: []column[data_structures.AlertV2]{
+		stringColumn("id", func(a *data_structures.AlertV2) *string { return &a.ID }),
+		stringColumn("type", func(a *data_structures.AlertV2) *string { return &a.Type }),
+		stringColumn("areaDesc", func(a *data_structures.AlertV2) *string { return &a.AreaDesc }),
+		geocodeColumn("same", func(g *data_structures.AlertPropertiesGeocodeV2) *[]string { return &g.SAME }),
+		geocodeColumn("ugc", func(g *data_structures.AlertPropertiesGeocodeV2) *[]string { return &g.UGC }),
+		listColumn("affectedZones", func(a *data_structures.AlertV2) *[]string { return &a.AffectedZones }),
+		listColumn("references", func(a *data_structures.AlertV2) *[]string { return &a.References }),
+		timeColumn("sent", func(a *data_structures.AlertV2) *time.Time { return &a.Sent }),
+		timeColumn("effective", func(a *data_structures.AlertV2) *time.Time { return &a.Effective }),
+		timeColumn("onset", func(a *data_structures.AlertV2) *time.Time { return &a.Onset }),
+		timeColumn("expires", func(a *data_structures.AlertV2) *time.Time { return &a.Expires }),
+		timeColumn("ends", func(a *data_structures.AlertV2) *time.Time { return &a.Ends }),
+		enumColumn("status", func(a *data_structures.AlertV2) *golang.AlertStatus { return &a.Status }),
+		enumColumn("messageType", func(a *data_structures.AlertV2) *golang.AlertMessageType { return &a.MessageType }),
+		enumColumn("category", func(a *data_structures.AlertV2) *golang.AlertCategory { return &a.Category }),
+		enumColumn("severity", func(a *data_structures.AlertV2) *golang.AlertSeverity { return &a.Severity }),
+		enumColumn("certainty", func(a *data_structures.AlertV2) *golang.AlertCertainty { return &a.Certainty }),
+		enumColumn("urgency", func(a *data_structures.AlertV2) *golang.AlertUrgency { return &a.Urgency }),
+		stringColumn("event", func(a *data_structures.AlertV2) *string { return &a.Event }),
+		stringColumn("sender", func(a *data_structures.AlertV2) *string { return &a.Sender }),
+		stringColumn("senderName", func(a *data_structures.AlertV2) *string { return &a.SenderName }),
+		stringColumn("headline", func(a *data_structures.AlertV2) *string { return &a.Headline }),
+		stringColumn("description", func(a *data_structures.AlertV2) *string { return &a.Description }),
+		stringColumn("instruction", func(a *data_structures.AlertV2) *string { return &a.Instruction }),
+		enumColumn("response", func(a *data_structures.AlertV2) *golang.AlertResponse { return &a.Response }),
+		parametersColumn("parameters"),
+	},
+	geometry: func(a *data_structures.AlertV2) *geojson_v2.Geometry { return a.Geometry },
+	setGeometry: func(a *data_structures.AlertV2, geometry *geojson_v2.Geometry) error {
+		a.Geometry = geometry
+		return nil
+	},
+	name: func(a *data_structures.AlertV2) string {
+		if a.Headline != "" {
+			return a.Headline
+		}
+		return a.Event
+	},
+	description: func(a *data_structures.AlertV2) string { return a.Description },
+}
+
+var convectiveOutlookProduct = product[data_structures.ConvectiveOutlookV2]{
+	columns: []column[data_structures.ConvectiveOutlookV2]{
+		stringColumn("id", func(o *data_structures.ConvectiveOutlookV2) *string { return &o.ID }),
+		{
+			name: "outlookType",
+			value: func(o *data_structures.ConvectiveOutlookV2) (string, error) {
+				return string(o.OutlookType), nil
+			},
+			parse: func(o *data_structures.ConvectiveOutlookV2, value string) error {
+				o.OutlookType = golang.ConvectiveOutlookType(value)
+				return nil
+			},
+		},
+		intColumn("dn", func(o *data_structures.ConvectiveOutlookV2) *int { return &o.DN }),
+		timeColumn("valid", func(o *data_structures.ConvectiveOutlookV2) *time.Time { return &o.Valid }),
+		timeColumn("expires", func(o *data_structures.ConvectiveOutlookV2) *time.Time { return &o.Expires }),
+		timeColumn("issued", func(o *data_structures.ConvectiveOutlookV2) *time.Time { return &o.Issued }),
+		stringColumn("label", func(o *data_structures.ConvectiveOutlookV2) *string { return &o.Label }),
+		stringColumn("label2", func(o *data_structures.ConvectiveOutlookV2) *string { return &o.Label2 }),
+		stringColumn("stroke", func(o *data_structures.ConvectiveOutlookV2) *string { return &o.Stroke }),
+		stringColumn("fill", func(o *data_structures.ConvectiveOutlookV2) *string { return &o.Fill }),
+	},
+	geometry: func(o *data_structures.ConvectiveOutlookV2) *geojson_v2.Geometry { return o.Geometry },
+	setGeometry: func(o *data_structures.ConvectiveOutlookV2, geometry *geojson_v2.Geometry) error {
+		o.Geometry = geometry
+		return nil
+	},
+	name:        func(o *data_structures.ConvectiveOutlookV2) string { return o.Label2 },
+	description: func(o *data_structures.ConvectiveOutlookV2) string { return o.Label },
+	colors: func(o *data_structures.ConvectiveOutlookV2) (string, string) {
+		return o.Stroke, o.Fill
+	},
+}
+
+var mesoscaleDiscussionProduct = product[data_structures.MesoscaleDiscussionV2]{
+	columns: []column[data_structures.MesoscaleDiscussionV2]{
+		stringColumn("id", func(md *data_structures.MesoscaleDiscussionV2) *string { return &md.ID }),
+		intColumn("number", func(md *data_structures.MesoscaleDiscussionV2) *int { return &md.Number }),
+		intColumn("year", func(md *data_structures.MesoscaleDiscussionV2) *int { return &md.Year }),
+		intPointerColumn("probabilityOfWatchIssuance", func(md *data_structures.MesoscaleDiscussionV2) **int {
+			return &md.ProbabilityOfWatchIssuance
+		}),
+		timePointerColumn("effective", func(md *data_structures.MesoscaleDiscussionV2) **time.Time { return &md.Effective }),
+		timePointerColumn("expires", func(md *data_structures.MesoscaleDiscussionV2) **time.Time { return &md.Expires }),
+		stringColumn("rawText", func(md *data_structures.MesoscaleDiscussionV2) *string { return &md.RawText }),
+	},
+	geometry: func(md *data_structures.MesoscaleDiscussionV2) *geojson_v2.Geometry { return md.Geometry },
+	setGeometry: func(md *data_structures.MesoscaleDiscussionV2, geometry *geojson_v2.Geometry) error {
+		md.Geometry = geometry
+		return nil
+	},
+	name: func(md *data_structures.MesoscaleDiscussionV2) string {
+		return fmt.Sprintf("Mesoscale Discussion %d", md.Number)
+	},
+	description: func(md *data_structures.MesoscaleDiscussionV2) string { return md.RawText },
+}
+
+var locationProduct = product[data_structures.Location]{
+	columns: []column[data_structures.Location]{
+		stringColumn("locationId", func(l *data_structures.Location) *string { return &l.LocationID }),
+		{
+			name: "locationType",
+			value: func(l *data_structures.Location) (string, error) {
+				return strconv.Itoa(int(l.LocationType)), nil
+			},
+			parse: func(l *data_structures.Location, value string) error {
+				parsed, err := strconv.ParseInt(value, 10, 8)
+				if err != nil {
+					return fmt.Errorf("invalid locationType: %w", err)
+				}
+
+				l.LocationType = data_structures.LocationType(parsed)
+				return nil
+			},
+		},
+		stringColumn("locationReferenceId", func(l *data_structures.Location) *string { return &l.LocationReferenceID }),
+		stringColumn("zoneCode", func(l *data_structures.Location) *string { return &l.ZoneCode }),
+		stringColumn("countyCode", func(l *data_structures.Location) *string { return &l.CountyCode }),
+		timeColumn("created", func(l *data_structures.Location) *time.Time { return &l.Created }),
+		stringColumn("locationName", func(l *data_structures.Location) *string { return &l.LocationName }),
+		floatColumn("proximityRadiusMeters", func(l *data_structures.Location) *float64 { return &l.ProximityRadiusMeters }),
+		enumListColumn("convectiveOutlookOptions", func(l *data_structures.Location) *[]golang.ConvectiveOutlookType {
+			return &l.ConvectiveOutlookOptions
+		}),
+		enumListColumn("alertOptions", func(l *data_structures.Location) *[]golang.AlertType { return &l.AlertOptions }),
+		boolColumn("mesoscaleDiscussionNotifications", func(l *data_structures.Location) *bool {
+			return &l.MesoscaleDiscussionNotifications
+		}),
+	},
+	geometry: func(l *data_structures.Location) *geojson_v2.Geometry {
+		return &geojson_v2.Geometry{Point: &geojson_v2.Point{Latitude: l.Latitude, Longitude: l.Longitude}}
+	},
+	setGeometry: func(l *data_structures.Location, geometry *geojson_v2.Geometry) error {
+		if geometry == nil {
+			return nil
+		} else if geometry.Point == nil {
+			return errors.New("unsupported location geometry: must be a Point")
+		}
+
+		l.Latitude = geometry.Point.Latitude
+		l.Longitude = geometry.Point.Longitude
+		return nil
+	},
+	name:        func(l *data_structures.Location) string { return l.LocationName },
+	description: func(l *data_structures.Location) string { return "" },
+}
+
+func stringColumn[T any](name string, field func(item *T) *string) column[T] {
+	return column[T]{
+		name: name,
+		value: func(item *T) (string, error) {
+			return *field(item), nil
+		},
+		parse: func(item *T, value string) error {
+			*field(item) = value
+			return nil
+		},
+	}
+}
+
+func intColumn[T any](name string, field func(item *T) *int) column[T] {
+	return column[T]{
+		name: name,
+		value: func(item *T) (string, error) {
+			return strconv.Itoa(*field(item)), nil
+		},
+		parse: func(item *T, value string) error {
+			parsed, err := strconv.Atoi(value)
+			if err != nil {
+				return fmt.Errorf("invalid %s: %w", name, err)
+			}
+
+			*field(item) = parsed
+			return nil
+		},
+	}
+}
+
+func floatColumn[T any](name string, field func(item *T) *float64) column[T] {
+	return column[T]{
+		name: name,
+		value: func(item *T) (string, error) {
+			return strconv.FormatFloat(*field(item), 'f', -1, 64), nil
+		},
+		parse: func(item *T, value string) error {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return fmt.Errorf("invalid %s: %w", name, err)
+			}
+
+			*field(item) = parsed
+			return nil
+		},
+	}
+}
+
+func boolColumn[T any](name string, field func(item *T) *bool) column[T] {
+	return column[T]{
+		name: name,
+		value: func(item *T) (string, error) {
+			return strconv.FormatBool(*field(item)), nil
+		},
+		parse: func(item *T, value string) error {
+			parsed, err := strconv.ParseBool(value)
+			if err != nil {
+				return fmt.Errorf("invalid %s: %w", name, err)
+			}
+
+			*field(item) = parsed
+			return nil
+		},
+	}
+}
+
+func intPointerColumn[T any](name string, field func(item *T) **int) column[T] {
+	return column[T]{
+		name: name,
+		value: func(item *T) (string, error) {
+			if *field(item) == nil {
+				return "", nil
+			}
+			return strconv.Itoa(**field(item)), nil
+		},
+		parse: func(item *T, value string) error {
+			if value == "" {
+				*field(item) = nil
+				return nil
+			}
+
+			parsed, err := strconv.Atoi(value)
+			if err != nil {
+				return fmt.Errorf("invalid %s: %w", name, err)
+			}
+
+			*field(item) = &parsed
+			return nil
+		},
+	}
+}
+
+func timeColumn[T any](name string, field func(item *T) *time.Time) column[T] {
+	return column[T]{
+		name: name,
+		value: func(item *T) (string, error) {
+			return field(item).Format(time.RFC3339Nano), nil
+		},
+		parse: func(item *T, value string) error {
+			parsed, err := time.Parse(time.RFC3339Nano, value)
+			if err != nil {
+				return fmt.Errorf("invalid %s: %w", name, err)
+			}
+
+			*field(item) = parsed
+			return nil
+		},
+	}
+}
+
+func timePointerColumn[T any](name string, field func(item *T) **time.Time) column[T] {
+	return column[T]{
+		name: name,
+		value: func(item *T) (string, error) {
+			if *field(item) == nil {
+				return "", nil
+			}
+			return (*field(item)).Format(time.RFC3339Nano), nil
+		},
+		parse: func(item *T, value string) error {
+			if value == "" {
+				*field(item) = nil
+				return nil
+			}
+
+			parsed, err := time.Parse(time.RFC3339Nano, value)
+			if err != nil {
+				return fmt.Errorf("invalid %s: %w", name, err)
+			}
+
+			*field(item) = &parsed
+			return nil
+		},
+	}
+}
+
+// enumColumn exports a generated enum, which may be empty or one of its values.
+func enumColumn[T any, E interface {
+	~string
+	Valid() bool
+}](name string, field func(item *T) *E) column[T] {
+	return column[T]{
+		name: name,
+		value: func(item *T) (string, error) {
+			return string(*field(item)), nil
+		},
+		parse: func(item *T, value string) error {
+			if value != "" && !E(value).Valid() {
+				return fmt.Errorf("invalid %s: %s", name, value)
+			}
+
+			*field(item) = E(value)
+			return nil
+		},
+	}
+}
+
+func listColumn[T any](name string, field func(item *T) *[]string) column[T] {
+	return column[T]{
+		name: name,
+		value: func(item *T) (string, error) {
+			return strings.Join(*field(item), listSeparator), nil
+		},
+		parse: func(item *T, value string) error {
+			*field(item) = splitList(value)
+			return nil
+		},
+	}
+}
+
+// enumListColumn exports a list of string enums such as the alert types a location is notified of.
+func enumListColumn[T any, E ~string](name string, field func(item *T) *[]E) column[T] {
+	return column[T]{
+		name: name,
+		value: func(item *T) (string, error) {
+			values := make([]string, len(*field(item)))
+			for i, value := range *field(item) {
+				values[i] = string(value)
+			}
+			return strings.Join(values, listSeparator), nil
+		},
+		parse: func(item *T, value string) error {
+			var values []E
+			for _, v := range splitList(value) {
+				values = append(values, E(v))
+			}
+			*field(item) = values
+			return nil
+		},
+	}
+}
+
+// geocodeColumn exports one list of the alert geocode. The geocode is left nil when every geocode column is empty.
+func geocodeColumn(name string, field func(geocode *data_structures.AlertPropertiesGeocodeV2) *[]string) column[data_structures.AlertV2] {
+	return column[data_structures.AlertV2]{
+		name: name,
+		value: func(alert *data_structures.AlertV2) (string, error) {
+			if alert.Geocode == nil {
+				return "", nil
+			}
+			return strings.Join(*field(alert.Geocode), listSeparator), nil
+		},
+		parse: func(alert *data_structures.AlertV2, value string) error {
+			codes := splitList(value)
+			if codes == nil {
+				return nil
+			}
+
+			if alert.Geocode == nil {
+				alert.Geocode = &data_structures.AlertPropertiesGeocodeV2{}
+			}
+			*field(alert.Geocode) = codes
+			return nil
+		},
+	}
+}
+
+// parametersColumn exports the alert parameters as JSON.
+func parametersColumn(name string) column[data_structures.AlertV2] {
+	return column[data_structures.AlertV2]{
+		name: name,
+		value: func(alert *data_structures.AlertV2) (string, error) {
+			if alert.Parameters == nil {
+				return "", nil
+			}
+
+			marshalled, err := json.Marshal(alert.Parameters)
+			if err != nil {
+				return "", err
+			}
+			return string(marshalled), nil
+		},
+		parse: func(alert *data_structures.AlertV2, value string) error {
+			if value == "" {
+				alert.Parameters = nil
+				return nil
+			}
+			return json.Unmarshal([]byte(value), &alert.Parameters)
+		},
+	}
+}
+
+func splitList(value string) []string {
+	if value == "" {
+		return nil
+	}
+	return strings.Split(value, listSeparator)
+}
+
+// header returns the names of the columns of the product.
+func (p product[T]) header() []string {
+	names := make([]string, len(p.columns))
+	for i, column := range p.columns {
+		names[i] = column.name
+	}
+	return names
+}
+
+// values returns the value of every column for the item.
+func (p product[T]) values(item *T) ([]string, error) {
+	values := make([]string, len(p.columns))
+	for i, column := range p.columns {
+		value, err := column.value(item)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s: %w", column.name, err)
+		}
+		values[i] = value
+	}
+	return values, nil
+}
+
+// parse fills in an item from attributes keyed by column name. Missing attributes leave their field unset.
+func (p product[T]) parse(attributes map[string]string) (T, error) {
+	var item T
+	for _, column := range p.columns {
+		value, ok := attributes[column.name]
+		if !ok {
+			continue
+		}
+
+		err := column.parse(&item, value)
+		if err != nil {
+			return item, err
+		}
+	}
+	return item, nil
+}
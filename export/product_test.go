@@ -0,0 +1,156 @@
+package export
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/cmeyer18/weather-common/v6/data_structures"
+	"github.com/cmeyer18/weather-common/v6/data_structures/geojson_v2"
+	"github.com/cmeyer18/weather-common/v6/generative/golang"
+)
+
+func testGeometry(t *testing.T, data string) *geojson_v2.Geometry {
+	t.Helper()
+
+	var geometry geojson_v2.Geometry
+	err := json.Unmarshal([]byte(data), &geometry)
+	if err != nil {
+		t.Fatalf("invalid geometry %s: %v", data, err)
+	}
+	return &geometry
+}
+
+func testAlerts(t *testing.T) []data_structures.AlertV2 {
+	sent := time.Date(2024, 5, 16, 21, 6, 0, 0, time.UTC)
+	return []data_structures.AlertV2{
+		{
+			ID:            "urn:oid:2.49.0.1.840.0.1",
+			Type:          "wx:Alert",
+			Geometry:      testGeometry(t, `{"type":"Polygon","coordinates":[[[-96.2,41.1],[-95.9,41.1],[-95.9,41.4],[-96.2,41.4],[-96.2,41.1]]]}`),
+			AreaDesc:      "Douglas, NE; Sarpy, NE",
+			Geocode:       &data_structures.AlertPropertiesGeocodeV2{SAME: []string{"031055", "031153"}, UGC: []string{"NEC055", "NEC153"}},
+			AffectedZones: []string{"https://api.weather.gov/zones/county/NEC055"},
+			References:    []string{"urn:oid:2.49.0.1.840.0.0"},
+			Sent:          sent,
+			Effective:     sent,
+			Onset:         sent,
+			Expires:       sent.Add(45 * time.Minute),
+			Ends:          sent.Add(45 * time.Minute),
+			Status:        golang.AlertStatusActual,
+			MessageType:   golang.AlertMessageTypeUpdate,
+			Category:      golang.AlertCategoryMet,
+			Severity:      golang.AlertSeveritySevere,
+			Certainty:     golang.AlertCertaintyObserved,
+			Urgency:       golang.AlertUrgencyImmediate,
+			Event:         "Severe Thunderstorm Warning",
+			Sender:        "w-nws.webmaster@noaa.gov",
+			SenderName:    "NWS Omaha/Valley NE",
+			Headline:      "Severe Thunderstorm Warning issued May 16 at 4:06PM CDT",
+			Description:   "At 406 PM CDT, a severe thunderstorm was located over Omaha, \"moving east\", at 40 mph.",
+			Instruction:   "For your protection move to an interior room on the lowest floor of a building.",
+			Response:      golang.AlertResponseShelter,
+			Parameters: map[string]interface{}{
+				"maxHailSize": []interface{}{"1.00"},
+				"VTEC":        []interface{}{"/O.CON.KOAX.SV.W.0100.000000T0000Z-240516T2145Z/"},
+			},
+		},
+		{
+			ID:          "urn:oid:2.49.0.1.840.0.2",
+			Geometry:    testGeometry(t, `{"type":"MultiPolygon","coordinates":[[[[-100,35],[-98,35],[-98,37],[-100,37],[-100,35]],[[-99.5,35.5],[-98.5,35.5],[-98.5,36.5],[-99.5,36.5],[-99.5,35.5]]],[[[-97,35],[-96,35],[-96,36],[-97,36],[-97,35]]]]}`),
+			Sent:        sent,
+			Effective:   sent,
+			Onset:       sent,
+			Expires:     sent,
+			Ends:        sent,
+			Status:      golang.AlertStatusTest,
+			MessageType: golang.AlertMessageTypeAlert,
+			Event:       "Test Message",
+		},
+	}
+}
+
+func testConvectiveOutlooks(t *testing.T) []data_structures.ConvectiveOutlookV2 {
+	issued := time.Date(2024, 5, 16, 12, 51, 0, 0, time.UTC)
+	return []data_structures.ConvectiveOutlookV2{
+		{
+			ID:          "day1otlk_20240516_1300",
+			OutlookType: golang.Day1Categorical,
+			Geometry:    testGeometry(t, `{"type":"Polygon","coordinates":[[[-100.25,37.5],[-94.5,37.5],[-94.5,40.75],[-100.25,40.75],[-100.25,37.5]]]}`),
+			DN:          4,
+			Valid:       issued.Add(9 * time.Minute),
+			Expires:     issued.Add(18 * time.Hour),
+			Issued:      issued,
+			Label:       "SLGT",
+			Label2:      "Slight Risk",
+			Stroke:      "#DDAA00",
+			Fill:        "#FFE066",
+		},
+		{
+			ID:          "day1otlk_20240516_1300",
+			OutlookType: golang.Day1Categorical,
+			Geometry:    testGeometry(t, `{"type":"MultiPolygon","coordinates":[[[[-102.5,35.5],[-92.5,35.5],[-92.5,42.5],[-102.5,42.5],[-102.5,35.5]]],[[[-90.5,36.5],[-88.5,36.5],[-88.5,38.5],[-90.5,38.5],[-90.5,36.5]]]]}`),
+			DN:          3,
+			Valid:       issued.Add(9 * time.Minute),
+			Expires:     issued.Add(18 * time.Hour),
+			Issued:      issued,
+			Label:       "MRGL",
+			Label2:      "Marginal Risk",
+			Stroke:      "#005500",
+			Fill:        "#66A366",
+		},
+	}
+}
+
+func testLocations() []data_structures.Location {
+	return []data_structures.Location{
+		{
+			LocationID:                       "6f1c2a7e-55f1-4c6a-9f0e-6d3b2f4d8a10",
+			LocationType:                     data_structures.LocationType_UserLocation,
+			LocationReferenceID:              "user-1",
+			ZoneCode:                         "NEZ052",
+			CountyCode:                       "NEC055",
+			Created:                          time.Date(2024, 5, 1, 8, 30, 0, 0, time.UTC),
+			Latitude:                         41.2565,
+			Longitude:                        -95.9345,
+			LocationName:                     "Omaha, NE",
+			ProximityRadiusMeters:            16093.44,
+			ConvectiveOutlookOptions:         []golang.ConvectiveOutlookType{golang.Day1Categorical, golang.Day1Tornado},
+			AlertOptions:                     []golang.AlertType{"Tornado Warning", "Severe Thunderstorm Warning"},
+			MesoscaleDiscussionNotifications: true,
+		},
+		{
+			LocationID:   "0c9a4b1e-2b7d-4e3f-8a6c-1f5e9d7b3c20",
+			LocationType: data_structures.LocationType_DeviceLocaiton,
+			Created:      time.Date(2024, 5, 2, 0, 0, 0, 0, time.UTC),
+			Latitude:     51.88,
+			Longitude:    -176.65,
+			LocationName: "Adak, AK",
+		},
+	}
+}
+
+// assertRoundTrip compares the items through their JSON, which treats nil and empty inner paths alike.
+func assertRoundTrip[T any](t *testing.T, want, got []T) {
+	t.Helper()
+
+	if len(got) != len(want) {
+		t.Fatalf("items = %d, want %d", len(got), len(want))
+	}
+
+	for i := range want {
+		wantJSON, err := json.Marshal(want[i])
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		gotJSON, err := json.Marshal(got[i])
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if string(gotJSON) != string(wantJSON) {
+			t.Errorf("item %d\n got: %s\nwant: %s", i, gotJSON, wantJSON)
+		}
+	}
+}
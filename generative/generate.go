@@ -11,6 +11,9 @@ func main() {
 	print(os.Getwd())
 	golang.GenerateConvectiveOutlookGo()
 	golang.GenerateWeatherAlertsGo()
+	golang.GenerateAlertCAPEnumsGo()
 	swift.GenerateConvectiveOutlookSwift()
 	swift.GenerateWeatherAlertsSwift()
+	swift.GenerateAlertCAPEnumsSwift()
+	swift.GenerateGeometryEncodingSwift()
 }
@@ -0,0 +1,157 @@
+package golang
+
+import (
+	"fmt"
+	"go/format"
+	"os"
+	"strings"
+	"time"
+)
+
+const alertCAPEnumsGoTopComment = `// This file was generated by generative/generators/golang/alert_cap_enums.go
+// Please do not hand write.
+
+package golang
+
+`
+
+// capEnum is an enum of the CAP alert message, read from generators/templates/alert_cap_enums.txt. Values of an
+// ordered enum are listed from highest to lowest.
+type capEnum struct {
+	name    string
+	ordered bool
+	values  []string
+}
+
+func GenerateAlertCAPEnumsGo() {
+	enums, err := readCAPEnums("generators/templates/alert_cap_enums.txt")
+	if err != nil {
+		fmt.Printf("Failed to read file: %v\n", err)
+		return
+	}
+
+	ordered := false
+	for _, enum := range enums {
+		ordered = ordered || enum.ordered
+	}
+
+	// Add generated time
+	generated := "// Generated on " + time.Now().String() + "\n"
+
+	// Add block comment
+	generated += alertCAPEnumsGoTopComment
+
+	// Write imports
+	generated += "import (\n"
+	if ordered {
+		generated += "\t\"cmp\"\n"
+	}
+	generated += "\t\"encoding/json\"\n\t\"fmt\"\n\t\"slices\"\n)\n\n"
+
+	for _, enum := range enums {
+		generated += generateCAPEnumGo(enum)
+	}
+
+	formatted, err := format.Source([]byte(generated))
+	if err != nil {
+		fmt.Printf("Error formatting generated code: %v\n", err)
+		return
+	}
+
+	// Write the generated Go enums to a file
+	file, err := os.Create("golang/alert_cap_enums.go")
+	if err != nil {
+		fmt.Printf("Error creating file: %v\n", err)
+		return
+	}
+	defer file.Close()
+
+	file.Write(formatted)
+}
+
+func generateCAPEnumGo(enum capEnum) string {
+	list := enum.name + "Values"
+
+	generated := fmt.Sprintf("type %s string\n\n", enum.name)
+
+	// Write the enum values
+	generated += "const (\n"
+	for _, value := range enum.values {
+		generated += fmt.Sprintf("\t%s%s %s = \"%s\"\n", enum.name, value, enum.name, value)
+	}
+	generated += ")\n\n"
+
+	// Write the list of values
+	if enum.ordered {
+		generated += fmt.Sprintf("// %s lists every %s, from highest to lowest.\n", list, enum.name)
+	} else {
+		generated += fmt.Sprintf("// %s lists every %s.\n", list, enum.name)
+	}
+	generated += fmt.Sprintf("var %s = []%s{\n", list, enum.name)
+	for _, value := range enum.values {
+		generated += fmt.Sprintf("\t%s%s,\n", enum.name, value)
+	}
+	generated += "}\n\n"
+
+	// Write validation
+	generated += fmt.Sprintf("// Valid reports whether the value is one of %s.\n", list)
+	generated += fmt.Sprintf("func (v %s) Valid() bool {\n\treturn slices.Contains(%s, v)\n}\n\n", enum.name, list)
+
+	generated += fmt.Sprintf("// UnmarshalJSON accepts an empty value or one of %s.\n", list)
+	generated += fmt.Sprintf("func (v *%s) UnmarshalJSON(data []byte) error {\n", enum.name)
+	generated += "\tvar value string\n"
+	generated += "\terr := json.Unmarshal(data, &value)\n"
+	generated += "\tif err != nil {\n\t\treturn err\n\t}\n\n"
+	generated += fmt.Sprintf("\tif value != \"\" && !%s(value).Valid() {\n", enum.name)
+	generated += fmt.Sprintf("\t\treturn fmt.Errorf(\"invalid %s: %%s\", value)\n\t}\n\n", enum.name)
+	generated += fmt.Sprintf("\t*v = %s(value)\n\treturn nil\n}\n\n", enum.name)
+
+	if !enum.ordered {
+		return generated
+	}
+
+	// Write ordering
+	generated += fmt.Sprintf("// Rank orders the values from 0 for the lowest %s to len(%s)-1 for the highest. An empty or invalid\n", enum.name, list)
+	generated += "// value ranks -1.\n"
+	generated += fmt.Sprintf("func (v %s) Rank() int {\n", enum.name)
+	generated += fmt.Sprintf("\tindex := slices.Index(%s, v)\n", list)
+	generated += "\tif index < 0 {\n\t\treturn -1\n\t}\n"
+	generated += fmt.Sprintf("\treturn len(%s) - 1 - index\n}\n\n", list)
+
+	generated += "// Compare returns -1, 0 or +1 as the value ranks below, the same as or above the other value.\n"
+	generated += fmt.Sprintf("func (v %s) Compare(other %s) int {\n", enum.name, enum.name)
+	generated += "\treturn cmp.Compare(v.Rank(), other.Rank())\n}\n\n"
+
+	return generated
+}
+
+// readCAPEnums reads lines of the form "Name: Value, Value" or "Name ordered: Value, Value".
+func readCAPEnums(filePath string) ([]capEnum, error) {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	var enums []capEnum
+	for _, line := range strings.Split(string(content), "\n") {
+		line = strings.TrimSpace(line)
+
+		// Skip empty lines
+		if line == "" {
+			continue
+		}
+
+		declaration, values, ok := strings.Cut(line, ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid enum line: %s", line)
+		}
+
+		var enum capEnum
+		enum.name, enum.ordered = strings.CutSuffix(strings.TrimSpace(declaration), " ordered")
+		for _, value := range strings.Split(values, ",") {
+			enum.values = append(enum.values, strings.TrimSpace(value))
+		}
+		enums = append(enums, enum)
+	}
+	return enums, nil
+}
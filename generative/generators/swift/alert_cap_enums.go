@@ -0,0 +1,108 @@
+package swift
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+const alertCAPEnumsSwiftTopComment = `// This file was generated by generative/generators/swift/alert_cap_enums.go.
+// Please do not hand write.
+
+import Foundation
+
+`
+
+// capEnum is an enum of the CAP alert message, read from generators/templates/alert_cap_enums.txt. Values of an
+// ordered enum are listed from highest to lowest.
+type capEnum struct {
+	name    string
+	ordered bool
+	values  []string
+}
+
+func GenerateAlertCAPEnumsSwift() {
+	enums, err := readCAPEnums("generators/templates/alert_cap_enums.txt")
+	if err != nil {
+		fmt.Printf("Failed to read file: %v\n", err)
+		return
+	}
+
+	// Add generated time
+	generated := "// Generated on " + time.Now().String() + "\n"
+
+	// Add block comment
+	generated += alertCAPEnumsSwiftTopComment
+
+	for i, enum := range enums {
+		if i > 0 {
+			generated += "\n"
+		}
+
+		protocols := "String, CaseIterable, Codable"
+		if enum.ordered {
+			protocols += ", Comparable"
+		}
+		generated += fmt.Sprintf("enum %s: %s {\n", enum.name, protocols)
+
+		// Write the enum cases
+		for _, value := range enum.values {
+			generated += fmt.Sprintf("\tcase %s = \"%s\"\n", value, value)
+		}
+
+		// Write ordering, with cases listed from highest to lowest
+		if enum.ordered {
+			generated += "\n"
+			generated += "\tvar rank: Int {\n"
+			generated += fmt.Sprintf("\t\treturn %s.allCases.count - 1 - %s.allCases.firstIndex(of: self)!\n", enum.name, enum.name)
+			generated += "\t}\n\n"
+			generated += fmt.Sprintf("\tstatic func < (lhs: %s, rhs: %s) -> Bool {\n", enum.name, enum.name)
+			generated += "\t\treturn lhs.rank < rhs.rank\n"
+			generated += "\t}\n"
+		}
+
+		generated += "}\n"
+	}
+
+	// Write the generated Swift enums to a file
+	file, err := os.Create("swift/AlertCAPEnums.swift")
+	if err != nil {
+		fmt.Printf("Error creating file: %v\n", err)
+		return
+	}
+	defer file.Close()
+
+	file.Write([]byte(generated))
+}
+
+// readCAPEnums reads lines of the form "Name: Value, Value" or "Name ordered: Value, Value".
+func readCAPEnums(filePath string) ([]capEnum, error) {
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	var enums []capEnum
+	for _, line := range strings.Split(string(content), "\n") {
+		line = strings.TrimSpace(line)
+
+		// Skip empty lines
+		if line == "" {
+			continue
+		}
+
+		declaration, values, ok := strings.Cut(line, ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid enum line: %s", line)
+		}
+
+		var enum capEnum
+		enum.name, enum.ordered = strings.CutSuffix(strings.TrimSpace(declaration), " ordered")
+		for _, value := range strings.Split(values, ",") {
+			enum.values = append(enum.values, strings.TrimSpace(value))
+		}
+		enums = append(enums, enum)
+	}
+	return enums, nil
+}
@@ -0,0 +1,151 @@
+package swift
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/cmeyer18/weather-common/v6/data_structures/geojson_v2"
+)
+
+const geometryEncodingSwiftTopComment = `// This file was generated by generative/generators/swift/geometry_encoding.go.
+// Please do not hand write.
+
+import Foundation
+
+`
+
+const geometryEncodingSwiftDecoders = `// Decodes the strings that replace position arrays in geometries written with a compact coordinate encoding. Positions
+// are returned as [longitude, latitude], the same order as GeoJSON positions, or nil when the string is malformed.
+enum CompactCoordinates {
+    static func decode(_ encoded: String, encoding: CoordinateEncoding) -> [[Double]]? {
+        switch encoding {
+        case .polyline:
+            return decodePolyline(encoded)
+        case .delta:
+            return decodeDelta(encoded)
+        default:
+            return nil
+        }
+    }
+
+    static func decodePolyline(_ encoded: String) -> [[Double]]? {
+        let bytes = Array(encoded.utf8)
+        var positions: [[Double]] = []
+        var index = 0
+        var latitude: Int64 = 0
+        var longitude: Int64 = 0
+
+        while index < bytes.count {
+            guard let latitudeDelta = readPolylineValue(bytes, &index),
+                  let longitudeDelta = readPolylineValue(bytes, &index) else {
+                return nil
+            }
+
+            latitude += latitudeDelta
+            longitude += longitudeDelta
+            positions.append([Double(longitude) / polylineFactor, Double(latitude) / polylineFactor])
+        }
+        return positions
+    }
+
+    static func decodeDelta(_ encoded: String) -> [[Double]]? {
+        guard let data = Data(base64Encoded: encoded) else {
+            return nil
+        }
+
+        let bytes = Array(data)
+        var positions: [[Double]] = []
+        var index = 0
+        var longitude: Int64 = 0
+        var latitude: Int64 = 0
+
+        while index < bytes.count {
+            guard let longitudeDelta = readVarint(bytes, &index),
+                  let latitudeDelta = readVarint(bytes, &index) else {
+                return nil
+            }
+
+            longitude += longitudeDelta
+            latitude += latitudeDelta
+            positions.append([Double(longitude) / deltaFactor, Double(latitude) / deltaFactor])
+        }
+        return positions
+    }
+
+    private static func readPolylineValue(_ bytes: [UInt8], _ index: inout Int) -> Int64? {
+        var zigzagged: UInt64 = 0
+        var shift: UInt64 = 0
+
+        while true {
+            guard index < bytes.count, shift <= 60, bytes[index] >= 63, bytes[index] - 63 <= 0x3f else {
+                return nil
+            }
+
+            let chunk = UInt64(bytes[index] - 63)
+            index += 1
+
+            zigzagged |= (chunk & 0x1f) << shift
+            if chunk < 0x20 {
+                return unzigzag(zigzagged)
+            }
+            shift += 5
+        }
+    }
+
+    private static func readVarint(_ bytes: [UInt8], _ index: inout Int) -> Int64? {
+        var zigzagged: UInt64 = 0
+        var shift: UInt64 = 0
+
+        while true {
+            guard index < bytes.count, shift <= 63 else {
+                return nil
+            }
+
+            let byte = UInt64(bytes[index])
+            index += 1
+
+            zigzagged |= (byte & 0x7f) << shift
+            if byte < 0x80 {
+                return unzigzag(zigzagged)
+            }
+            shift += 7
+        }
+    }
+
+    private static func unzigzag(_ value: UInt64) -> Int64 {
+        return Int64(bitPattern: value >> 1) ^ -Int64(bitPattern: value & 1)
+    }
+}
+`
+
+func GenerateGeometryEncodingSwift() {
+	// Add generated time
+	generated := "// Generated on " + time.Now().String() + "\n"
+
+	// Add block comment
+	generated += geometryEncodingSwiftTopComment
+
+	generated += "enum CoordinateEncoding: String, CaseIterable, Codable {\n"
+	for _, encoding := range geojson_v2.CoordinateEncodings {
+		generated += fmt.Sprintf("\tcase %s = \"%s\"\n", strings.ToLower(string(encoding)), encoding)
+	}
+	generated += "}\n\n"
+
+	// Write the precision of each compact encoding
+	generated += fmt.Sprintf("private let polylineFactor = 1e%d\n", geojson_v2.PolylinePrecision)
+	generated += fmt.Sprintf("private let deltaFactor = 1e%d\n\n", geojson_v2.DeltaPrecision)
+
+	generated += geometryEncodingSwiftDecoders
+
+	// Write the generated Swift decoders to a file
+	file, err := os.Create("swift/GeometryEncoding.swift")
+	if err != nil {
+		fmt.Printf("Error creating file: %v\n", err)
+		return
+	}
+	defer file.Close()
+
+	file.Write([]byte(generated))
+}
@@ -0,0 +1,359 @@
+// Generated on 2026-10-18 07:23:45.989410984 +0000 UTC m=+0.000325073
+// This file was generated by generative/generators/golang/alert_cap_enums.go
+// Please do not hand write.
+
+package golang
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"slices"
+)
+
+type AlertStatus string
+
+const (
+	AlertStatusActual   AlertStatus = "Actual"
+	AlertStatusExercise AlertStatus = "Exercise"
+	AlertStatusSystem   AlertStatus = "System"
+	AlertStatusTest     AlertStatus = "Test"
+	AlertStatusDraft    AlertStatus = "Draft"
+)
+
+// AlertStatusValues lists every AlertStatus.
+var AlertStatusValues = []AlertStatus{
+	AlertStatusActual,
+	AlertStatusExercise,
+	AlertStatusSystem,
+	AlertStatusTest,
+	AlertStatusDraft,
+}
+
+// Valid reports whether the value is one of AlertStatusValues.
+func (v AlertStatus) Valid() bool {
+	return slices.Contains(AlertStatusValues, v)
+}
+
+// UnmarshalJSON accepts an empty value or one of AlertStatusValues.
+func (v *AlertStatus) UnmarshalJSON(data []byte) error {
+	var value string
+	err := json.Unmarshal(data, &value)
+	if err != nil {
+		return err
+	}
+
+	if value != "" && !AlertStatus(value).Valid() {
+		return fmt.Errorf("invalid AlertStatus: %s", value)
+	}
+
+	*v = AlertStatus(value)
+	return nil
+}
+
+type AlertMessageType string
+
+const (
+	AlertMessageTypeAlert  AlertMessageType = "Alert"
+	AlertMessageTypeUpdate AlertMessageType = "Update"
+	AlertMessageTypeCancel AlertMessageType = "Cancel"
+	AlertMessageTypeAck    AlertMessageType = "Ack"
+	AlertMessageTypeError  AlertMessageType = "Error"
+)
+
+// AlertMessageTypeValues lists every AlertMessageType.
+var AlertMessageTypeValues = []AlertMessageType{
+	AlertMessageTypeAlert,
+	AlertMessageTypeUpdate,
+	AlertMessageTypeCancel,
+	AlertMessageTypeAck,
+	AlertMessageTypeError,
+}
+
+// Valid reports whether the value is one of AlertMessageTypeValues.
+func (v AlertMessageType) Valid() bool {
+	return slices.Contains(AlertMessageTypeValues, v)
+}
+
+// UnmarshalJSON accepts an empty value or one of AlertMessageTypeValues.
+func (v *AlertMessageType) UnmarshalJSON(data []byte) error {
+	var value string
+	err := json.Unmarshal(data, &value)
+	if err != nil {
+		return err
+	}
+
+	if value != "" && !AlertMessageType(value).Valid() {
+		return fmt.Errorf("invalid AlertMessageType: %s", value)
+	}
+
+	*v = AlertMessageType(value)
+	return nil
+}
+
+type AlertCategory string
+
+const (
+	AlertCategoryGeo       AlertCategory = "Geo"
+	AlertCategoryMet       AlertCategory = "Met"
+	AlertCategorySafety    AlertCategory = "Safety"
+	AlertCategorySecurity  AlertCategory = "Security"
+	AlertCategoryRescue    AlertCategory = "Rescue"
+	AlertCategoryFire      AlertCategory = "Fire"
+	AlertCategoryHealth    AlertCategory = "Health"
+	AlertCategoryEnv       AlertCategory = "Env"
+	AlertCategoryTransport AlertCategory = "Transport"
+	AlertCategoryInfra     AlertCategory = "Infra"
+	AlertCategoryCBRNE     AlertCategory = "CBRNE"
+	AlertCategoryOther     AlertCategory = "Other"
+)
+
+// AlertCategoryValues lists every AlertCategory.
+var AlertCategoryValues = []AlertCategory{
+	AlertCategoryGeo,
+	AlertCategoryMet,
+	AlertCategorySafety,
+	AlertCategorySecurity,
+	AlertCategoryRescue,
+	AlertCategoryFire,
+	AlertCategoryHealth,
+	AlertCategoryEnv,
+	AlertCategoryTransport,
+	AlertCategoryInfra,
+	AlertCategoryCBRNE,
+	AlertCategoryOther,
+}
+
+// Valid reports whether the value is one of AlertCategoryValues.
+func (v AlertCategory) Valid() bool {
+	return slices.Contains(AlertCategoryValues, v)
+}
+
+// UnmarshalJSON accepts an empty value or one of AlertCategoryValues.
+func (v *AlertCategory) UnmarshalJSON(data []byte) error {
+	var value string
+	err := json.Unmarshal(data, &value)
+	if err != nil {
+		return err
+	}
+
+	if value != "" && !AlertCategory(value).Valid() {
+		return fmt.Errorf("invalid AlertCategory: %s", value)
+	}
+
+	*v = AlertCategory(value)
+	return nil
+}
+
+type AlertSeverity string
+
+const (
+	AlertSeverityExtreme  AlertSeverity = "Extreme"
+	AlertSeveritySevere   AlertSeverity = "Severe"
+	AlertSeverityModerate AlertSeverity = "Moderate"
+	AlertSeverityMinor    AlertSeverity = "Minor"
+	AlertSeverityUnknown  AlertSeverity = "Unknown"
+)
+
+// AlertSeverityValues lists every AlertSeverity, from highest to lowest.
+var AlertSeverityValues = []AlertSeverity{
+	AlertSeverityExtreme,
+	AlertSeveritySevere,
+	AlertSeverityModerate,
+	AlertSeverityMinor,
+	AlertSeverityUnknown,
+}
+
+// Valid reports whether the value is one of AlertSeverityValues.
+func (v AlertSeverity) Valid() bool {
+	return slices.Contains(AlertSeverityValues, v)
+}
+
+// UnmarshalJSON accepts an empty value or one of AlertSeverityValues.
+func (v *AlertSeverity) UnmarshalJSON(data []byte) error {
+	var value string
+	err := json.Unmarshal(data, &value)
+	if err != nil {
+		return err
+	}
+
+	if value != "" && !AlertSeverity(value).Valid() {
+		return fmt.Errorf("invalid AlertSeverity: %s", value)
+	}
+
+	*v = AlertSeverity(value)
+	return nil
+}
+
+// Rank orders the values from 0 for the lowest AlertSeverity to len(AlertSeverityValues)-1 for the highest. An empty or invalid
+// value ranks -1.
+func (v AlertSeverity) Rank() int {
+	index := slices.Index(AlertSeverityValues, v)
+	if index < 0 {
+		return -1
+	}
+	return len(AlertSeverityValues) - 1 - index
+}
+
+// Compare returns -1, 0 or +1 as the value ranks below, the same as or above the other value.
+func (v AlertSeverity) Compare(other AlertSeverity) int {
+	return cmp.Compare(v.Rank(), other.Rank())
+}
+
+type AlertCertainty string
+
+const (
+	AlertCertaintyObserved AlertCertainty = "Observed"
+	AlertCertaintyLikely   AlertCertainty = "Likely"
+	AlertCertaintyPossible AlertCertainty = "Possible"
+	AlertCertaintyUnlikely AlertCertainty = "Unlikely"
+	AlertCertaintyUnknown  AlertCertainty = "Unknown"
+)
+
+// AlertCertaintyValues lists every AlertCertainty, from highest to lowest.
+var AlertCertaintyValues = []AlertCertainty{
+	AlertCertaintyObserved,
+	AlertCertaintyLikely,
+	AlertCertaintyPossible,
+	AlertCertaintyUnlikely,
+	AlertCertaintyUnknown,
+}
+
+// Valid reports whether the value is one of AlertCertaintyValues.
+func (v AlertCertainty) Valid() bool {
+	return slices.Contains(AlertCertaintyValues, v)
+}
+
+// UnmarshalJSON accepts an empty value or one of AlertCertaintyValues.
+func (v *AlertCertainty) UnmarshalJSON(data []byte) error {
+	var value string
+	err := json.Unmarshal(data, &value)
+	if err != nil {
+		return err
+	}
+
+	if value != "" && !AlertCertainty(value).Valid() {
+		return fmt.Errorf("invalid AlertCertainty: %s", value)
+	}
+
+	*v = AlertCertainty(value)
+	return nil
+}
+
+// Rank orders the values from 0 for the lowest AlertCertainty to len(AlertCertaintyValues)-1 for the highest. An empty or invalid
+// value ranks -1.
+func (v AlertCertainty) Rank() int {
+	index := slices.Index(AlertCertaintyValues, v)
+	if index < 0 {
+		return -1
+	}
+	return len(AlertCertaintyValues) - 1 - index
+}
+
+// Compare returns -1, 0 or +1 as the value ranks below, the same as or above the other value.
+func (v AlertCertainty) Compare(other AlertCertainty) int {
+	return cmp.Compare(v.Rank(), other.Rank())
+}
+
+type AlertUrgency string
+
+const (
+	AlertUrgencyImmediate AlertUrgency = "Immediate"
+	AlertUrgencyExpected  AlertUrgency = "Expected"
+	AlertUrgencyFuture    AlertUrgency = "Future"
+	AlertUrgencyPast      AlertUrgency = "Past"
+	AlertUrgencyUnknown   AlertUrgency = "Unknown"
+)
+
+// AlertUrgencyValues lists every AlertUrgency, from highest to lowest.
+var AlertUrgencyValues = []AlertUrgency{
+	AlertUrgencyImmediate,
+	AlertUrgencyExpected,
+	AlertUrgencyFuture,
+	AlertUrgencyPast,
+	AlertUrgencyUnknown,
+}
+
+// Valid reports whether the value is one of AlertUrgencyValues.
+func (v AlertUrgency) Valid() bool {
+	return slices.Contains(AlertUrgencyValues, v)
+}
+
+// UnmarshalJSON accepts an empty value or one of AlertUrgencyValues.
+func (v *AlertUrgency) UnmarshalJSON(data []byte) error {
+	var value string
+	err := json.Unmarshal(data, &value)
+	if err != nil {
+		return err
+	}
+
+	if value != "" && !AlertUrgency(value).Valid() {
+		return fmt.Errorf("invalid AlertUrgency: %s", value)
+	}
+
+	*v = AlertUrgency(value)
+	return nil
+}
+
+// Rank orders the values from 0 for the lowest AlertUrgency to len(AlertUrgencyValues)-1 for the highest. An empty or invalid
+// value ranks -1.
+func (v AlertUrgency) Rank() int {
+	index := slices.Index(AlertUrgencyValues, v)
+	if index < 0 {
+		return -1
+	}
+	return len(AlertUrgencyValues) - 1 - index
+}
+
+// Compare returns -1, 0 or +1 as the value ranks below, the same as or above the other value.
+func (v AlertUrgency) Compare(other AlertUrgency) int {
+	return cmp.Compare(v.Rank(), other.Rank())
+}
+
+type AlertResponse string
+
+const (
+	AlertResponseShelter  AlertResponse = "Shelter"
+	AlertResponseEvacuate AlertResponse = "Evacuate"
+	AlertResponsePrepare  AlertResponse = "Prepare"
+	AlertResponseExecute  AlertResponse = "Execute"
+	AlertResponseAvoid    AlertResponse = "Avoid"
+	AlertResponseMonitor  AlertResponse = "Monitor"
+	AlertResponseAssess   AlertResponse = "Assess"
+	AlertResponseAllClear AlertResponse = "AllClear"
+	AlertResponseNone     AlertResponse = "None"
+)
+
+// AlertResponseValues lists every AlertResponse.
+var AlertResponseValues = []AlertResponse{
+	AlertResponseShelter,
+	AlertResponseEvacuate,
+	AlertResponsePrepare,
+	AlertResponseExecute,
+	AlertResponseAvoid,
+	AlertResponseMonitor,
+	AlertResponseAssess,
+	AlertResponseAllClear,
+	AlertResponseNone,
+}
+
+// Valid reports whether the value is one of AlertResponseValues.
+func (v AlertResponse) Valid() bool {
+	return slices.Contains(AlertResponseValues, v)
+}
+
+// UnmarshalJSON accepts an empty value or one of AlertResponseValues.
+func (v *AlertResponse) UnmarshalJSON(data []byte) error {
+	var value string
+	err := json.Unmarshal(data, &value)
+	if err != nil {
+		return err
+	}
+
+	if value != "" && !AlertResponse(value).Valid() {
+		return fmt.Errorf("invalid AlertResponse: %s", value)
+	}
+
+	*v = AlertResponse(value)
+	return nil
+}
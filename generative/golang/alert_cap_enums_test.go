@@ -0,0 +1,69 @@
+package golang
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestAlertCAPEnumsUnmarshalJSON(t *testing.T) {
+	targets := map[string]func() any{
+		"AlertStatus":      func() any { return new(AlertStatus) },
+		"AlertMessageType": func() any { return new(AlertMessageType) },
+		"AlertCategory":    func() any { return new(AlertCategory) },
+		"AlertSeverity":    func() any { return new(AlertSeverity) },
+		"AlertCertainty":   func() any { return new(AlertCertainty) },
+		"AlertUrgency":     func() any { return new(AlertUrgency) },
+		"AlertResponse":    func() any { return new(AlertResponse) },
+	}
+
+	for name, target := range targets {
+		t.Run(name, func(t *testing.T) {
+			err := json.Unmarshal([]byte(`"Bogus"`), target())
+			if err == nil {
+				t.Error("expected an error for an unknown value")
+			}
+
+			err = json.Unmarshal([]byte(`7`), target())
+			if err == nil {
+				t.Error("expected an error for a value that is not a string")
+			}
+
+			err = json.Unmarshal([]byte(`""`), target())
+			if err != nil {
+				t.Errorf("unexpected error for an empty value: %v", err)
+			}
+		})
+	}
+}
+
+func TestAlertSeverityUnmarshalJSON(t *testing.T) {
+	var severity AlertSeverity
+	err := json.Unmarshal([]byte(`"Severe"`), &severity)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if severity != AlertSeveritySevere {
+		t.Errorf("got %s, want %s", severity, AlertSeveritySevere)
+	}
+
+	// A rejected value leaves the previous value in place.
+	err = json.Unmarshal([]byte(`"severe"`), &severity)
+	if err == nil {
+		t.Error("expected an error for a value in the wrong case")
+	}
+	if severity != AlertSeveritySevere {
+		t.Errorf("got %s, want %s", severity, AlertSeveritySevere)
+	}
+}
+
+func TestAlertSeverityCompare(t *testing.T) {
+	if AlertSeverityExtreme.Compare(AlertSeverityMinor) != 1 {
+		t.Error("expected Extreme to rank above Minor")
+	}
+	if AlertSeverityUnknown.Compare(AlertSeverity("")) != 1 {
+		t.Error("expected Unknown to rank above an empty value")
+	}
+	if AlertSeverity("Bogus").Rank() != -1 {
+		t.Error("expected an invalid value to rank -1")
+	}
+}
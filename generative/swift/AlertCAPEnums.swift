@@ -0,0 +1,96 @@
+// Generated on 2026-10-18 07:23:45.996101227 +0000 UTC m=+0.007015291
+// This file was generated by generative/generators/swift/alert_cap_enums.go.
+// Please do not hand write.
+
+import Foundation
+
+enum AlertStatus: String, CaseIterable, Codable {
+	case Actual = "Actual"
+	case Exercise = "Exercise"
+	case System = "System"
+	case Test = "Test"
+	case Draft = "Draft"
+}
+
+enum AlertMessageType: String, CaseIterable, Codable {
+	case Alert = "Alert"
+	case Update = "Update"
+	case Cancel = "Cancel"
+	case Ack = "Ack"
+	case Error = "Error"
+}
+
+enum AlertCategory: String, CaseIterable, Codable {
+	case Geo = "Geo"
+	case Met = "Met"
+	case Safety = "Safety"
+	case Security = "Security"
+	case Rescue = "Rescue"
+	case Fire = "Fire"
+	case Health = "Health"
+	case Env = "Env"
+	case Transport = "Transport"
+	case Infra = "Infra"
+	case CBRNE = "CBRNE"
+	case Other = "Other"
+}
+
+enum AlertSeverity: String, CaseIterable, Codable, Comparable {
+	case Extreme = "Extreme"
+	case Severe = "Severe"
+	case Moderate = "Moderate"
+	case Minor = "Minor"
+	case Unknown = "Unknown"
+
+	var rank: Int {
+		return AlertSeverity.allCases.count - 1 - AlertSeverity.allCases.firstIndex(of: self)!
+	}
+
+	static func < (lhs: AlertSeverity, rhs: AlertSeverity) -> Bool {
+		return lhs.rank < rhs.rank
+	}
+}
+
+enum AlertCertainty: String, CaseIterable, Codable, Comparable {
+	case Observed = "Observed"
+	case Likely = "Likely"
+	case Possible = "Possible"
+	case Unlikely = "Unlikely"
+	case Unknown = "Unknown"
+
+	var rank: Int {
+		return AlertCertainty.allCases.count - 1 - AlertCertainty.allCases.firstIndex(of: self)!
+	}
+
+	static func < (lhs: AlertCertainty, rhs: AlertCertainty) -> Bool {
+		return lhs.rank < rhs.rank
+	}
+}
+
+enum AlertUrgency: String, CaseIterable, Codable, Comparable {
+	case Immediate = "Immediate"
+	case Expected = "Expected"
+	case Future = "Future"
+	case Past = "Past"
+	case Unknown = "Unknown"
+
+	var rank: Int {
+		return AlertUrgency.allCases.count - 1 - AlertUrgency.allCases.firstIndex(of: self)!
+	}
+
+	static func < (lhs: AlertUrgency, rhs: AlertUrgency) -> Bool {
+		return lhs.rank < rhs.rank
+	}
+}
+
+enum AlertResponse: String, CaseIterable, Codable {
+	case Shelter = "Shelter"
+	case Evacuate = "Evacuate"
+	case Prepare = "Prepare"
+	case Execute = "Execute"
+	case Avoid = "Avoid"
+	case Monitor = "Monitor"
+	case Assess = "Assess"
+	case AllClear = "AllClear"
+	case None = "None"
+}
@@ -0,0 +1,117 @@
+// Generated on 2026-10-18 07:20:58.101235645 +0000 UTC m=+0.000272633
+// This file was generated by generative/generators/swift/geometry_encoding.go.
+// Please do not hand write.
+
+import Foundation
+
+enum CoordinateEncoding: String, CaseIterable, Codable {
+	case geojson = "geojson"
+	case polyline = "polyline"
+	case delta = "delta"
+}
+
+private let polylineFactor = 1e5
+private let deltaFactor = 1e6
+
+// Decodes the strings that replace position arrays in geometries written with a compact coordinate encoding. Positions
+// are returned as [longitude, latitude], the same order as GeoJSON positions, or nil when the string is malformed.
+enum CompactCoordinates {
+    static func decode(_ encoded: String, encoding: CoordinateEncoding) -> [[Double]]? {
+        switch encoding {
+        case .polyline:
+            return decodePolyline(encoded)
+        case .delta:
+            return decodeDelta(encoded)
+        default:
+            return nil
+        }
+    }
+
+    static func decodePolyline(_ encoded: String) -> [[Double]]? {
+        let bytes = Array(encoded.utf8)
+        var positions: [[Double]] = []
+        var index = 0
+        var latitude: Int64 = 0
+        var longitude: Int64 = 0
+
+        while index < bytes.count {
+            guard let latitudeDelta = readPolylineValue(bytes, &index),
+                  let longitudeDelta = readPolylineValue(bytes, &index) else {
+                return nil
+            }
+
+            latitude += latitudeDelta
+            longitude += longitudeDelta
+            positions.append([Double(longitude) / polylineFactor, Double(latitude) / polylineFactor])
+        }
+        return positions
+    }
+
+    static func decodeDelta(_ encoded: String) -> [[Double]]? {
+        guard let data = Data(base64Encoded: encoded) else {
+            return nil
+        }
+
+        let bytes = Array(data)
+        var positions: [[Double]] = []
+        var index = 0
+        var longitude: Int64 = 0
+        var latitude: Int64 = 0
+
+        while index < bytes.count {
+            guard let longitudeDelta = readVarint(bytes, &index),
+                  let latitudeDelta = readVarint(bytes, &index) else {
+                return nil
+            }
+
+            longitude += longitudeDelta
+            latitude += latitudeDelta
+            positions.append([Double(longitude) / deltaFactor, Double(latitude) / deltaFactor])
+        }
+        return positions
+    }
+
+    private static func readPolylineValue(_ bytes: [UInt8], _ index: inout Int) -> Int64? {
+        var zigzagged: UInt64 = 0
+        var shift: UInt64 = 0
+
+        while true {
+            guard index < bytes.count, shift <= 60, bytes[index] >= 63, bytes[index] - 63 <= 0x3f else {
+                return nil
+            }
+
+            let chunk = UInt64(bytes[index] - 63)
+            index += 1
+
+            zigzagged |= (chunk & 0x1f) << shift
+            if chunk < 0x20 {
+                return unzigzag(zigzagged)
+            }
+            shift += 5
+        }
+    }
+
+    private static func readVarint(_ bytes: [UInt8], _ index: inout Int) -> Int64? {
+        var zigzagged: UInt64 = 0
+        var shift: UInt64 = 0
+
+        while true {
+            guard index < bytes.count, shift <= 63 else {
+                return nil
+            }
+
+            let byte = UInt64(bytes[index])
+            index += 1
+
+            zigzagged |= (byte & 0x7f) << shift
+            if byte < 0x80 {
+                return unzigzag(zigzagged)
+            }
+            shift += 7
+        }
+    }
+
+    private static func unzigzag(_ value: UInt64) -> Int64 {
+        return Int64(bitPattern: value >> 1) ^ -Int64(bitPattern: value & 1)
+    }
+}
@@ -0,0 +1,266 @@
+package spatial_index
+
+import (
+	"sync"
+	"time"
+
+	"github.com/cmeyer18/weather-common/v6/data_structures"
+	"github.com/cmeyer18/weather-common/v6/data_structures/geojson_v2"
+	"github.com/cmeyer18/weather-common/v6/generative/golang"
+)
+
+// Index is an in-memory spatial index over alerts, convective outlooks and mesoscale discussions. It is safe for
+// concurrent use. Items without a geometry, such as alerts that are only described by UGC codes, are not indexed and
+// are never returned by a query; load alerts from a table created with sql.WithDerivedGeometry to index them by the
+// boundaries of their codes.
+type Index struct {
+	mutex   sync.RWMutex
+	tree    *rTree[*indexEntry]
+	entries map[data_structures.NotificationUpdateV2][]*indexEntry
+}
+
+// Results holds the items matched by a query, in no particular order.
+type Results struct {
+	Alerts               []data_structures.AlertV2
+	ConvectiveOutlooks   []data_structures.ConvectiveOutlookV2
+	MesoscaleDiscussions []data_structures.MesoscaleDiscussionV2
+}
+
+type indexEntry struct {
+	key                 data_structures.NotificationUpdateV2
+	bboxes              []geojson_v2.BBox
+	geometry            *geojson_v2.Geometry
+	expires             *time.Time
+	alert               *data_structures.AlertV2
+	convectiveOutlook   *data_structures.ConvectiveOutlookV2
+	mesoscaleDiscussion *data_structures.MesoscaleDiscussionV2
+}
+
+// NewIndex builds an index packed from the active alerts, the results of SelectAllLatest on the convective outlook
+// table and the results of SelectLatest on the mesoscale discussion table.
+func NewIndex(
+	alerts []data_structures.AlertV2,
+	convectiveOutlooks map[golang.ConvectiveOutlookType][]data_structures.ConvectiveOutlookV2,
+	mesoscaleDiscussions []data_structures.MesoscaleDiscussionV2,
+) *Index {
+	index := &Index{
+		tree:    newRTree[*indexEntry](),
+		entries: make(map[data_structures.NotificationUpdateV2][]*indexEntry),
+	}
+
+	var entries []*indexEntry
+	for _, alert := range alerts {
+		entries = append(entries, newAlertEntry(alert))
+	}
+	for _, outlooks := range convectiveOutlooks {
+		for _, outlook := range outlooks {
+			entries = append(entries, newConvectiveOutlookEntry(outlook))
+		}
+	}
+	for _, md := range mesoscaleDiscussions {
+		entries = append(entries, newMesoscaleDiscussionEntry(md))
+	}
+
+	var treeEntries []rTreeEntry[*indexEntry]
+	for _, entry := range entries {
+		if entry == nil {
+			continue
+		}
+
+		index.entries[entry.key] = append(index.entries[entry.key], entry)
+		for _, bbox := range entry.bboxes {
+			treeEntries = append(treeEntries, rTreeEntry[*indexEntry]{bbox: bbox, value: entry})
+		}
+	}
+	index.tree.load(treeEntries)
+
+	return index
+}
+
+// Len returns the number of indexed geometries.
+func (i *Index) Len() int {
+	i.mutex.RLock()
+	defer i.mutex.RUnlock()
+
+	count := 0
+	for _, entries := range i.entries {
+		count += len(entries)
+	}
+	return count
+}
+
+// Covering returns the items whose geometry contains the point.
+func (i *Index) Covering(point geojson_v2.Point) Results {
+	i.mutex.RLock()
+	defer i.mutex.RUnlock()
+
+	var results Results
+	i.search(*point.BBox(), func(entry *indexEntry) {
+		if entry.geometry.Contains(point) {
+			results.add(entry)
+		}
+	})
+	return results
+}
+
+// Intersecting returns the items whose geometry touches or overlaps the box.
+func (i *Index) Intersecting(bbox geojson_v2.BBox) Results {
+	i.mutex.RLock()
+	defer i.mutex.RUnlock()
+
+	var results Results
+	i.search(bbox, func(entry *indexEntry) {
+		if entry.geometry.IntersectsBBox(bbox) {
+			results.add(entry)
+		}
+	})
+	return results
+}
+
+// InsertAlert adds the alert to the index, replacing any alert with the same id.
+func (i *Index) InsertAlert(alert data_structures.AlertV2) {
+	i.replace(data_structures.NotificationUpdateV2{Id: alert.ID, NotificationType: data_structures.AlertType}, []*indexEntry{newAlertEntry(alert)})
+}
+
+// InsertConvectiveOutlooks adds the outlooks to the index, replacing all previously indexed outlooks sharing an id
+// with any of them.
+func (i *Index) InsertConvectiveOutlooks(outlooks []data_structures.ConvectiveOutlookV2) {
+	entriesByKey := make(map[data_structures.NotificationUpdateV2][]*indexEntry)
+	for _, outlook := range outlooks {
+		key := data_structures.NotificationUpdateV2{Id: outlook.ID, NotificationType: data_structures.ConvectiveOutlookType}
+		entriesByKey[key] = append(entriesByKey[key], newConvectiveOutlookEntry(outlook))
+	}
+
+	for key, entries := range entriesByKey {
+		i.replace(key, entries)
+	}
+}
+
+// InsertMesoscaleDiscussion adds the mesoscale discussion to the index, replacing any mesoscale discussion with the
+// same id.
+func (i *Index) InsertMesoscaleDiscussion(md data_structures.MesoscaleDiscussionV2) {
+	i.replace(data_structures.NotificationUpdateV2{Id: md.ID, NotificationType: data_structures.MesoscaleDiscussionType}, []*indexEntry{newMesoscaleDiscussionEntry(md)})
+}
+
+// Remove removes every item matching the id and type of the update.
+func (i *Index) Remove(update data_structures.NotificationUpdateV2) {
+	i.replace(update, nil)
+}
+
+// RemoveExpired removes every item that expired before the given time.
+func (i *Index) RemoveExpired(now time.Time) {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	for key, entries := range i.entries {
+		var kept []*indexEntry
+		for _, entry := range entries {
+			if entry.expires != nil && entry.expires.Before(now) {
+				i.removeEntry(entry)
+			} else {
+				kept = append(kept, entry)
+			}
+		}
+
+		if len(kept) == 0 {
+			delete(i.entries, key)
+		} else {
+			i.entries[key] = kept
+		}
+	}
+}
+
+func (i *Index) replace(key data_structures.NotificationUpdateV2, entries []*indexEntry) {
+	i.mutex.Lock()
+	defer i.mutex.Unlock()
+
+	for _, entry := range i.entries[key] {
+		i.removeEntry(entry)
+	}
+	delete(i.entries, key)
+
+	for _, entry := range entries {
+		if entry == nil {
+			continue
+		}
+
+		i.entries[key] = append(i.entries[key], entry)
+		for _, bbox := range entry.bboxes {
+			i.tree.insert(bbox, entry)
+		}
+	}
+}
+
+func (i *Index) removeEntry(entry *indexEntry) {
+	for _, bbox := range entry.bboxes {
+		i.tree.remove(bbox, entry)
+	}
+}
+
+// search visits every entry whose boxes intersect the box once, splitting boxes that cross the antimeridian.
+func (i *Index) search(bbox geojson_v2.BBox, visit func(entry *indexEntry)) {
+	seen := make(map[*indexEntry]bool)
+	for _, part := range bbox.Split() {
+		i.tree.search(part, func(entry *indexEntry) {
+			if !seen[entry] {
+				seen[entry] = true
+				visit(entry)
+			}
+		})
+	}
+}
+
+func (r *Results) add(entry *indexEntry) {
+	if entry.alert != nil {
+		r.Alerts = append(r.Alerts, *entry.alert)
+	} else if entry.convectiveOutlook != nil {
+		r.ConvectiveOutlooks = append(r.ConvectiveOutlooks, *entry.convectiveOutlook)
+	} else if entry.mesoscaleDiscussion != nil {
+		r.MesoscaleDiscussions = append(r.MesoscaleDiscussions, *entry.mesoscaleDiscussion)
+	}
+}
+
+func newAlertEntry(alert data_structures.AlertV2) *indexEntry {
+	bbox := alert.Geometry.BBox()
+	if bbox == nil {
+		return nil
+	}
+
+	return &indexEntry{
+		key:      data_structures.NotificationUpdateV2{Id: alert.ID, NotificationType: data_structures.AlertType},
+		bboxes:   bbox.Split(),
+		geometry: alert.Geometry,
+		expires:  &alert.Expires,
+		alert:    &alert,
+	}
+}
+
+func newConvectiveOutlookEntry(outlook data_structures.ConvectiveOutlookV2) *indexEntry {
+	bbox := outlook.Geometry.BBox()
+	if bbox == nil {
+		return nil
+	}
+
+	return &indexEntry{
+		key:               data_structures.NotificationUpdateV2{Id: outlook.ID, NotificationType: data_structures.ConvectiveOutlookType},
+		bboxes:            bbox.Split(),
+		geometry:          outlook.Geometry,
+		expires:           &outlook.Expires,
+		convectiveOutlook: &outlook,
+	}
+}
+
+func newMesoscaleDiscussionEntry(md data_structures.MesoscaleDiscussionV2) *indexEntry {
+	bbox := md.Geometry.BBox()
+	if bbox == nil {
+		return nil
+	}
+
+	return &indexEntry{
+		key:                 data_structures.NotificationUpdateV2{Id: md.ID, NotificationType: data_structures.MesoscaleDiscussionType},
+		bboxes:              bbox.Split(),
+		geometry:            md.Geometry,
+		expires:             md.Expires,
+		mesoscaleDiscussion: &md,
+	}
+}
@@ -0,0 +1,132 @@
+package spatial_index
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/cmeyer18/weather-common/v6/data_structures"
+	"github.com/cmeyer18/weather-common/v6/data_structures/geojson_v2"
+	"github.com/cmeyer18/weather-common/v6/generative/golang"
+)
+
+func mustGeometry(t *testing.T, data string) *geojson_v2.Geometry {
+	t.Helper()
+
+	var geometry geojson_v2.Geometry
+	err := json.Unmarshal([]byte(data), &geometry)
+	if err != nil {
+		t.Fatalf("invalid geometry %s: %v", data, err)
+	}
+	return &geometry
+}
+
+// square returns a polygon covering one degree north east of the position.
+func square(t *testing.T, longitude, latitude float64) *geojson_v2.Geometry {
+	t.Helper()
+
+	geometry := &geojson_v2.Geometry{Polygon: &geojson_v2.Polygon{OuterPath: &geojson_v2.MultiPoint{Points: []*geojson_v2.Point{
+		{Longitude: longitude, Latitude: latitude},
+		{Longitude: longitude + 1, Latitude: latitude},
+		{Longitude: longitude + 1, Latitude: latitude + 1},
+		{Longitude: longitude, Latitude: latitude + 1},
+		{Longitude: longitude, Latitude: latitude},
+	}}}}
+	if err := geometry.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	return geometry
+}
+
+func alertIDs(results Results) map[string]bool {
+	ids := make(map[string]bool)
+	for _, alert := range results.Alerts {
+		ids[alert.ID] = true
+	}
+	return ids
+}
+
+func TestIndex(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	expires := now.Add(time.Hour)
+
+	index := NewIndex(
+		[]data_structures.AlertV2{
+			{ID: "kansas", Geometry: square(t, -98, 38), Expires: expires},
+			{ID: "ugc only", Expires: expires},
+			{ID: "aleutians", Expires: expires, Geometry: mustGeometry(t,
+				`{"type":"Polygon","coordinates":[[[179,51],[-179,51],[-179,52],[179,52],[179,51]]]}`)},
+		},
+		map[golang.ConvectiveOutlookType][]data_structures.ConvectiveOutlookV2{
+			golang.Day1Categorical: {{ID: "day1", Geometry: square(t, -98, 38), Expires: expires}},
+		},
+		[]data_structures.MesoscaleDiscussionV2{{ID: "md", Geometry: square(t, -90, 30)}},
+	)
+
+	if index.Len() != 4 {
+		t.Errorf("Len() = %d, want 4 without the alert lacking a geometry", index.Len())
+	}
+
+	results := index.Covering(geojson_v2.Point{Longitude: -97.5, Latitude: 38.5})
+	if ids := alertIDs(results); len(ids) != 1 || !ids["kansas"] {
+		t.Errorf("covering alerts = %v", ids)
+	}
+	if len(results.ConvectiveOutlooks) != 1 || len(results.MesoscaleDiscussions) != 0 {
+		t.Errorf("covering results = %+v", results)
+	}
+
+	if ids := alertIDs(index.Covering(geojson_v2.Point{Longitude: -179.5, Latitude: 51.5})); !ids["aleutians"] {
+		t.Error("alert split at the antimeridian was not found east of it")
+	}
+	if ids := alertIDs(index.Covering(geojson_v2.Point{Longitude: 179.5, Latitude: 51.5})); !ids["aleutians"] {
+		t.Error("alert split at the antimeridian was not found west of it")
+	}
+
+	wrapping := geojson_v2.BBox{MinLongitude: 179.8, MinLatitude: 51.2, MaxLongitude: -179.8, MaxLatitude: 51.4}
+	if results := index.Intersecting(wrapping); len(results.Alerts) != 1 {
+		t.Errorf("box across the antimeridian matched %d alerts, want 1", len(results.Alerts))
+	}
+
+	results = index.Intersecting(geojson_v2.BBox{MinLongitude: -91, MinLatitude: 29, MaxLongitude: -89.5, MaxLatitude: 30.5})
+	if len(results.MesoscaleDiscussions) != 1 || len(results.Alerts) != 0 {
+		t.Errorf("intersecting results = %+v", results)
+	}
+}
+
+func TestIndexUpdates(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	index := NewIndex(nil, nil, nil)
+
+	index.InsertAlert(data_structures.AlertV2{ID: "moving", Geometry: square(t, -98, 38), Expires: now.Add(time.Hour)})
+	index.InsertAlert(data_structures.AlertV2{ID: "moving", Geometry: square(t, -90, 38), Expires: now.Add(time.Hour)})
+	index.InsertAlert(data_structures.AlertV2{ID: "expiring", Geometry: square(t, -90, 38), Expires: now.Add(-time.Minute)})
+
+	if ids := alertIDs(index.Covering(geojson_v2.Point{Longitude: -97.5, Latitude: 38.5})); len(ids) != 0 {
+		t.Errorf("replaced alert still found at its old geometry: %v", ids)
+	}
+	if ids := alertIDs(index.Covering(geojson_v2.Point{Longitude: -89.5, Latitude: 38.5})); !ids["moving"] || !ids["expiring"] {
+		t.Errorf("covering alerts = %v", ids)
+	}
+
+	index.RemoveExpired(now)
+	if ids := alertIDs(index.Covering(geojson_v2.Point{Longitude: -89.5, Latitude: 38.5})); len(ids) != 1 || !ids["moving"] {
+		t.Errorf("after removing expired alerts = %v", ids)
+	}
+
+	index.InsertConvectiveOutlooks([]data_structures.ConvectiveOutlookV2{
+		{ID: "day1", Geometry: square(t, -98, 38), Expires: now.Add(time.Hour)},
+		{ID: "day1", Geometry: square(t, -96, 38), Expires: now.Add(time.Hour)},
+	})
+	index.InsertConvectiveOutlooks([]data_structures.ConvectiveOutlookV2{
+		{ID: "day1", Geometry: square(t, -96, 38), Expires: now.Add(time.Hour)},
+	})
+	if results := index.Covering(geojson_v2.Point{Longitude: -97.5, Latitude: 38.5}); len(results.ConvectiveOutlooks) != 0 {
+		t.Error("outlooks sharing the id were not all replaced")
+	}
+
+	index.Remove(data_structures.NotificationUpdateV2{Id: "moving", NotificationType: data_structures.AlertType})
+	index.Remove(data_structures.NotificationUpdateV2{Id: "day1", NotificationType: data_structures.ConvectiveOutlookType})
+	if index.Len() != 0 {
+		t.Errorf("Len() = %d after removing everything", index.Len())
+	}
+}
@@ -0,0 +1,320 @@
+package spatial_index
+
+import (
+	"cmp"
+	"math"
+	"slices"
+
+	"github.com/cmeyer18/weather-common/v6/data_structures/geojson_v2"
+)
+
+const (
+	maxNodeEntries = 16
+	minNodeEntries = 6
+)
+
+type rTreeEntry[T comparable] struct {
+	bbox  geojson_v2.BBox
+	child *rTreeNode[T]
+	value T
+}
+
+type rTreeNode[T comparable] struct {
+	leaf    bool
+	entries []rTreeEntry[T]
+}
+
+// rTree is an R-tree using quadratic splits for inserts and Sort-Tile-Recursive packing for bulk loads.
+type rTree[T comparable] struct {
+	root *rTreeNode[T]
+	size int
+}
+
+func newRTree[T comparable]() *rTree[T] {
+	return &rTree[T]{root: &rTreeNode[T]{leaf: true}}
+}
+
+// load replaces the contents of the tree with the entries, packing them with Sort-Tile-Recursive.
+func (t *rTree[T]) load(entries []rTreeEntry[T]) {
+	t.size = len(entries)
+	if len(entries) == 0 {
+		t.root = &rTreeNode[T]{leaf: true}
+		return
+	}
+
+	leaf := true
+	for {
+		nodes := packEntries(entries, leaf)
+		if len(nodes) == 1 {
+			t.root = nodes[0]
+			return
+		}
+
+		entries = make([]rTreeEntry[T], len(nodes))
+		for i, node := range nodes {
+			entries[i] = rTreeEntry[T]{bbox: node.bbox(), child: node}
+		}
+		leaf = false
+	}
+}
+
+// packEntries tiles the entries into slices sorted by longitude then latitude and groups each run into a node.
+func packEntries[T comparable](entries []rTreeEntry[T], leaf bool) []*rTreeNode[T] {
+	nodeCount := int(math.Ceil(float64(len(entries)) / maxNodeEntries))
+	sliceCount := int(math.Ceil(math.Sqrt(float64(nodeCount))))
+	sliceSize := sliceCount * maxNodeEntries
+
+	entries = slices.Clone(entries)
+	slices.SortFunc(entries, func(a, b rTreeEntry[T]) int {
+		return cmp.Compare(a.bbox.MinLongitude+a.bbox.MaxLongitude, b.bbox.MinLongitude+b.bbox.MaxLongitude)
+	})
+
+	var nodes []*rTreeNode[T]
+	for start := 0; start < len(entries); start += sliceSize {
+		tile := entries[start:min(start+sliceSize, len(entries))]
+		slices.SortFunc(tile, func(a, b rTreeEntry[T]) int {
+			return cmp.Compare(a.bbox.MinLatitude+a.bbox.MaxLatitude, b.bbox.MinLatitude+b.bbox.MaxLatitude)
+		})
+
+		for i := 0; i < len(tile); i += maxNodeEntries {
+			group := tile[i:min(i+maxNodeEntries, len(tile))]
+			nodes = append(nodes, &rTreeNode[T]{leaf: leaf, entries: slices.Clone(group)})
+		}
+	}
+
+	return nodes
+}
+
+// search calls visit with every value whose box intersects the given box.
+func (t *rTree[T]) search(bbox geojson_v2.BBox, visit func(value T)) {
+	t.root.search(bbox, visit)
+}
+
+func (n *rTreeNode[T]) search(bbox geojson_v2.BBox, visit func(value T)) {
+	for _, entry := range n.entries {
+		if !entry.bbox.Intersects(bbox) {
+			continue
+		}
+
+		if n.leaf {
+			visit(entry.value)
+		} else {
+			entry.child.search(bbox, visit)
+		}
+	}
+}
+
+func (t *rTree[T]) insert(bbox geojson_v2.BBox, value T) {
+	t.insertEntry(rTreeEntry[T]{bbox: bbox, value: value})
+	t.size++
+}
+
+func (t *rTree[T]) insertEntry(entry rTreeEntry[T]) {
+	sibling := t.root.insert(entry)
+	if sibling != nil {
+		t.root = &rTreeNode[T]{
+			entries: []rTreeEntry[T]{
+				{bbox: t.root.bbox(), child: t.root},
+				{bbox: sibling.bbox(), child: sibling},
+			},
+		}
+	}
+}
+
+// insert adds the entry below the node, returning the new sibling when the node had to be split.
+func (n *rTreeNode[T]) insert(entry rTreeEntry[T]) *rTreeNode[T] {
+	if n.leaf {
+		n.entries = append(n.entries, entry)
+	} else {
+		i := n.chooseSubtree(entry.bbox)
+		child := n.entries[i].child
+		sibling := child.insert(entry)
+		n.entries[i].bbox = child.bbox()
+		if sibling != nil {
+			n.entries = append(n.entries, rTreeEntry[T]{bbox: sibling.bbox(), child: sibling})
+		}
+	}
+
+	if len(n.entries) > maxNodeEntries {
+		return n.split()
+	}
+	return nil
+}
+
+// chooseSubtree returns the entry needing the least enlargement to cover the box, preferring smaller entries on ties.
+func (n *rTreeNode[T]) chooseSubtree(bbox geojson_v2.BBox) int {
+	best := 0
+	bestEnlargement := math.Inf(1)
+	bestArea := math.Inf(1)
+	for i, entry := range n.entries {
+		area := bboxArea(entry.bbox)
+		enlargement := bboxArea(bboxUnion(entry.bbox, bbox)) - area
+		if enlargement < bestEnlargement || (enlargement == bestEnlargement && area < bestArea) {
+			best = i
+			bestEnlargement = enlargement
+			bestArea = area
+		}
+	}
+	return best
+}
+
+// split divides the entries of the node using Guttman's quadratic split, keeping one group in the node and returning
+// the other as a new node.
+func (n *rTreeNode[T]) split() *rTreeNode[T] {
+	entries := n.entries
+
+	seedA, seedB := 0, 1
+	worstWaste := math.Inf(-1)
+	for i := range entries {
+		for j := i + 1; j < len(entries); j++ {
+			waste := bboxArea(bboxUnion(entries[i].bbox, entries[j].bbox)) - bboxArea(entries[i].bbox) - bboxArea(entries[j].bbox)
+			if waste > worstWaste {
+				seedA, seedB = i, j
+				worstWaste = waste
+			}
+		}
+	}
+
+	groupA := []rTreeEntry[T]{entries[seedA]}
+	groupB := []rTreeEntry[T]{entries[seedB]}
+	bboxA := entries[seedA].bbox
+	bboxB := entries[seedB].bbox
+
+	var remaining []rTreeEntry[T]
+	for i, entry := range entries {
+		if i != seedA && i != seedB {
+			remaining = append(remaining, entry)
+		}
+	}
+
+	for len(remaining) > 0 {
+		if len(groupA)+len(remaining) <= minNodeEntries {
+			groupA = append(groupA, remaining...)
+			break
+		} else if len(groupB)+len(remaining) <= minNodeEntries {
+			groupB = append(groupB, remaining...)
+			break
+		}
+
+		next := 0
+		greatestDifference := math.Inf(-1)
+		for i, entry := range remaining {
+			difference := math.Abs(bboxEnlargement(bboxA, entry.bbox) - bboxEnlargement(bboxB, entry.bbox))
+			if difference > greatestDifference {
+				next = i
+				greatestDifference = difference
+			}
+		}
+
+		entry := remaining[next]
+		remaining = slices.Delete(remaining, next, next+1)
+
+		enlargementA := bboxEnlargement(bboxA, entry.bbox)
+		enlargementB := bboxEnlargement(bboxB, entry.bbox)
+		if enlargementA < enlargementB ||
+			(enlargementA == enlargementB && bboxArea(bboxA) < bboxArea(bboxB)) ||
+			(enlargementA == enlargementB && bboxArea(bboxA) == bboxArea(bboxB) && len(groupA) <= len(groupB)) {
+			groupA = append(groupA, entry)
+			bboxA = bboxUnion(bboxA, entry.bbox)
+		} else {
+			groupB = append(groupB, entry)
+			bboxB = bboxUnion(bboxB, entry.bbox)
+		}
+	}
+
+	n.entries = groupA
+	return &rTreeNode[T]{leaf: n.leaf, entries: groupB}
+}
+
+// remove deletes the value stored with the box, reporting whether it was found.
+func (t *rTree[T]) remove(bbox geojson_v2.BBox, value T) bool {
+	var orphans []rTreeEntry[T]
+	if !t.root.remove(bbox, value, &orphans) {
+		return false
+	}
+	t.size--
+
+	for !t.root.leaf && len(t.root.entries) == 1 {
+		t.root = t.root.entries[0].child
+	}
+	if !t.root.leaf && len(t.root.entries) == 0 {
+		t.root = &rTreeNode[T]{leaf: true}
+	}
+
+	for _, orphan := range orphans {
+		t.insertEntry(orphan)
+	}
+
+	return true
+}
+
+// remove deletes the value from below the node. Children left with too few entries are dissolved and their values
+// collected into orphans for reinsertion.
+func (n *rTreeNode[T]) remove(bbox geojson_v2.BBox, value T, orphans *[]rTreeEntry[T]) bool {
+	for i, entry := range n.entries {
+		if !entry.bbox.Intersects(bbox) {
+			continue
+		}
+
+		if n.leaf {
+			if entry.value == value {
+				n.entries = slices.Delete(n.entries, i, i+1)
+				return true
+			}
+			continue
+		}
+
+		if !entry.child.remove(bbox, value, orphans) {
+			continue
+		}
+
+		if len(entry.child.entries) < minNodeEntries {
+			entry.child.collect(orphans)
+			n.entries = slices.Delete(n.entries, i, i+1)
+		} else {
+			n.entries[i].bbox = entry.child.bbox()
+		}
+		return true
+	}
+
+	return false
+}
+
+// collect appends every leaf entry below the node.
+func (n *rTreeNode[T]) collect(entries *[]rTreeEntry[T]) {
+	if n.leaf {
+		*entries = append(*entries, n.entries...)
+		return
+	}
+
+	for _, entry := range n.entries {
+		entry.child.collect(entries)
+	}
+}
+
+func (n *rTreeNode[T]) bbox() geojson_v2.BBox {
+	bbox := n.entries[0].bbox
+	for _, entry := range n.entries[1:] {
+		bbox = bboxUnion(bbox, entry.bbox)
+	}
+	return bbox
+}
+
+// bboxUnion returns the planar box covering both boxes. Entries never cross the antimeridian, so unlike BBox.Extend it
+// never wraps.
+func bboxUnion(bbox geojson_v2.BBox, other geojson_v2.BBox) geojson_v2.BBox {
+	return geojson_v2.BBox{
+		MinLongitude: min(bbox.MinLongitude, other.MinLongitude),
+		MinLatitude:  min(bbox.MinLatitude, other.MinLatitude),
+		MaxLongitude: max(bbox.MaxLongitude, other.MaxLongitude),
+		MaxLatitude:  max(bbox.MaxLatitude, other.MaxLatitude),
+	}
+}
+
+func bboxArea(bbox geojson_v2.BBox) float64 {
+	return (bbox.MaxLongitude - bbox.MinLongitude) * (bbox.MaxLatitude - bbox.MinLatitude)
+}
+
+func bboxEnlargement(bbox geojson_v2.BBox, other geojson_v2.BBox) float64 {
+	return bboxArea(bboxUnion(bbox, other)) - bboxArea(bbox)
+}
@@ -0,0 +1,210 @@
+package spatial_index
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/cmeyer18/weather-common/v6/data_structures/geojson_v2"
+)
+
+// testBoxes returns count small boxes scattered deterministically over the contiguous United States.
+func testBoxes(count int) []geojson_v2.BBox {
+	random := rand.New(rand.NewSource(1))
+	boxes := make([]geojson_v2.BBox, count)
+	for i := range boxes {
+		longitude := -125 + random.Float64()*58
+		latitude := 24 + random.Float64()*25
+		boxes[i] = geojson_v2.BBox{
+			MinLongitude: longitude,
+			MinLatitude:  latitude,
+			MaxLongitude: longitude + random.Float64(),
+			MaxLatitude:  latitude + random.Float64(),
+		}
+	}
+	return boxes
+}
+
+// checkRTree verifies that every node is within capacity, every entry box covers its child, and every leaf is at the
+// same depth, returning the number of values in the tree.
+func checkRTree(t *testing.T, tree *rTree[int], checkMinimum bool) int {
+	t.Helper()
+
+	leafDepth := -1
+	var check func(node *rTreeNode[int], depth int) int
+	check = func(node *rTreeNode[int], depth int) int {
+		if len(node.entries) > maxNodeEntries {
+			t.Errorf("node at depth %d has %d entries", depth, len(node.entries))
+		}
+		if checkMinimum && node != tree.root && len(node.entries) < minNodeEntries {
+			t.Errorf("node at depth %d has %d entries", depth, len(node.entries))
+		}
+
+		if node.leaf {
+			if leafDepth >= 0 && leafDepth != depth {
+				t.Errorf("leaf at depth %d, want %d", depth, leafDepth)
+			}
+			leafDepth = depth
+			return len(node.entries)
+		}
+
+		count := 0
+		for _, entry := range node.entries {
+			if entry.bbox != entry.child.bbox() {
+				t.Errorf("entry box %+v does not match child box %+v", entry.bbox, entry.child.bbox())
+			}
+			count += check(entry.child, depth+1)
+		}
+		return count
+	}
+
+	count := check(tree.root, 0)
+	if count != tree.size {
+		t.Errorf("tree holds %d values, size is %d", count, tree.size)
+	}
+	return count
+}
+
+// checkRTreeSearch compares searches of the tree against a scan of the boxes still present.
+func checkRTreeSearch(t *testing.T, tree *rTree[int], boxes []geojson_v2.BBox, present func(int) bool) {
+	t.Helper()
+
+	for _, query := range []geojson_v2.BBox{
+		{MinLongitude: -100, MinLatitude: 35, MaxLongitude: -95, MaxLatitude: 40},
+		{MinLongitude: -125, MinLatitude: 24, MaxLongitude: -66, MaxLatitude: 50},
+		{MinLongitude: -80.5, MinLatitude: 30, MaxLongitude: -80.4, MaxLatitude: 30.1},
+		{MinLongitude: 10, MinLatitude: 10, MaxLongitude: 20, MaxLatitude: 20},
+	} {
+		var want []int
+		for i, bbox := range boxes {
+			if present(i) && bbox.Intersects(query) {
+				want = append(want, i)
+			}
+		}
+
+		var got []int
+		tree.search(query, func(value int) {
+			got = append(got, value)
+		})
+		slices.Sort(got)
+
+		if !slices.Equal(got, want) {
+			t.Errorf("search %+v = %d values, want %d", query, len(got), len(want))
+		}
+	}
+}
+
+func TestRTreeLoad(t *testing.T) {
+	boxes := testBoxes(1000)
+	entries := make([]rTreeEntry[int], len(boxes))
+	for i, bbox := range boxes {
+		entries[i] = rTreeEntry[int]{bbox: bbox, value: i}
+	}
+
+	tree := newRTree[int]()
+	tree.load(entries)
+
+	if count := checkRTree(t, tree, false); count != len(boxes) {
+		t.Fatalf("loaded %d values, want %d", count, len(boxes))
+	}
+	if tree.root.leaf {
+		t.Error("expected a packed tree with more than one level")
+	}
+	checkRTreeSearch(t, tree, boxes, func(int) bool { return true })
+
+	tree.load(nil)
+	if checkRTree(t, tree, false) != 0 || !tree.root.leaf {
+		t.Error("loading no entries did not empty the tree")
+	}
+}
+
+func TestRTreeInsertSplits(t *testing.T) {
+	boxes := testBoxes(500)
+
+	tree := newRTree[int]()
+	for i, bbox := range boxes {
+		tree.insert(bbox, i)
+	}
+
+	if count := checkRTree(t, tree, true); count != len(boxes) {
+		t.Fatalf("inserted %d values, want %d", count, len(boxes))
+	}
+	if tree.root.leaf {
+		t.Error("expected the root to have split")
+	}
+	checkRTreeSearch(t, tree, boxes, func(int) bool { return true })
+}
+
+func TestRTreeQuadraticSplit(t *testing.T) {
+	// Two clusters far apart must end up in separate nodes.
+	node := &rTreeNode[int]{leaf: true}
+	for i := 0; i <= maxNodeEntries; i++ {
+		offset := 0.0
+		if i%2 == 1 {
+			offset = 100
+		}
+		bbox := geojson_v2.BBox{MinLongitude: offset + float64(i)/10, MinLatitude: 0, MaxLongitude: offset + float64(i)/10 + 0.05, MaxLatitude: 1}
+		node.entries = append(node.entries, rTreeEntry[int]{bbox: bbox, value: i})
+	}
+
+	sibling := node.split()
+
+	for _, group := range [][]rTreeEntry[int]{node.entries, sibling.entries} {
+		if len(group) < minNodeEntries {
+			t.Errorf("group has %d entries, want at least %d", len(group), minNodeEntries)
+		}
+		for _, entry := range group[1:] {
+			if entry.value%2 != group[0].value%2 {
+				t.Errorf("clusters were mixed: %d and %d", group[0].value, entry.value)
+			}
+		}
+	}
+	if len(node.entries)+len(sibling.entries) != maxNodeEntries+1 {
+		t.Errorf("split kept %d entries", len(node.entries)+len(sibling.entries))
+	}
+}
+
+func TestRTreeRemove(t *testing.T) {
+	boxes := testBoxes(600)
+	entries := make([]rTreeEntry[int], len(boxes))
+	for i, bbox := range boxes {
+		entries[i] = rTreeEntry[int]{bbox: bbox, value: i}
+	}
+
+	tree := newRTree[int]()
+	tree.load(entries)
+
+	// Removing two of every three values leaves most packed nodes under the minimum, dissolving them.
+	removed := make(map[int]bool)
+	for i := range boxes {
+		if i%3 == 2 {
+			continue
+		}
+
+		if !tree.remove(boxes[i], i) {
+			t.Fatalf("value %d was not found", i)
+		}
+		removed[i] = true
+	}
+
+	if count := checkRTree(t, tree, false); count != len(boxes)-len(removed) {
+		t.Fatalf("tree holds %d values, want %d", count, len(boxes)-len(removed))
+	}
+	checkRTreeSearch(t, tree, boxes, func(i int) bool { return !removed[i] })
+
+	if tree.remove(boxes[0], 0) {
+		t.Error("removed a value twice")
+	}
+	if tree.remove(geojson_v2.BBox{MinLongitude: 0, MinLatitude: 0, MaxLongitude: 1, MaxLatitude: 1}, 2) {
+		t.Error("removed a value stored under a different box")
+	}
+
+	for i := range boxes {
+		if !removed[i] {
+			tree.remove(boxes[i], i)
+		}
+	}
+	if checkRTree(t, tree, false) != 0 || !tree.root.leaf {
+		t.Error("removing every value did not empty the tree")
+	}
+}
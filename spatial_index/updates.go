@@ -0,0 +1,59 @@
+package spatial_index
+
+import (
+	databaseSql "database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/cmeyer18/weather-common/v6/data_structures"
+	"github.com/cmeyer18/weather-common/v6/sql"
+)
+
+// Tables are the tables an index reads from when applying notification updates.
+type Tables struct {
+	Alerts               sql.IAlertV2Table
+	ConvectiveOutlooks   sql.IConvectiveOutlookTableV2
+	MesoscaleDiscussions sql.IMesoscaleDiscussionV2Table
+}
+
+// Apply refreshes the item named by the update from the tables, removing it from the index when it no longer exists.
+func (i *Index) Apply(update data_structures.NotificationUpdateV2, tables Tables) error {
+	switch update.NotificationType {
+	case data_structures.AlertType:
+		alert, err := tables.Alerts.Select(update.Id)
+		if err != nil {
+			return err
+		}
+
+		if alert == nil {
+			i.Remove(update)
+		} else {
+			i.InsertAlert(*alert)
+		}
+	case data_structures.ConvectiveOutlookType:
+		outlooks, err := tables.ConvectiveOutlooks.SelectById(update.Id)
+		if err != nil {
+			return err
+		}
+
+		if len(outlooks) == 0 {
+			i.Remove(update)
+		} else {
+			i.InsertConvectiveOutlooks(outlooks)
+		}
+	case data_structures.MesoscaleDiscussionType:
+		md, err := tables.MesoscaleDiscussions.SelectById(update.Id)
+		if errors.Is(err, databaseSql.ErrNoRows) {
+			i.Remove(update)
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		i.InsertMesoscaleDiscussion(*md)
+	default:
+		return fmt.Errorf("unsupported notification type: %s", update.NotificationType)
+	}
+
+	return nil
+}
@@ -0,0 +1,116 @@
+package spatial_index
+
+import (
+	databaseSql "database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/cmeyer18/weather-common/v6/data_structures"
+	"github.com/cmeyer18/weather-common/v6/data_structures/geojson_v2"
+	"github.com/cmeyer18/weather-common/v6/sql"
+)
+
+type fakeAlertTable struct {
+	sql.IAlertV2Table
+	alerts map[string]data_structures.AlertV2
+	err    error
+}
+
+func (f *fakeAlertTable) Select(id string) (*data_structures.AlertV2, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	alert, ok := f.alerts[id]
+	if !ok {
+		return nil, nil
+	}
+	return &alert, nil
+}
+
+type fakeConvectiveOutlookTable struct {
+	sql.IConvectiveOutlookTableV2
+	outlooks map[string][]data_structures.ConvectiveOutlookV2
+}
+
+func (f *fakeConvectiveOutlookTable) SelectById(id string) ([]data_structures.ConvectiveOutlookV2, error) {
+	return f.outlooks[id], nil
+}
+
+type fakeMesoscaleDiscussionTable struct {
+	sql.IMesoscaleDiscussionV2Table
+	mds map[string]data_structures.MesoscaleDiscussionV2
+}
+
+func (f *fakeMesoscaleDiscussionTable) SelectById(id string) (*data_structures.MesoscaleDiscussionV2, error) {
+	md, ok := f.mds[id]
+	if !ok {
+		return nil, databaseSql.ErrNoRows
+	}
+	return &md, nil
+}
+
+func TestApply(t *testing.T) {
+	expires := time.Now().Add(time.Hour)
+	alerts := &fakeAlertTable{alerts: map[string]data_structures.AlertV2{
+		"alert": {ID: "alert", Geometry: square(t, -98, 38), Expires: expires},
+	}}
+	outlooks := &fakeConvectiveOutlookTable{outlooks: map[string][]data_structures.ConvectiveOutlookV2{
+		"outlook": {{ID: "outlook", Geometry: square(t, -98, 38), Expires: expires}},
+	}}
+	mds := &fakeMesoscaleDiscussionTable{mds: map[string]data_structures.MesoscaleDiscussionV2{
+		"md": {ID: "md", Geometry: square(t, -98, 38)},
+	}}
+	tables := Tables{Alerts: alerts, ConvectiveOutlooks: outlooks, MesoscaleDiscussions: mds}
+
+	updates := []data_structures.NotificationUpdateV2{
+		{Id: "alert", NotificationType: data_structures.AlertType},
+		{Id: "outlook", NotificationType: data_structures.ConvectiveOutlookType},
+		{Id: "md", NotificationType: data_structures.MesoscaleDiscussionType},
+	}
+
+	index := NewIndex(nil, nil, nil)
+	for _, update := range updates {
+		err := index.Apply(update, tables)
+		if err != nil {
+			t.Fatalf("apply %+v: %v", update, err)
+		}
+	}
+
+	point := geojson_v2.Point{Longitude: -97.5, Latitude: 38.5}
+	results := index.Covering(point)
+	if len(results.Alerts) != 1 || len(results.ConvectiveOutlooks) != 1 || len(results.MesoscaleDiscussions) != 1 {
+		t.Fatalf("after inserting updates = %+v", results)
+	}
+
+	alerts.alerts = nil
+	outlooks.outlooks = nil
+	mds.mds = nil
+	for _, update := range updates {
+		err := index.Apply(update, tables)
+		if err != nil {
+			t.Fatalf("apply %+v: %v", update, err)
+		}
+	}
+
+	if index.Len() != 0 {
+		t.Errorf("Len() = %d after applying deletions", index.Len())
+	}
+}
+
+func TestApplyErrors(t *testing.T) {
+	index := NewIndex(nil, nil, nil)
+	selectErr := errors.New("connection refused")
+
+	err := index.Apply(data_structures.NotificationUpdateV2{Id: "alert", NotificationType: data_structures.AlertType},
+		Tables{Alerts: &fakeAlertTable{err: selectErr}})
+	if !errors.Is(err, selectErr) {
+		t.Errorf("err = %v, want %v", err, selectErr)
+	}
+
+	err = index.Apply(data_structures.NotificationUpdateV2{Id: "unknown", NotificationType: "unknown"}, Tables{})
+	if err == nil {
+		t.Error("expected an error for an unsupported notification type")
+	}
+}
@@ -4,12 +4,14 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
-	"fmt"
 
 	"github.com/lib/pq"
 
 	"github.com/cmeyer18/weather-common/v6/data_structures"
 	"github.com/cmeyer18/weather-common/v6/data_structures/geojson_v2"
+	"github.com/cmeyer18/weather-common/v6/data_structures/same"
+	"github.com/cmeyer18/weather-common/v6/data_structures/ugc"
+	"github.com/cmeyer18/weather-common/v6/data_structures/vtec"
 	"github.com/cmeyer18/weather-common/v6/sql/internal"
 	"github.com/cmeyer18/weather-common/v6/sql/internal/common_tables"
 )
@@ -21,6 +23,14 @@ type IAlertV2Table interface {
 
 	SelectByLocation(codes []string, point geojson_v2.Point) ([]data_structures.AlertV2, error)
 
+	SelectActive() ([]data_structures.AlertV2, error)
+
+	SelectActiveByBBox(bbox geojson_v2.BBox) ([]data_structures.AlertV2, error)
+
+	SelectByVTECEvent(event vtec.Event) ([]data_structures.AlertV2, error)
+
+	SelectBySAME(codes []string) ([]data_structures.AlertV2, error)
+
 	Exists(id string) (bool, error)
 }
 
@@ -29,18 +39,26 @@ type PostgresAlertV2Table struct {
 	sameTable       internal.IAlertV2SAMECodesTable
 	ugcTable        internal.IAlertV2UGCCodesTable
 	referencesTable internal.IAlertV2ReferencesTable
+	vtecTable       internal.IAlertV2VTECTable
+	boundaryTable   IUGCBoundaryTable
+	options         geometryOptions
 }
 
-func NewPostgresAlertV2Table(db *sql.DB) PostgresAlertV2Table {
+func NewPostgresAlertV2Table(db *sql.DB, options ...GeometryOption) PostgresAlertV2Table {
 	sameTable := internal.NewPostgresAlertV2SAMECodesTable(db)
 	ugcTable := internal.NewPostgresAlertV2UGCCodesTable(db)
 	referencesTable := internal.NewPostgresAlertV2ReferencesTable(db)
+	vtecTable := internal.NewPostgresAlertV2VTECTable(db)
+	boundaryTable := NewPostgresUGCBoundaryTable(db)
 
 	return PostgresAlertV2Table{
 		db:              db,
 		sameTable:       &sameTable,
 		ugcTable:        &ugcTable,
 		referencesTable: &referencesTable,
+		vtecTable:       &vtecTable,
+		boundaryTable:   &boundaryTable,
+		options:         newGeometryOptions(options),
 	}
 }
 
@@ -57,15 +75,10 @@ func (p *PostgresAlertV2Table) Insert(alert data_structures.AlertV2) error {
 		effective, onset, expires, ends, status, 
 		messageType, category, severity, certainty, urgency, 
 		event, sender, senderName, headline, description,
-	 	instruction, response, parameters
+	 	instruction, response, parameters, geometryDerived
 	) 
 	VALUES (
-		$1, $2, 
-		CASE 
-			WHEN $3::TEXT IS NULL OR $3::TEXT = '' OR jsonb_typeof($3::JSONB) = 'null' THEN NULL 
-			ELSE ST_GeomFromGeoJSON($3::JSONB) 
-    	END,
-		$4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23
+		$1, $2, $3::geometry, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22, $23, $24
 	);`)
 	if err != nil {
 		return err
@@ -77,32 +90,40 @@ func (p *PostgresAlertV2Table) Insert(alert data_structures.AlertV2) error {
 		return err
 	}
 
-	var marshalledGeometryBytes []byte
-	if alert.Geometry != nil {
-		marshalledGeometryBytes, err = json.Marshal(&alert.Geometry)
+	if p.options.deriveGeometry && alert.Geometry.IsEmpty() && alert.Geocode != nil && len(alert.Geocode.UGC) != 0 {
+		alert.Geometry, err = p.boundaryTable.SelectGeometryByCodesTx(tx, alert.Geocode.UGC)
 		if err != nil {
 			return err
 		}
+		alert.GeometryDerived = alert.Geometry != nil
 	}
 
+	geometry, err := p.options.prepareForInsert(alert.Geometry)
+	if err != nil {
+		return err
+	}
+
+	// Malformed VTEC strings are left out rather than losing the alert, which keeps them in its parameters.
+	codes, _ := alert.VTEC()
+
 	_, err = statement.Exec(
-		alert.ID, alert.Type, marshalledGeometryBytes, alert.AreaDesc, alert.Sent,
+		alert.ID, alert.Type, geometry, alert.AreaDesc, alert.Sent,
 		alert.Effective, alert.Onset, alert.Expires, alert.Ends, alert.Status,
 		alert.MessageType, alert.Category, alert.Severity, alert.Certainty, alert.Urgency,
 		alert.Event, alert.Sender, alert.SenderName, alert.Headline, alert.Description,
-		alert.Instruction, alert.Response, marshalledParameters,
+		alert.Instruction, alert.Response, marshalledParameters, alert.GeometryDerived,
 	)
 	if err != nil {
 		return err
 	}
 
 	if alert.Geocode != nil {
-		err := p.sameTable.Insert(tx, alert.ID, alert.Geocode.SAME)
+		err = p.sameTable.Insert(tx, alert.ID, same.NormalizeLenient(alert.Geocode.SAME))
 		if err != nil {
 			return err
 		}
 
-		err = p.ugcTable.Insert(tx, alert.ID, alert.Geocode.UGC)
+		err = p.ugcTable.Insert(tx, alert.ID, ugc.NormalizeLenient(alert.Geocode.UGC))
 		if err != nil {
 			return err
 		}
@@ -115,6 +136,13 @@ func (p *PostgresAlertV2Table) Insert(alert data_structures.AlertV2) error {
 		}
 	}
 
+	if len(codes) != 0 {
+		err := p.vtecTable.Insert(tx, alert.ID, codes)
+		if err != nil {
+			return err
+		}
+	}
+
 	err = tx.Commit()
 	if err != nil {
 		return err
@@ -126,10 +154,10 @@ func (p *PostgresAlertV2Table) Insert(alert data_structures.AlertV2) error {
 func (p *PostgresAlertV2Table) Select(id string) (*data_structures.AlertV2, error) {
 	statement, err := p.db.Prepare(`
         SELECT 
-            id, type, geometry::JSONB, areaDesc, sent, effective, onset, 
+            id, type, geometry, areaDesc, sent, effective, onset, 
             expires, ends, status, messageType, category, severity, 
             certainty, urgency, event, sender, senderName, headline, 
-            description, instruction, response, parameters
+            description, instruction, response, parameters, geometryDerived
         FROM alertV2
         WHERE id = $1
     `)
@@ -156,64 +184,171 @@ func (p *PostgresAlertV2Table) Select(id string) (*data_structures.AlertV2, erro
 	return &alerts[0], nil
 }
 
+// SelectByLocation returns the active alerts containing the point, along with the active alerts without a geometry
+// for any of the UGC codes. Codes that do not parse are matched as they are, so they never hide the alerts containing
+// the point.
 func (p *PostgresAlertV2Table) SelectByLocation(codes []string, point geojson_v2.Point) ([]data_structures.AlertV2, error) {
 	statement, err := p.db.Prepare(`
-	SELECT DISTINCT a.id, a.type, a.geometry::JSONB, a.areaDesc, a.sent, a.effective, a.onset, 
-            a.expires, a.ends, a.status, a.messageType, a.category, a.severity, 
-            a.certainty, a.urgency, a.event, a.sender, a.senderName, a.headline, 
-            a.description, a.instruction, a.response, a.parameters 
+	SELECT a.id, a.type, a.geometry, a.areaDesc, a.sent, a.effective, a.onset, 
+		a.expires, a.ends, a.status, a.messageType, a.category, a.severity, 
+		a.certainty, a.urgency, a.event, a.sender, a.senderName, a.headline, 
+		a.description, a.instruction, a.response, a.parameters, a.geometryDerived
 	FROM alertV2 a
-		LEFT JOIN alertV2_UGCCodes ugc ON a.id = ugc.alertId
 	WHERE 
-	    a.geometry IS NULL AND
-		a.expires >= NOW() AND 
-		ugc.code = ANY($1::VARCHAR[]);`)
+		a.expires >= NOW() AND (
+			ST_Contains(a.geometry, $2::geometry) OR (
+				a.geometry IS NULL AND
+				EXISTS (
+					SELECT 1 FROM alertV2_UGCCodes ugc
+					WHERE ugc.alertId = a.id AND ugc.code = ANY($1::VARCHAR[])
+				)
+			)
+		)
+	`)
 	if err != nil {
 		return nil, err
 	}
 	defer statement.Close()
 
-	rows, err := statement.Query(pq.Array(codes))
+	rows, err := statement.Query(pq.Array(ugc.NormalizeLenient(codes)), &geojson_v2.Geometry{Point: &point})
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 
-	geocodeActiveAlerts, err := p.processAlertRows(rows)
+	return p.processAlertRows(rows)
+}
+
+// SelectActive returns every alert that has not expired, including alerts without a geometry.
+func (p *PostgresAlertV2Table) SelectActive() ([]data_structures.AlertV2, error) {
+	statement, err := p.db.Prepare(`
+        SELECT 
+            id, type, geometry, areaDesc, sent, effective, onset, 
+            expires, ends, status, messageType, category, severity, 
+            certainty, urgency, event, sender, senderName, headline, 
+            description, instruction, response, parameters, geometryDerived
+        FROM alertV2
+        WHERE expires >= NOW()
+    `)
+	if err != nil {
+		return nil, err
+	}
+	defer statement.Close()
+
+	rows, err := statement.Query()
 	if err != nil {
 		return nil, err
 	}
+	defer rows.Close()
 
-	statement2, err := p.db.Prepare(`
-	SELECT DISTINCT a.id, a.type, a.geometry::JSONB, a.areaDesc, a.sent, a.effective, a.onset, 
+	return p.processAlertRows(rows)
+}
+
+// SelectActiveByBBox returns the unexpired alerts whose geometry intersects the box. Alerts without a geometry, such as
+// those only described by UGC codes, never match unless the table was created WithDerivedGeometry and a geometry could
+// be derived for them.
+func (p *PostgresAlertV2Table) SelectActiveByBBox(bbox geojson_v2.BBox) ([]data_structures.AlertV2, error) {
+	statement, err := p.db.Prepare(`
+        SELECT 
+            id, type, geometry, areaDesc, sent, effective, onset, 
+            expires, ends, status, messageType, category, severity, 
+            certainty, urgency, event, sender, senderName, headline, 
+            description, instruction, response, parameters, geometryDerived
+        FROM alertV2
+        WHERE 
+            ST_Intersects(geometry, $1::geometry) AND
+            expires >= NOW()
+    `)
+	if err != nil {
+		return nil, err
+	}
+	defer statement.Close()
+
+	rows, err := statement.Query(bbox.Geometry())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return p.processAlertRows(rows)
+}
+
+// SelectByVTECEvent returns every alert referencing the event, from the one that issued it to the latest update,
+// ordered by when they were sent.
+func (p *PostgresAlertV2Table) SelectByVTECEvent(event vtec.Event) ([]data_structures.AlertV2, error) {
+	statement, err := p.db.Prepare(`
+	SELECT a.id, a.type, a.geometry, a.areaDesc, a.sent, a.effective, a.onset, 
 		a.expires, a.ends, a.status, a.messageType, a.category, a.severity, 
 		a.certainty, a.urgency, a.event, a.sender, a.senderName, a.headline, 
-		a.description, a.instruction, a.response, a.parameters 
+		a.description, a.instruction, a.response, a.parameters, a.geometryDerived
 	FROM alertV2 a
-	WHERE 
-	    ST_Contains(a.geometry, ST_GeomFromText($1, 4326)) AND
-	    a.expires >= NOW()
+	WHERE EXISTS (
+		SELECT 1 FROM alertV2_VTEC v
+		WHERE 
+			v.alertId = a.id AND
+			v.office = $1 AND
+			v.phenomena = $2 AND
+			v.significance = $3 AND
+			v.eventTrackingNumber = $4
+	)
+	ORDER BY a.sent
 	`)
 	if err != nil {
 		return nil, err
 	}
-	defer statement2.Close()
+	defer statement.Close()
 
-	pointString := fmt.Sprintf("POINT (%f %f)", point.Longitude, point.Latitude)
-	rows2, err := statement2.Query(pointString)
+	rows, err := statement.Query(event.Office, event.Phenomena, event.Significance, event.EventTrackingNumber)
 	if err != nil {
 		return nil, err
 	}
-	defer rows2.Close()
+	defer rows.Close()
+
+	return p.processAlertRows(rows)
+}
 
-	geometryAlerts, err := p.processAlertRows(rows2)
+// SelectBySAME returns the active alerts for any of the SAME codes. An alert matches a code in the same county when
+// either covers the entire county or both cover the same part of it. Codes that do not parse are matched as they are,
+// the way they are stored.
+func (p *PostgresAlertV2Table) SelectBySAME(codes []string) ([]data_structures.AlertV2, error) {
+	var matchingCodes []string
+	for _, value := range codes {
+		code, err := same.Parse(value)
+		if err != nil {
+			matchingCodes = append(matchingCodes, value)
+			continue
+		}
+
+		for _, matchingCode := range code.Matching() {
+			matchingCodes = append(matchingCodes, matchingCode.String())
+		}
+	}
+
+	statement, err := p.db.Prepare(`
+	SELECT a.id, a.type, a.geometry, a.areaDesc, a.sent, a.effective, a.onset, 
+		a.expires, a.ends, a.status, a.messageType, a.category, a.severity, 
+		a.certainty, a.urgency, a.event, a.sender, a.senderName, a.headline, 
+		a.description, a.instruction, a.response, a.parameters, a.geometryDerived
+	FROM alertV2 a
+	WHERE 
+		a.expires >= NOW() AND
+		EXISTS (
+			SELECT 1 FROM alertV2_SAMECodes s
+			WHERE s.alertId = a.id AND s.code = ANY($1::VARCHAR[])
+		)
+	`)
 	if err != nil {
 		return nil, err
 	}
+	defer statement.Close()
 
-	activeAlerts := append(geocodeActiveAlerts, geometryAlerts...)
+	rows, err := statement.Query(pq.Array(matchingCodes))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
 
-	return activeAlerts, nil
+	return p.processAlertRows(rows)
 }
 
 func (p *PostgresAlertV2Table) Exists(id string) (bool, error) {
@@ -254,18 +389,20 @@ func (p *PostgresAlertV2Table) processAlertRows(rows *sql.Rows) ([]data_structur
 	for rows.Next() {
 		var alert data_structures.AlertV2
 		var marshalledParameters []byte
-		var marshalledGeometry []byte
 
 		err := rows.Scan(
-			&alert.ID, &alert.Type, &marshalledGeometry, &alert.AreaDesc, &alert.Sent, &alert.Effective,
+			&alert.ID, &alert.Type, &alert.Geometry, &alert.AreaDesc, &alert.Sent, &alert.Effective,
 			&alert.Onset, &alert.Expires, &alert.Ends, &alert.Status, &alert.MessageType, &alert.Category,
 			&alert.Severity, &alert.Certainty, &alert.Urgency, &alert.Event, &alert.Sender, &alert.SenderName,
 			&alert.Headline, &alert.Description, &alert.Instruction, &alert.Response, &marshalledParameters,
+			&alert.GeometryDerived,
 		)
 		if err != nil {
 			return nil, err
 		}
 
+		alert.Geometry = p.options.prepareForSelect(alert.Geometry)
+
 		if !(string(marshalledParameters) == "" || string(marshalledParameters) == `""` || string(marshalledParameters) == "null") {
 			err = json.Unmarshal(marshalledParameters, &alert.Parameters)
 			if err != nil {
@@ -273,13 +410,6 @@ func (p *PostgresAlertV2Table) processAlertRows(rows *sql.Rows) ([]data_structur
 			}
 		}
 
-		if !(string(marshalledGeometry) == "" || string(marshalledGeometry) == `""` || string(marshalledGeometry) == "null") {
-			err = json.Unmarshal(marshalledGeometry, &alert.Geometry)
-			if err != nil {
-				return nil, err
-			}
-		}
-
 		sameIds, err := p.sameTable.SelectByAlertId(alert.ID)
 		if err != nil {
 			return nil, err
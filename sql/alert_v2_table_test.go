@@ -0,0 +1,158 @@
+package sql
+
+import (
+	"database/sql/driver"
+	"encoding/hex"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/cmeyer18/weather-common/v6/data_structures"
+	"github.com/cmeyer18/weather-common/v6/data_structures/geojson_v2"
+)
+
+var alertColumns = []string{
+	"id", "type", "geometry", "areaDesc", "sent", "effective", "onset", "expires", "ends", "status", "messageType",
+	"category", "severity", "certainty", "urgency", "event", "sender", "senderName", "headline", "description",
+	"instruction", "response", "parameters", "geometryDerived",
+}
+
+func mustGeometry(t *testing.T, data string) *geojson_v2.Geometry {
+	t.Helper()
+
+	var geometry geojson_v2.Geometry
+	err := json.Unmarshal([]byte(data), &geometry)
+	if err != nil {
+		t.Fatalf("invalid geometry %s: %v", data, err)
+	}
+	return &geometry
+}
+
+func alertRow(t *testing.T, id string, geometry *geojson_v2.Geometry) []driver.Value {
+	t.Helper()
+
+	var encoded driver.Value
+	if geometry != nil {
+		ewkb, err := geometry.MarshalEWKB(geojson_v2.WGS84SRID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		encoded = hex.EncodeToString(ewkb)
+	}
+
+	now := time.Now().UTC()
+	return []driver.Value{
+		id, "wx:Alert", encoded, "Douglas", now, now, now, now.Add(time.Hour), now.Add(time.Hour), "Actual", "Alert",
+		"Met", "Severe", "Likely", "Immediate", "Tornado Warning", "w-nws.webmaster@noaa.gov", "NWS Omaha", "", "",
+		"", "Shelter", []byte("null"), false,
+	}
+}
+
+func TestSelectByLocationInvalidCode(t *testing.T) {
+	db, fake := newFakeDatabase(t)
+	table := NewPostgresAlertV2Table(db)
+
+	square := mustGeometry(t, `{"type":"Polygon","coordinates":[[[0,0],[2,0],[2,2],[0,2],[0,0]]]}`)
+	fake.respond("FROM alertV2 a", alertColumns, alertRow(t, "containing", square))
+
+	alerts, err := table.SelectByLocation([]string{"ksz001", "not a code"}, geojson_v2.Point{Longitude: 1, Latitude: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(alerts) != 1 || alerts[0].ID != "containing" || alerts[0].Geometry.Polygon == nil {
+		t.Fatalf("alerts = %+v, want the alert containing the point", alerts)
+	}
+
+	statements := fake.find("FROM alertV2 a")
+	if len(statements) != 1 {
+		t.Fatalf("statements = %+v", statements)
+	}
+	if want := `{"KSZ001","not a code"}`; statements[0].args[0] != want {
+		t.Errorf("codes = %v, want %s", statements[0].args[0], want)
+	}
+}
+
+func TestSelectBySAMEInvalidCode(t *testing.T) {
+	db, fake := newFakeDatabase(t)
+	table := NewPostgresAlertV2Table(db)
+
+	fake.respond("FROM alertV2 a", alertColumns, alertRow(t, "county", nil))
+
+	alerts, err := table.SelectBySAME([]string{"131055", "not a code"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(alerts) != 1 || alerts[0].ID != "county" {
+		t.Fatalf("alerts = %+v, want the alert for the valid code", alerts)
+	}
+
+	statements := fake.find("FROM alertV2 a")
+	if len(statements) != 1 {
+		t.Fatalf("statements = %+v", statements)
+	}
+	if want := `{"031055","131055","not a code"}`; statements[0].args[0] != want {
+		t.Errorf("codes = %v, want %s", statements[0].args[0], want)
+	}
+}
+
+func TestInsertDerivesGeometry(t *testing.T) {
+	db, fake := newFakeDatabase(t)
+	table := NewPostgresAlertV2Table(db, WithDerivedGeometry())
+
+	boundary := mustGeometry(t, `{"type":"Polygon","coordinates":[[[0,0],[2,0],[2,2],[0,2],[0,0]]]}`)
+	ewkb, err := boundary.MarshalEWKB(geojson_v2.WGS84SRID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fake.respond("ST_Union", []string{"st_union"}, []driver.Value{hex.EncodeToString(ewkb)})
+
+	err = table.Insert(data_structures.AlertV2{
+		ID:      "derived",
+		Geocode: &data_structures.AlertPropertiesGeocodeV2{UGC: []string{"nez052"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	derived := fake.find("ST_Union")
+	if len(derived) != 1 || !derived[0].inTx {
+		t.Fatalf("statements = %+v, want the geometry derived within the insert transaction", derived)
+	}
+	if want := `{"NEZ052"}`; derived[0].args[0] != want {
+		t.Errorf("codes = %v, want %s", derived[0].args[0], want)
+	}
+
+	inserted := fake.find("INSERT INTO alertV2 (")
+	if len(inserted) != 1 {
+		t.Fatalf("statements = %+v", inserted)
+	}
+	if inserted[0].args[2] == nil || inserted[0].args[23] != true {
+		t.Errorf("geometry = %v and geometryDerived = %v, want a derived geometry", inserted[0].args[2], inserted[0].args[23])
+	}
+}
+
+func TestInsertDerivesNoGeometry(t *testing.T) {
+	db, fake := newFakeDatabase(t)
+	table := NewPostgresAlertV2Table(db, WithDerivedGeometry())
+
+	// ST_Union returns NULL when none of the codes has a boundary.
+	fake.respond("ST_Union", []string{"st_union"}, []driver.Value{nil})
+
+	err := table.Insert(data_structures.AlertV2{
+		ID:      "underived",
+		Geocode: &data_structures.AlertPropertiesGeocodeV2{UGC: []string{"NEZ052"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	inserted := fake.find("INSERT INTO alertV2 (")
+	if len(inserted) != 1 {
+		t.Fatalf("statements = %+v", inserted)
+	}
+	if inserted[0].args[2] != nil || inserted[0].args[23] != false {
+		t.Errorf("geometry = %v and geometryDerived = %v, want no geometry", inserted[0].args[2], inserted[0].args[23])
+	}
+}
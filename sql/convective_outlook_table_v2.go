@@ -3,8 +3,6 @@ package sql
 import (
 	"context"
 	"database/sql"
-	"encoding/json"
-	"fmt"
 	"time"
 
 	"github.com/cmeyer18/weather-common/v6/data_structures"
@@ -26,15 +24,19 @@ type IConvectiveOutlookTableV2 interface {
 	SelectAllLatest() (map[golang.ConvectiveOutlookType][]data_structures.ConvectiveOutlookV2, error)
 
 	SelectAllLatestByLocation(point geojson_v2.Point) ([]data_structures.ConvectiveOutlookV2, error)
+
+	SelectAllLatestByBBox(bbox geojson_v2.BBox) ([]data_structures.ConvectiveOutlookV2, error)
 }
 
 type PostgresConvectiveOutlookTableV2 struct {
-	db *sql.DB
+	db      *sql.DB
+	options geometryOptions
 }
 
-func NewPostgresConvectiveOutlookTableV2(db *sql.DB) PostgresConvectiveOutlookTableV2 {
+func NewPostgresConvectiveOutlookTableV2(db *sql.DB, options ...GeometryOption) PostgresConvectiveOutlookTableV2 {
 	return PostgresConvectiveOutlookTableV2{
-		db: db,
+		db:      db,
+		options: newGeometryOptions(options),
 	}
 }
 
@@ -48,26 +50,18 @@ func (p *PostgresConvectiveOutlookTableV2) Insert(outlooks []data_structures.Con
 		statement, err := p.db.Prepare(`
 		INSERT INTO convectiveOutlookV2(id, outlookType, geometry, dn, issued, expires, valid, label, label2, stroke, fill) 
 		VALUES(
-			$1,$2, 
-			CASE 
-				WHEN $3::TEXT IS NULL OR $3::TEXT = '' OR  jsonb_typeof($3::JSONB) = 'null' THEN NULL 
-				ELSE ST_GeomFromGeoJSON($3::JSONB) 
-    		END,
-			$4, $5, $6, $7, $8, $9, $10, $11)`)
+			$1, $2, $3::geometry, $4, $5, $6, $7, $8, $9, $10, $11)`)
 		if err != nil {
 			return err
 		}
 		defer statement.Close()
 
-		var marshalledGeometryBytes []byte
-		if outlook.Geometry != nil {
-			marshalledGeometryBytes, err = json.Marshal(&outlook.Geometry)
-			if err != nil {
-				return err
-			}
+		geometry, err := p.options.prepareForInsert(outlook.Geometry)
+		if err != nil {
+			return err
 		}
 
-		_, err = statement.Exec(outlook.ID, string(outlook.OutlookType), marshalledGeometryBytes, outlook.DN, outlook.Issued, outlook.Expires, outlook.Valid, outlook.Label, outlook.Label2, outlook.Stroke, outlook.Fill)
+		_, err = statement.Exec(outlook.ID, string(outlook.OutlookType), geometry, outlook.DN, outlook.Issued, outlook.Expires, outlook.Valid, outlook.Label, outlook.Label2, outlook.Stroke, outlook.Fill)
 		if err != nil {
 			return err
 		}
@@ -82,7 +76,7 @@ func (p *PostgresConvectiveOutlookTableV2) Insert(outlooks []data_structures.Con
 }
 
 func (p *PostgresConvectiveOutlookTableV2) Select(issuedTime time.Time, outlookType golang.ConvectiveOutlookType) ([]data_structures.ConvectiveOutlookV2, error) {
-	statement, err := p.db.Prepare(`SELECT id, outlookType, geometry::JSONB, dn, issued, expires, valid, label, label2, stroke, fill FROM convectiveOutlookV2 WHERE $1 = issued AND $2 = outlookType`)
+	statement, err := p.db.Prepare(`SELECT id, outlookType, geometry, dn, issued, expires, valid, label, label2, stroke, fill FROM convectiveOutlookV2 WHERE $1 = issued AND $2 = outlookType`)
 	if err != nil {
 		return nil, err
 	}
@@ -98,7 +92,7 @@ func (p *PostgresConvectiveOutlookTableV2) Select(issuedTime time.Time, outlookT
 }
 
 func (p *PostgresConvectiveOutlookTableV2) SelectById(id string) ([]data_structures.ConvectiveOutlookV2, error) {
-	statement, err := p.db.Prepare(`SELECT id, outlookType, geometry::JSONB, dn, issued, expires, valid, label, label2, stroke, fill FROM convectiveOutlookV2 WHERE $1 = id`)
+	statement, err := p.db.Prepare(`SELECT id, outlookType, geometry, dn, issued, expires, valid, label, label2, stroke, fill FROM convectiveOutlookV2 WHERE $1 = id`)
 	if err != nil {
 		return nil, err
 	}
@@ -115,7 +109,7 @@ func (p *PostgresConvectiveOutlookTableV2) SelectById(id string) ([]data_structu
 
 func (p *PostgresConvectiveOutlookTableV2) SelectLatest(outlookType golang.ConvectiveOutlookType) ([]data_structures.ConvectiveOutlookV2, error) {
 	statement, err := p.db.Prepare(`
-	SELECT id, outlookType, geometry::JSONB, dn, issued, expires, valid, label, label2, stroke, fill 
+	SELECT id, outlookType, geometry, dn, issued, expires, valid, label, label2, stroke, fill 
 	FROM convectiveOutlookV2 
 	WHERE $1 = outlookType AND issued = (
 		SELECT MAX(issued)
@@ -150,7 +144,7 @@ func (p *PostgresConvectiveOutlookTableV2) SelectAllLatest() (map[golang.Convect
 	SELECT
 	    c.id, 
 	    c.outlookType, 
-	    c.geometry::JSONB, 
+	    c.geometry, 
 	    c.dn, 
 	    c.issued, 
 	    c.expires, 
@@ -207,7 +201,56 @@ func (p *PostgresConvectiveOutlookTableV2) SelectAllLatestByLocation(point geojs
 	SELECT
 		c.id, 
 	    c.outlookType, 
-	    c.geometry::JSONB, 
+	    c.geometry, 
+	    c.dn, 
+	    c.issued, 
+	    c.expires, 
+	    c.valid, 
+	    c.label, 
+	    c.label2, 
+	    c.stroke, 
+	    c.fill
+	FROM
+		convectiveOutlookV2 c
+	INNER JOIN
+		latest_issued l
+	ON
+		c.outlookType = l.outlookType
+		AND c.issued = l.latestIssueTime
+	WHERE 
+		ST_Contains(c.geometry, $1::geometry)
+	ORDER BY
+		c.outlookType ASC, c.dn ASC;
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer statement.Close()
+
+	rows, err := statement.Query(&geojson_v2.Geometry{Point: &point})
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	return p.processConvectiveOutlooks(rows)
+}
+
+func (p *PostgresConvectiveOutlookTableV2) SelectAllLatestByBBox(bbox geojson_v2.BBox) ([]data_structures.ConvectiveOutlookV2, error) {
+	statement, err := p.db.Prepare(`
+	WITH latest_issued AS (
+		SELECT
+			outlookType,
+			MAX(issued) AS latestIssueTime
+		FROM
+			convectiveOutlookV2
+		GROUP BY
+			outlookType
+	)
+	SELECT
+		c.id, 
+	    c.outlookType, 
+	    c.geometry, 
 	    c.dn, 
 	    c.issued, 
 	    c.expires, 
@@ -224,7 +267,7 @@ func (p *PostgresConvectiveOutlookTableV2) SelectAllLatestByLocation(point geojs
 		c.outlookType = l.outlookType
 		AND c.issued = l.latestIssueTime
 	WHERE 
-		ST_Contains(c.geometry, ST_GeomFromText($1, 4326))
+		ST_Intersects(c.geometry, $1::geometry)
 	ORDER BY
 		c.outlookType ASC, c.dn ASC;
 	`)
@@ -233,8 +276,7 @@ func (p *PostgresConvectiveOutlookTableV2) SelectAllLatestByLocation(point geojs
 	}
 	defer statement.Close()
 
-	pointString := fmt.Sprintf("POINT (%f %f)", point.Longitude, point.Latitude)
-	rows, err := statement.Query(pointString)
+	rows, err := statement.Query(bbox.Geometry())
 	if err != nil {
 		return nil, err
 	}
@@ -247,22 +289,15 @@ func (p *PostgresConvectiveOutlookTableV2) processConvectiveOutlooks(rows *sql.R
 	var outlooks []data_structures.ConvectiveOutlookV2
 	for rows.Next() {
 		outlook := data_structures.ConvectiveOutlookV2{}
-		var marshalledGeometry []byte
 		var outlookType string
 
-		err := rows.Scan(&outlook.ID, &outlookType, &marshalledGeometry, &outlook.DN, &outlook.Issued, &outlook.Expires, &outlook.Valid, &outlook.Label, &outlook.Label2, &outlook.Stroke, &outlook.Fill)
+		err := rows.Scan(&outlook.ID, &outlookType, &outlook.Geometry, &outlook.DN, &outlook.Issued, &outlook.Expires, &outlook.Valid, &outlook.Label, &outlook.Label2, &outlook.Stroke, &outlook.Fill)
 		if err != nil {
 			return nil, err
 		}
 
-		if !(string(marshalledGeometry) == "" || string(marshalledGeometry) == `""` || string(marshalledGeometry) == "null") {
-			err = json.Unmarshal(marshalledGeometry, &outlook.Geometry)
-			if err != nil {
-				return nil, err
-			}
-		}
-
 		outlook.OutlookType = golang.ConvectiveOutlookType(outlookType)
+		outlook.Geometry = p.options.prepareForSelect(outlook.Geometry)
 		outlooks = append(outlooks, outlook)
 	}
 
@@ -0,0 +1,173 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fakeDatabase records the statements run through it and answers queries with scripted rows, so that tables can be
+// tested without a Postgres server.
+type fakeDatabase struct {
+	mu         sync.Mutex
+	statements []fakeStatement
+	results    []fakeResult
+}
+
+// fakeStatement is a statement run against the fake database, with whether it ran inside a transaction.
+type fakeStatement struct {
+	query string
+	args  []driver.Value
+	inTx  bool
+}
+
+// fakeResult answers queries containing the fragment with the rows.
+type fakeResult struct {
+	fragment string
+	columns  []string
+	rows     [][]driver.Value
+}
+
+func newFakeDatabase(t *testing.T) (*sql.DB, *fakeDatabase) {
+	t.Helper()
+
+	fake := &fakeDatabase{}
+	db := sql.OpenDB(fake)
+	t.Cleanup(func() {
+		db.Close()
+	})
+	return db, fake
+}
+
+// respond answers every later query containing the fragment with the rows. Queries without a response return no rows.
+func (f *fakeDatabase) respond(fragment string, columns []string, rows ...[]driver.Value) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.results = append(f.results, fakeResult{fragment: fragment, columns: columns, rows: rows})
+}
+
+// find returns the statements run containing the fragment.
+func (f *fakeDatabase) find(fragment string) []fakeStatement {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	var statements []fakeStatement
+	for _, statement := range f.statements {
+		if strings.Contains(statement.query, fragment) {
+			statements = append(statements, statement)
+		}
+	}
+	return statements
+}
+
+func (f *fakeDatabase) record(query string, args []driver.Value, inTx bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.statements = append(f.statements, fakeStatement{query: query, args: args, inTx: inTx})
+}
+
+func (f *fakeDatabase) result(query string) fakeResult {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, result := range f.results {
+		if strings.Contains(query, result.fragment) {
+			return result
+		}
+	}
+	return fakeResult{}
+}
+
+func (f *fakeDatabase) Connect(context.Context) (driver.Conn, error) {
+	return &fakeConn{database: f}, nil
+}
+
+func (f *fakeDatabase) Driver() driver.Driver {
+	return fakeDriver{}
+}
+
+type fakeDriver struct{}
+
+func (fakeDriver) Open(string) (driver.Conn, error) {
+	return nil, driver.ErrSkip
+}
+
+type fakeConn struct {
+	database *fakeDatabase
+	inTx     bool
+}
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) {
+	return &fakeStmt{conn: c, query: query}, nil
+}
+
+func (c *fakeConn) Close() error {
+	return nil
+}
+
+func (c *fakeConn) Begin() (driver.Tx, error) {
+	c.inTx = true
+	return c, nil
+}
+
+func (c *fakeConn) Commit() error {
+	c.inTx = false
+	return nil
+}
+
+func (c *fakeConn) Rollback() error {
+	c.inTx = false
+	return nil
+}
+
+type fakeStmt struct {
+	conn  *fakeConn
+	query string
+}
+
+func (s *fakeStmt) Close() error {
+	return nil
+}
+
+func (s *fakeStmt) NumInput() int {
+	return -1
+}
+
+func (s *fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	s.conn.database.record(s.query, args, s.conn.inTx)
+	return driver.RowsAffected(1), nil
+}
+
+func (s *fakeStmt) Query(args []driver.Value) (driver.Rows, error) {
+	s.conn.database.record(s.query, args, s.conn.inTx)
+	return &fakeRows{result: s.conn.database.result(s.query)}, nil
+}
+
+type fakeRows struct {
+	result fakeResult
+	next   int
+}
+
+func (r *fakeRows) Columns() []string {
+	return r.result.columns
+}
+
+func (r *fakeRows) Close() error {
+	return nil
+}
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.next >= len(r.result.rows) {
+		return io.EOF
+	}
+
+	copy(dest, r.result.rows[r.next])
+	r.next++
+	return nil
+}
@@ -0,0 +1,86 @@
+package sql
+
+import (
+	"github.com/cmeyer18/weather-common/v6/data_structures/geojson_v2"
+)
+
+// GeometryOption configures how a table treats the geometries it stores and returns.
+type GeometryOption func(*geometryOptions)
+
+type geometryOptions struct {
+	normalize         bool
+	simplify          bool
+	simplifyTolerance float64
+	simplifyAlgorithm geojson_v2.SimplificationAlgorithm
+	deriveGeometry    bool
+}
+
+// WithGeometryNormalization normalizes and then validates every geometry before it is inserted, rejecting the insert
+// when the geometry is invalid. The caller's geometry is not modified.
+func WithGeometryNormalization() GeometryOption {
+	return func(options *geometryOptions) {
+		options.normalize = true
+	}
+}
+
+// WithSimplification simplifies every polygon returned by the select methods with the given tolerance and algorithm.
+// Stored geometries are not modified.
+func WithSimplification(tolerance float64, algorithm geojson_v2.SimplificationAlgorithm) GeometryOption {
+	return func(options *geometryOptions) {
+		options.simplify = true
+		options.simplifyTolerance = tolerance
+		options.simplifyAlgorithm = algorithm
+	}
+}
+
+// WithDerivedGeometry gives alerts inserted without a geometry the union of the boundaries of their UGC codes, marking
+// the geometry as derived, so that they can be matched spatially. Alerts keep no geometry when none of their codes has
+// a boundary loaded. Only the alert table derives geometries.
+func WithDerivedGeometry() GeometryOption {
+	return func(options *geometryOptions) {
+		options.deriveGeometry = true
+	}
+}
+
+func newGeometryOptions(options []GeometryOption) geometryOptions {
+	var geometryOptions geometryOptions
+	for _, option := range options {
+		option(&geometryOptions)
+	}
+	return geometryOptions
+}
+
+// prepareForInsert returns the geometry to write to the database for the configured options. Geometries crossing the
+// antimeridian are always split there, since PostGIS treats longitude as planar.
+func (o geometryOptions) prepareForInsert(geometry *geojson_v2.Geometry) (*geojson_v2.Geometry, error) {
+	if geometry == nil {
+		return nil, nil
+	}
+
+	if geometry.CrossesAntimeridian() {
+		geometry = geometry.SplitAntimeridian()
+	}
+
+	if !o.normalize {
+		return geometry, nil
+	}
+
+	normalized := geometry.Clone()
+	normalized.Normalize()
+
+	err := normalized.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return normalized, nil
+}
+
+// prepareForSelect returns the geometry to hand back to the caller for the configured options.
+func (o geometryOptions) prepareForSelect(geometry *geojson_v2.Geometry) *geojson_v2.Geometry {
+	if geometry == nil || !o.simplify {
+		return geometry
+	}
+
+	return geometry.Simplify(o.simplifyTolerance, o.simplifyAlgorithm)
+}
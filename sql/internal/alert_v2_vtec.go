@@ -0,0 +1,93 @@
+package internal
+
+import (
+	"database/sql"
+
+	"github.com/cmeyer18/weather-common/v6/data_structures/vtec"
+)
+
+var _ IAlertV2VTECTable = (*PostgresAlertV2VTECTable)(nil)
+
+type IAlertV2VTECTable interface {
+	Insert(tx *sql.Tx, alertId string, codes []vtec.VTEC) error
+
+	SelectByAlertId(alertId string) ([]vtec.VTEC, error)
+
+	Delete(tx *sql.Tx, alertId string) error
+}
+
+type PostgresAlertV2VTECTable struct {
+	db *sql.DB
+}
+
+func NewPostgresAlertV2VTECTable(db *sql.DB) PostgresAlertV2VTECTable {
+	return PostgresAlertV2VTECTable{
+		db: db,
+	}
+}
+
+func (p *PostgresAlertV2VTECTable) Insert(tx *sql.Tx, alertId string, codes []vtec.VTEC) error {
+	for _, code := range codes {
+		statement, err := tx.Prepare(`INSERT INTO alertV2_VTEC (
+			alertId, productClass, action, office, phenomena,
+			significance, eventTrackingNumber, beginTime, endTime
+		)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`)
+		if err != nil {
+			return err
+		}
+		defer statement.Close()
+
+		_, err = statement.Exec(
+			alertId, code.ProductClass, code.Action, code.Office, code.Phenomena,
+			code.Significance, code.EventTrackingNumber, code.Begin, code.End,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (p *PostgresAlertV2VTECTable) SelectByAlertId(alertId string) ([]vtec.VTEC, error) {
+	statement, err := p.db.Prepare(`
+		SELECT productClass, action, office, phenomena, significance, eventTrackingNumber, beginTime, endTime
+		FROM alertV2_VTEC
+		WHERE alertId = $1`)
+	if err != nil {
+		return nil, err
+	}
+	defer statement.Close()
+
+	rows, err := statement.Query(alertId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var codes []vtec.VTEC
+	for rows.Next() {
+		var code vtec.VTEC
+		err := rows.Scan(
+			&code.ProductClass, &code.Action, &code.Office, &code.Phenomena, &code.Significance,
+			&code.EventTrackingNumber, &code.Begin, &code.End,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		codes = append(codes, code)
+	}
+
+	return codes, nil
+}
+
+func (p *PostgresAlertV2VTECTable) Delete(tx *sql.Tx, alertId string) error {
+	_, err := tx.Exec(`DELETE FROM alertV2_VTEC WHERE alertId = $1`, alertId)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -56,6 +56,10 @@ func (n *PostgresLocationQueries) GetDevicesForAlertID(alertId string) (map[data
 			AND CASE
 				WHEN (a.geometry IS NOT NULL)
 					THEN ST_Contains(a.geometry, ST_SetSRID(ST_MakePoint(location.longitude , location.latitude), 4326))
+						OR (
+							location.proximityRadius > 0 AND
+							ST_DWithin(a.geometry::geography, ST_SetSRID(ST_MakePoint(location.longitude , location.latitude), 4326)::geography, location.proximityRadius)
+						)
 				ELSE
 					au.code = location.zonecode OR au.code = location.countycode
 			END`)
@@ -111,7 +115,13 @@ func (n *PostgresLocationQueries) GetDevicesForConvectiveOutlookID(convectiveOut
 			)
 		WHERE
 		    convectiveoutlookv2.id = $1
-		  	AND ST_Contains(convectiveoutlookv2.geometry, ST_SetSRID(ST_MakePoint(location.longitude , location.latitude), 4326)) 
+		  	AND (
+				ST_Contains(convectiveoutlookv2.geometry, ST_SetSRID(ST_MakePoint(location.longitude , location.latitude), 4326))
+				OR (
+					location.proximityRadius > 0 AND
+					ST_DWithin(convectiveoutlookv2.geometry::geography, ST_SetSRID(ST_MakePoint(location.longitude , location.latitude), 4326)::geography, location.proximityRadius)
+				)
+			)
 	`)
 	if err != nil {
 		return nil, err
@@ -170,7 +180,13 @@ func (n *PostgresLocationQueries) GetDevicesForMesoscaleDiscussionID(mesoscaleDi
 			) OR (
 			    location.locationType = 1 AND location.locationReferenceID = device.userid 
 			)
-		WHERE m.id = $1 AND ST_Contains(m.geometry, ST_SetSRID(ST_MakePoint(location.longitude , location.latitude), 4326)) 
+		WHERE m.id = $1 AND (
+			ST_Contains(m.geometry, ST_SetSRID(ST_MakePoint(location.longitude , location.latitude), 4326))
+			OR (
+				location.proximityRadius > 0 AND
+				ST_DWithin(m.geometry::geography, ST_SetSRID(ST_MakePoint(location.longitude , location.latitude), 4326)::geography, location.proximityRadius)
+			)
+		)
 	`)
 	if err != nil {
 		return nil, err
@@ -7,6 +7,8 @@ import (
 	"time"
 
 	"github.com/cmeyer18/weather-common/v6/data_structures"
+	"github.com/cmeyer18/weather-common/v6/data_structures/geojson_v2"
+	"github.com/cmeyer18/weather-common/v6/data_structures/ugc"
 	"github.com/cmeyer18/weather-common/v6/generative/golang"
 	"github.com/cmeyer18/weather-common/v6/sql/internal/common_tables"
 )
@@ -30,12 +32,16 @@ type ILocationTable interface {
 }
 
 type PostgresLocationTable struct {
-	db *sql.DB
+	db            *sql.DB
+	boundaryTable IUGCBoundaryTable
 }
 
 func NewPostgresLocationTable(db *sql.DB) PostgresLocationTable {
+	boundaryTable := NewPostgresUGCBoundaryTable(db)
+
 	return PostgresLocationTable{
-		db: db,
+		db:            db,
+		boundaryTable: &boundaryTable,
 	}
 }
 
@@ -83,20 +89,31 @@ func (p *PostgresLocationTable) insert(transaction *sql.Tx, location data_struct
 		countyCode,
 		latitude,
 		longitude,
-		locationName
+		locationName,
+		proximityRadius
 	) 
-	VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+	VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+
+	var err error
+	if location.ZoneCode == "" || location.CountyCode == "" {
+		location, err = p.resolveCodes(transaction, location)
+		if err != nil {
+			return err
+		}
+	}
 
-	_, err := transaction.Exec(
+	// Codes that do not parse are stored as they are so that they still match alerts carrying the same value.
+	_, err = transaction.Exec(
 		locationQuery,
 		location.LocationID,
 		location.LocationType,
 		location.LocationReferenceID,
-		location.ZoneCode,
-		location.CountyCode,
+		ugc.NormalizeCodeLenient(location.ZoneCode),
+		ugc.NormalizeCodeLenient(location.CountyCode),
 		location.Latitude,
 		location.Longitude,
 		location.LocationName,
+		location.ProximityRadiusMeters,
 	)
 	if err != nil {
 		return err
@@ -144,6 +161,30 @@ func (p *PostgresLocationTable) insert(transaction *sql.Tx, location data_struct
 	return nil
 }
 
+// resolveCodes fills in the zone and county codes the location is missing from the boundaries containing it. Codes
+// are left empty when no boundary has been loaded for the location, and boundary codes that do not parse are skipped.
+func (p *PostgresLocationTable) resolveCodes(transaction *sql.Tx, location data_structures.Location) (data_structures.Location, error) {
+	codes, err := p.boundaryTable.ResolveCodesTx(transaction, geojson_v2.Point{Latitude: location.Latitude, Longitude: location.Longitude})
+	if err != nil {
+		return location, err
+	}
+
+	for _, value := range codes {
+		code, err := ugc.ParseCode(value)
+		if err != nil {
+			continue
+		}
+
+		if code.IsZone() && location.ZoneCode == "" {
+			location.ZoneCode = code.String()
+		} else if code.IsCounty() && location.CountyCode == "" {
+			location.CountyCode = code.String()
+		}
+	}
+
+	return location, nil
+}
+
 func (p *PostgresLocationTable) Select(locationID string) (*data_structures.Location, error) {
 	query := `
 	SELECT 
@@ -155,6 +196,7 @@ func (p *PostgresLocationTable) Select(locationID string) (*data_structures.Loca
 		location.latitude,
 		location.lng,
 		location.locationName,
+		location.proximityRadius,
 		location.created,
 		locationOptions.option,
 		locationOptions.optionType
@@ -190,6 +232,7 @@ func (p *PostgresLocationTable) SelectByUserID(userID string) ([]data_structures
 		location.latitude,
 		location.longitude,
 		location.locationName,
+		location.proximityRadius,
 		location.created,
 		locationOptions.option,
 		locationOptions.optionType
@@ -216,6 +259,7 @@ func (p *PostgresLocationTable) SelectByDeviceID(deviceID string) ([]data_struct
 		location.latitude,
 		location.longitude,
 		location.locationName,
+		location.proximityRadius,
 		location.created,
 		locationOptions.option,
 		locationOptions.optionType
@@ -231,7 +275,11 @@ func (p *PostgresLocationTable) SelectByDeviceID(deviceID string) ([]data_struct
 	return p.scanRows(rows)
 }
 
+// SelectByCodes returns the locations with a zone or county code among the codes. Codes that do not parse are matched
+// as they are.
 func (p *PostgresLocationTable) SelectByCodes(codes []string) ([]data_structures.Location, error) {
+	codes = ugc.NormalizeLenient(codes)
+
 	var userNotifications []data_structures.Location
 	for _, code := range codes {
 		query := `
@@ -244,6 +292,7 @@ func (p *PostgresLocationTable) SelectByCodes(codes []string) ([]data_structures
 			location.latitude,
 			location.longitude,
 			location.locationName,
+			location.proximityRadius,
 			location.created,
 			locationOptions.option,
 			locationOptions.optionType
@@ -279,6 +328,7 @@ func (p *PostgresLocationTable) SelectNotificationsWithConvectiveOutlook() ([]da
 		location.latitude,
 		location.longitude,
 		location.locationName,
+		location.proximityRadius,
 		location.created,
 		locationOptions.option,
 		locationOptions.optionType
@@ -319,6 +369,7 @@ func (p *PostgresLocationTable) SelectNotificationsWithMDNotifications() ([]data
 		location.latitude,
 		location.longitude,
 		location.locationName,
+		location.proximityRadius,
 		location.created,
 		locationOptions.option,
 		locationOptions.optionType
@@ -409,6 +460,7 @@ func (p *PostgresLocationTable) scanRows(rows *sql.Rows) ([]data_structures.Loca
 		var latitude float64
 		var longitude float64
 		var locationName string
+		var proximityRadius float64
 		var created time.Time
 		var option string
 		var optionType LocationOptionType
@@ -422,6 +474,7 @@ func (p *PostgresLocationTable) scanRows(rows *sql.Rows) ([]data_structures.Loca
 			&latitude,
 			&longitude,
 			&locationName,
+			&proximityRadius,
 			&created,
 			&option,
 			&optionType,
@@ -432,15 +485,16 @@ func (p *PostgresLocationTable) scanRows(rows *sql.Rows) ([]data_structures.Loca
 
 		if locations[locationID] == nil {
 			locations[locationID] = &data_structures.Location{
-				LocationID:          locationID,
-				LocationType:        locationType,
-				LocationReferenceID: locationReferenceID,
-				ZoneCode:            zoneCode,
-				CountyCode:          countyCode,
-				Latitude:            latitude,
-				Longitude:           longitude,
-				LocationName:        locationName,
-				Created:             created,
+				LocationID:            locationID,
+				LocationType:          locationType,
+				LocationReferenceID:   locationReferenceID,
+				ZoneCode:              zoneCode,
+				CountyCode:            countyCode,
+				Latitude:              latitude,
+				Longitude:             longitude,
+				LocationName:          locationName,
+				ProximityRadiusMeters: proximityRadius,
+				Created:               created,
 			}
 		}
 
@@ -0,0 +1,101 @@
+package sql
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+
+	"github.com/cmeyer18/weather-common/v6/data_structures"
+	"github.com/cmeyer18/weather-common/v6/data_structures/geojson_v2"
+)
+
+type fakeBoundaryTable struct {
+	IUGCBoundaryTable
+	codes []string
+}
+
+func (f *fakeBoundaryTable) ResolveCodesTx(*sql.Tx, geojson_v2.Point) ([]string, error) {
+	return f.codes, nil
+}
+
+func TestLocationInsertNormalizesCodes(t *testing.T) {
+	db, fake := newFakeDatabase(t)
+	table := NewPostgresLocationTable(db)
+
+	err := table.Insert(data_structures.Location{LocationID: "home", ZoneCode: "nez052", CountyCode: "XXC055"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	statements := fake.find("INSERT INTO location (")
+	if len(statements) != 1 {
+		t.Fatalf("statements = %+v", statements)
+	}
+
+	// The county code does not parse and is kept as it is.
+	if statements[0].args[3] != "NEZ052" || statements[0].args[4] != "XXC055" {
+		t.Errorf("codes = %v and %v, want NEZ052 and XXC055", statements[0].args[3], statements[0].args[4])
+	}
+}
+
+func TestLocationInsertResolvesCodes(t *testing.T) {
+	db, fake := newFakeDatabase(t)
+	table := NewPostgresLocationTable(db)
+	table.boundaryTable = &fakeBoundaryTable{codes: []string{"not a code", "NEC055", "NEZ052", "NEZ053"}}
+
+	err := table.Insert(data_structures.Location{LocationID: "home", Latitude: 41.26, Longitude: -96.01})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	statements := fake.find("INSERT INTO location (")
+	if len(statements) != 1 {
+		t.Fatalf("statements = %+v", statements)
+	}
+
+	if statements[0].args[3] != "NEZ052" || statements[0].args[4] != "NEC055" {
+		t.Errorf("codes = %v and %v, want NEZ052 and NEC055", statements[0].args[3], statements[0].args[4])
+	}
+}
+
+func TestLocationSelectByCodesInvalidCode(t *testing.T) {
+	db, fake := newFakeDatabase(t)
+	table := NewPostgresLocationTable(db)
+
+	_, err := table.SelectByCodes([]string{"nez052", "not a code"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	statements := fake.find("WHERE zoneCode = $1 OR countyCode = $1")
+	if len(statements) != 2 || statements[0].args[0] != "NEZ052" || statements[1].args[0] != "not a code" {
+		t.Errorf("statements = %+v, want one query per code", statements)
+	}
+}
+
+func TestLocationInsertResolvesCodesInTransaction(t *testing.T) {
+	db, fake := newFakeDatabase(t)
+	table := NewPostgresLocationTable(db)
+
+	fake.respond("FROM ugcBoundary", []string{"code"}, []driver.Value{"NEC055"}, []driver.Value{"NEZ052"})
+
+	err := table.Insert(data_structures.Location{LocationID: "home", ZoneCode: "NEZ053", Latitude: 41.26, Longitude: -96.01})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resolved := fake.find("FROM ugcBoundary")
+	if len(resolved) != 1 || !resolved[0].inTx {
+		t.Fatalf("statements = %+v, want the codes resolved within the insert transaction", resolved)
+	}
+
+	statements := fake.find("INSERT INTO location (")
+	if len(statements) != 1 {
+		t.Fatalf("statements = %+v", statements)
+	}
+
+	// The zone code given with the location is kept.
+	if statements[0].args[3] != "NEZ053" || statements[0].args[4] != "NEC055" {
+		t.Errorf("codes = %v and %v, want NEZ053 and NEC055", statements[0].args[3], statements[0].args[4])
+	}
+}
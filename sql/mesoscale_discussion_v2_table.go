@@ -2,8 +2,6 @@ package sql
 
 import (
 	"database/sql"
-	"encoding/json"
-	"fmt"
 
 	"github.com/cmeyer18/weather-common/v6/data_structures"
 	"github.com/cmeyer18/weather-common/v6/data_structures/geojson_v2"
@@ -22,18 +20,22 @@ type IMesoscaleDiscussionV2Table interface {
 
 	SelectLatestByLocation(point geojson_v2.Point) ([]data_structures.MesoscaleDiscussionV2, error)
 
+	SelectLatestByBBox(bbox geojson_v2.BBox) ([]data_structures.MesoscaleDiscussionV2, error)
+
 	SelectLatest() ([]data_structures.MesoscaleDiscussionV2, error)
 
 	Delete(year, mdNumber int) error
 }
 
 type PostgresMesoscaleDiscussionV2Table struct {
-	db *sql.DB
+	db      *sql.DB
+	options geometryOptions
 }
 
-func NewPostgresMesoscaleDiscussionV2Table(db *sql.DB) PostgresMesoscaleDiscussionV2Table {
+func NewPostgresMesoscaleDiscussionV2Table(db *sql.DB, options ...GeometryOption) PostgresMesoscaleDiscussionV2Table {
 	return PostgresMesoscaleDiscussionV2Table{
-		db: db,
+		db:      db,
+		options: newGeometryOptions(options),
 	}
 }
 
@@ -45,10 +47,7 @@ func (p *PostgresMesoscaleDiscussionV2Table) Insert(md data_structures.Mesoscale
 		$1, 
 		$2, 
 		$3,
-		CASE 
-			WHEN $4::TEXT IS NULL OR $4::TEXT = '' OR  jsonb_typeof($4::JSONB) = 'null' THEN NULL 
-			ELSE ST_GeomFromGeoJSON($4::JSONB) 
-		END,
+		$4::geometry,
 		$5,
 		$6,
 		$7,
@@ -58,15 +57,12 @@ func (p *PostgresMesoscaleDiscussionV2Table) Insert(md data_structures.Mesoscale
 	}
 	defer statement.Close()
 
-	var marshalledGeometryBytes []byte
-	if md.Geometry != nil {
-		marshalledGeometryBytes, err = json.Marshal(&md.Geometry)
-		if err != nil {
-			return err
-		}
+	geometry, err := p.options.prepareForInsert(md.Geometry)
+	if err != nil {
+		return err
 	}
 
-	_, err = statement.Exec(md.ID, md.Number, md.Year, marshalledGeometryBytes, md.RawText, md.ProbabilityOfWatchIssuance, md.Effective, md.Expires)
+	_, err = statement.Exec(md.ID, md.Number, md.Year, geometry, md.RawText, md.ProbabilityOfWatchIssuance, md.Effective, md.Expires)
 	if err != nil {
 		return err
 	}
@@ -75,7 +71,7 @@ func (p *PostgresMesoscaleDiscussionV2Table) Insert(md data_structures.Mesoscale
 }
 
 func (p *PostgresMesoscaleDiscussionV2Table) Select(year, mdNumber int) (*data_structures.MesoscaleDiscussionV2, error) {
-	statement, err := p.db.Prepare(`SELECT id, number, year, geometry::JSONB, rawText, probabilityOfWatchIssuance, effective, expires FROM mesoscaleDiscussionV2 WHERE year = $1 AND mdNumber = $2`)
+	statement, err := p.db.Prepare(`SELECT id, number, year, geometry, rawText, probabilityOfWatchIssuance, effective, expires FROM mesoscaleDiscussionV2 WHERE year = $1 AND mdNumber = $2`)
 	if err != nil {
 		return nil, err
 	}
@@ -83,12 +79,11 @@ func (p *PostgresMesoscaleDiscussionV2Table) Select(year, mdNumber int) (*data_s
 	row := statement.QueryRow(year, mdNumber)
 
 	md := data_structures.MesoscaleDiscussionV2{}
-	var marshalledGeometry []byte
 	err = row.Scan(
 		&md.ID,
 		&md.Number,
 		&md.Year,
-		&marshalledGeometry,
+		&md.Geometry,
 		&md.RawText,
 		&md.ProbabilityOfWatchIssuance,
 		&md.Effective,
@@ -98,18 +93,13 @@ func (p *PostgresMesoscaleDiscussionV2Table) Select(year, mdNumber int) (*data_s
 		return nil, err
 	}
 
-	if !(string(marshalledGeometry) == "" || string(marshalledGeometry) == `""` || string(marshalledGeometry) == "null") {
-		err = json.Unmarshal(marshalledGeometry, &md.Geometry)
-		if err != nil {
-			return nil, err
-		}
-	}
+	md.Geometry = p.options.prepareForSelect(md.Geometry)
 
 	return &md, nil
 }
 
 func (p *PostgresMesoscaleDiscussionV2Table) SelectById(id string) (*data_structures.MesoscaleDiscussionV2, error) {
-	statement, err := p.db.Prepare(`SELECT id, number, year, geometry::JSONB, rawText, probabilityOfWatchIssuance, effective, expires FROM mesoscaleDiscussionV2 WHERE id = $1`)
+	statement, err := p.db.Prepare(`SELECT id, number, year, geometry, rawText, probabilityOfWatchIssuance, effective, expires FROM mesoscaleDiscussionV2 WHERE id = $1`)
 	if err != nil {
 		return nil, err
 	}
@@ -117,12 +107,11 @@ func (p *PostgresMesoscaleDiscussionV2Table) SelectById(id string) (*data_struct
 	row := statement.QueryRow(id)
 
 	md := data_structures.MesoscaleDiscussionV2{}
-	var marshalledGeometry []byte
 	err = row.Scan(
 		&md.ID,
 		&md.Number,
 		&md.Year,
-		&marshalledGeometry,
+		&md.Geometry,
 		&md.RawText,
 		&md.ProbabilityOfWatchIssuance,
 		&md.Effective,
@@ -132,12 +121,7 @@ func (p *PostgresMesoscaleDiscussionV2Table) SelectById(id string) (*data_struct
 		return nil, err
 	}
 
-	if !(string(marshalledGeometry) == "" || string(marshalledGeometry) == `""` || string(marshalledGeometry) == "null") {
-		err = json.Unmarshal(marshalledGeometry, &md.Geometry)
-		if err != nil {
-			return nil, err
-		}
-	}
+	md.Geometry = p.options.prepareForSelect(md.Geometry)
 
 	return &md, nil
 }
@@ -145,7 +129,7 @@ func (p *PostgresMesoscaleDiscussionV2Table) SelectById(id string) (*data_struct
 func (p *PostgresMesoscaleDiscussionV2Table) SelectLatest() ([]data_structures.MesoscaleDiscussionV2, error) {
 	statement, err := p.db.Prepare(`
 		SELECT 
-		    id, number, year, geometry::JSONB, rawText, probabilityOfWatchIssuance, effective, expires 
+		    id, number, year, geometry, rawText, probabilityOfWatchIssuance, effective, expires 
 		FROM 
 		    mesoscalediscussionv2 m
 		WHERE 
@@ -171,10 +155,36 @@ func (p *PostgresMesoscaleDiscussionV2Table) SelectLatest() ([]data_structures.M
 
 func (p *PostgresMesoscaleDiscussionV2Table) SelectLatestByLocation(point geojson_v2.Point) ([]data_structures.MesoscaleDiscussionV2, error) {
 	statement, err := p.db.Prepare(`
-		SELECT id, number, year, geometry::JSONB, rawText, probabilityOfWatchIssuance, effective, expires 
+		SELECT id, number, year, geometry, rawText, probabilityOfWatchIssuance, effective, expires 
+		FROM mesoscalediscussionv2 m
+		WHERE 
+			ST_Contains(m.geometry, $1::geometry) AND
+			m.expires >= NOW()
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer statement.Close()
+
+	rows, err := statement.Query(&geojson_v2.Geometry{Point: &point})
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	mds, err := p.processMesoscaleDiscussions(rows)
+	if err != nil {
+		return nil, err
+	}
+	return mds, nil
+}
+
+func (p *PostgresMesoscaleDiscussionV2Table) SelectLatestByBBox(bbox geojson_v2.BBox) ([]data_structures.MesoscaleDiscussionV2, error) {
+	statement, err := p.db.Prepare(`
+		SELECT id, number, year, geometry, rawText, probabilityOfWatchIssuance, effective, expires 
 		FROM mesoscalediscussionv2 m
 		WHERE 
-			ST_Contains(m.geometry, ST_GeomFromText($1, 4326)) AND
+			ST_Intersects(m.geometry, $1::geometry) AND
 			m.expires >= NOW()
 	`)
 	if err != nil {
@@ -182,8 +192,7 @@ func (p *PostgresMesoscaleDiscussionV2Table) SelectLatestByLocation(point geojso
 	}
 	defer statement.Close()
 
-	pointString := fmt.Sprintf("POINT (%f %f)", point.Longitude, point.Latitude)
-	rows, err := statement.Query(pointString)
+	rows, err := statement.Query(bbox.Geometry())
 	if err != nil {
 		return nil, err
 	}
@@ -248,19 +257,13 @@ func (p *PostgresMesoscaleDiscussionV2Table) processMesoscaleDiscussions(rows *s
 	var mds []data_structures.MesoscaleDiscussionV2
 	for rows.Next() {
 		md := data_structures.MesoscaleDiscussionV2{}
-		var marshalledGeometry []byte
 
-		err := rows.Scan(&md.ID, &md.Number, &md.Year, &marshalledGeometry, &md.RawText, &md.ProbabilityOfWatchIssuance, &md.Effective, &md.Expires)
+		err := rows.Scan(&md.ID, &md.Number, &md.Year, &md.Geometry, &md.RawText, &md.ProbabilityOfWatchIssuance, &md.Effective, &md.Expires)
 		if err != nil {
 			return nil, err
 		}
 
-		if !(string(marshalledGeometry) == "" || string(marshalledGeometry) == `""` || string(marshalledGeometry) == "null") {
-			err = json.Unmarshal(marshalledGeometry, &md.Geometry)
-			if err != nil {
-				return nil, err
-			}
-		}
+		md.Geometry = p.options.prepareForSelect(md.Geometry)
 
 		mds = append(mds, md)
 	}
@@ -0,0 +1,218 @@
+package sql
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/lib/pq"
+
+	"github.com/cmeyer18/weather-common/v6/data_structures"
+	"github.com/cmeyer18/weather-common/v6/data_structures/geojson_v2"
+	"github.com/cmeyer18/weather-common/v6/data_structures/ugc"
+)
+
+var _ IUGCBoundaryTable = (*PostgresUGCBoundaryTable)(nil)
+
+type IUGCBoundaryTable interface {
+	Insert(boundaries []data_structures.UGCBoundary) error
+
+	LoadFile(path string) error
+
+	Select(code string) (*data_structures.UGCBoundary, error)
+
+	ResolveCodes(point geojson_v2.Point) ([]string, error)
+
+	ResolveCodesTx(tx *sql.Tx, point geojson_v2.Point) ([]string, error)
+
+	SelectGeometryByCodes(codes []string) (*geojson_v2.Geometry, error)
+
+	SelectGeometryByCodesTx(tx *sql.Tx, codes []string) (*geojson_v2.Geometry, error)
+
+	Delete(code string) error
+}
+
+type PostgresUGCBoundaryTable struct {
+	db      *sql.DB
+	options geometryOptions
+}
+
+func NewPostgresUGCBoundaryTable(db *sql.DB, options ...GeometryOption) PostgresUGCBoundaryTable {
+	return PostgresUGCBoundaryTable{
+		db:      db,
+		options: newGeometryOptions(options),
+	}
+}
+
+// Insert adds the boundaries in one transaction, replacing the boundaries already stored for their codes.
+func (p *PostgresUGCBoundaryTable) Insert(boundaries []data_structures.UGCBoundary) error {
+	ctx := context.Background()
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	statement, err := tx.Prepare(`
+	INSERT INTO ugcBoundary (code, type, name, geometry)
+	VALUES ($1, $2, $3, $4::geometry)
+	ON CONFLICT (code) DO UPDATE SET
+		type = EXCLUDED.type,
+		name = EXCLUDED.name,
+		geometry = EXCLUDED.geometry`)
+	if err != nil {
+		return err
+	}
+	defer statement.Close()
+
+	for _, boundary := range boundaries {
+		code, err := ugc.ParseCode(boundary.Code)
+		if err != nil {
+			return err
+		}
+
+		geometry, err := p.options.prepareForInsert(boundary.Geometry)
+		if err != nil {
+			return err
+		}
+
+		_, err = statement.Exec(code.String(), string(code.Type), boundary.Name, geometry)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// LoadFile inserts the boundaries of a GeoJSON file converted from an NWS county, zone or marine zone shapefile.
+func (p *PostgresUGCBoundaryTable) LoadFile(path string) error {
+	boundaries, err := data_structures.ReadUGCBoundariesFile(path)
+	if err != nil {
+		return err
+	}
+
+	return p.Insert(boundaries)
+}
+
+func (p *PostgresUGCBoundaryTable) Select(code string) (*data_structures.UGCBoundary, error) {
+	normalizedCode, err := ugc.NormalizeCode(code)
+	if err != nil {
+		return nil, err
+	}
+
+	statement, err := p.db.Prepare(`SELECT code, name, geometry FROM ugcBoundary WHERE code = $1`)
+	if err != nil {
+		return nil, err
+	}
+	defer statement.Close()
+
+	var boundary data_structures.UGCBoundary
+	err = statement.QueryRow(normalizedCode).Scan(&boundary.Code, &boundary.Name, &boundary.Geometry)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	boundary.Geometry = p.options.prepareForSelect(boundary.Geometry)
+
+	return &boundary, nil
+}
+
+// ResolveCodes returns the codes of every boundary containing the point, county codes before zone codes.
+func (p *PostgresUGCBoundaryTable) ResolveCodes(point geojson_v2.Point) ([]string, error) {
+	return p.resolveCodes(p.db.Prepare, point)
+}
+
+// ResolveCodesTx is ResolveCodes within tx.
+func (p *PostgresUGCBoundaryTable) ResolveCodesTx(tx *sql.Tx, point geojson_v2.Point) ([]string, error) {
+	return p.resolveCodes(tx.Prepare, point)
+}
+
+func (p *PostgresUGCBoundaryTable) resolveCodes(prepare func(query string) (*sql.Stmt, error), point geojson_v2.Point) ([]string, error) {
+	statement, err := prepare(`
+	SELECT code
+	FROM ugcBoundary
+	WHERE ST_Intersects(geometry, $1::geometry)
+	ORDER BY type, code`)
+	if err != nil {
+		return nil, err
+	}
+	defer statement.Close()
+
+	rows, err := statement.Query(&geojson_v2.Geometry{Point: &point})
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var codes []string
+	for rows.Next() {
+		var code string
+		err := rows.Scan(&code)
+		if err != nil {
+			return nil, err
+		}
+
+		codes = append(codes, code)
+	}
+
+	return codes, rows.Err()
+}
+
+// SelectGeometryByCodes returns the union of the boundaries of the codes, such as to draw an alert that is only
+// described by its UGC codes, or nil when none of the codes has a boundary.
+func (p *PostgresUGCBoundaryTable) SelectGeometryByCodes(codes []string) (*geojson_v2.Geometry, error) {
+	normalizedCodes, err := ugc.Normalize(codes)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.selectGeometryByCodes(p.db.Prepare, normalizedCodes)
+}
+
+// SelectGeometryByCodesTx is SelectGeometryByCodes within tx. Codes that do not parse are kept as they are and match
+// no boundary.
+func (p *PostgresUGCBoundaryTable) SelectGeometryByCodesTx(tx *sql.Tx, codes []string) (*geojson_v2.Geometry, error) {
+	return p.selectGeometryByCodes(tx.Prepare, ugc.NormalizeLenient(codes))
+}
+
+func (p *PostgresUGCBoundaryTable) selectGeometryByCodes(prepare func(query string) (*sql.Stmt, error), codes []string) (*geojson_v2.Geometry, error) {
+	statement, err := prepare(`SELECT ST_Union(geometry) FROM ugcBoundary WHERE code = ANY($1::VARCHAR[])`)
+	if err != nil {
+		return nil, err
+	}
+	defer statement.Close()
+
+	var geometry *geojson_v2.Geometry
+	err = statement.QueryRow(pq.Array(codes)).Scan(&geometry)
+	if err != nil {
+		return nil, err
+	}
+
+	if geometry.IsEmpty() {
+		return nil, nil
+	}
+
+	return p.options.prepareForSelect(geometry), nil
+}
+
+func (p *PostgresUGCBoundaryTable) Delete(code string) error {
+	normalizedCode, err := ugc.NormalizeCode(code)
+	if err != nil {
+		return err
+	}
+
+	statement, err := p.db.Prepare(`DELETE FROM ugcBoundary WHERE code = $1`)
+	if err != nil {
+		return err
+	}
+	defer statement.Close()
+
+	_, err = statement.Exec(normalizedCode)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,25 @@
+package sql
+
+import (
+	"testing"
+)
+
+func TestUGCBoundaryDelete(t *testing.T) {
+	db, fake := newFakeDatabase(t)
+	table := NewPostgresUGCBoundaryTable(db)
+
+	err := table.Delete(" nez052 ")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	statements := fake.find("DELETE FROM ugcBoundary")
+	if len(statements) != 1 || statements[0].args[0] != "NEZ052" {
+		t.Errorf("statements = %+v, want the normalized code deleted", statements)
+	}
+
+	err = table.Delete("not a code")
+	if err == nil {
+		t.Error("err = nil, want an error for a code that does not parse")
+	}
+}
@@ -0,0 +1,43 @@
+package topojson
+
+import (
+	"slices"
+	"time"
+
+	"github.com/cmeyer18/weather-common/v6/data_structures"
+	"github.com/cmeyer18/weather-common/v6/generative/golang"
+)
+
+// ConvectiveOutlooks builds a topology from the results of SelectAllLatest, with an object per outlook type holding
+// its outlooks. Nested risk levels of an outlook share their boundaries as arcs, so each boundary is written once.
+func ConvectiveOutlooks(outlooks map[golang.ConvectiveOutlookType][]data_structures.ConvectiveOutlookV2, quantization int) (*Topology, error) {
+	outlookTypes := make([]golang.ConvectiveOutlookType, 0, len(outlooks))
+	for outlookType := range outlooks {
+		outlookTypes = append(outlookTypes, outlookType)
+	}
+	slices.Sort(outlookTypes)
+
+	layers := make([]Layer, len(outlookTypes))
+	for i, outlookType := range outlookTypes {
+		layers[i].Name = string(outlookType)
+		for _, outlook := range outlooks[outlookType] {
+			layers[i].Features = append(layers[i].Features, Feature{
+				ID:       outlook.ID,
+				Geometry: outlook.Geometry,
+				Properties: map[string]any{
+					"outlookType": string(outlook.OutlookType),
+					"dn":          outlook.DN,
+					"valid":       outlook.Valid.Format(time.RFC3339),
+					"expires":     outlook.Expires.Format(time.RFC3339),
+					"issued":      outlook.Issued.Format(time.RFC3339),
+					"label":       outlook.Label,
+					"label2":      outlook.Label2,
+					"stroke":      outlook.Stroke,
+					"fill":        outlook.Fill,
+				},
+			})
+		}
+	}
+
+	return Encode(layers, quantization)
+}
@@ -0,0 +1,74 @@
+package topojson
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/cmeyer18/weather-common/v6/data_structures"
+	"github.com/cmeyer18/weather-common/v6/generative/golang"
+)
+
+func TestConvectiveOutlooks(t *testing.T) {
+	issued := time.Date(2024, 5, 6, 12, 0, 0, 0, time.UTC)
+	outlooks := map[golang.ConvectiveOutlookType][]data_structures.ConvectiveOutlookV2{
+		golang.Day1Tornado: {{
+			ID:          "tornado",
+			OutlookType: golang.Day1Tornado,
+			Geometry:    mustGeometry(t, `{"type":"Polygon","coordinates":[[[0,0],[1,0],[1,1],[0,1],[0,0]]]}`),
+			DN:          2,
+			Issued:      issued,
+		}},
+		golang.Day1Categorical: {
+			{
+				ID:          "categorical",
+				OutlookType: golang.Day1Categorical,
+				Geometry:    mustGeometry(t, `{"type":"Polygon","coordinates":[[[0,0],[2,0],[2,2],[0,2],[0,0]]]}`),
+				DN:          2,
+				Label:       "MRGL",
+				Issued:      issued,
+			},
+			{
+				ID:          "categorical",
+				OutlookType: golang.Day1Categorical,
+				Geometry:    mustGeometry(t, `{"type":"Polygon","coordinates":[[[0,0],[1,0],[1,2],[0,2],[0,0]]]}`),
+				DN:          3,
+				Label:       "SLGT",
+				Issued:      issued,
+			},
+		},
+	}
+
+	topology, err := ConvectiveOutlooks(outlooks, DefaultQuantization)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(topology.Objects) != 2 {
+		t.Fatalf("objects = %v, want one per outlook type", topology.Objects)
+	}
+
+	categorical := topology.Objects[string(golang.Day1Categorical)]
+	if categorical == nil || len(categorical.Geometries) != 2 {
+		t.Fatalf("categorical = %+v", categorical)
+	}
+
+	marginal := categorical.Geometries[0]
+	if marginal.Properties["label"] != "MRGL" || marginal.Properties["dn"] != 2 ||
+		marginal.Properties["issued"] != "2024-05-06T12:00:00Z" {
+		t.Errorf("properties = %v", marginal.Properties)
+	}
+
+	// The slight risk shares its western half of the marginal boundary.
+	marginalArcs := marginal.Arcs.([][]int)[0]
+	slightArcs := categorical.Geometries[1].Arcs.([][]int)[0]
+	shared := false
+	for _, reference := range slightArcs {
+		if slices.Contains(marginalArcs, reference) || slices.Contains(marginalArcs, ^reference) {
+			shared = true
+		}
+	}
+	if !shared {
+		t.Errorf("marginal %v and slight %v share no arcs", marginalArcs, slightArcs)
+	}
+}
@@ -0,0 +1,483 @@
+package topojson
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/cmeyer18/weather-common/v6/data_structures/geojson_v2"
+)
+
+// DefaultQuantization is the number of distinct positions along each axis of the topology, about 100 meters across
+// the continental United States.
+const DefaultQuantization = 100000
+
+// Topology is a TopoJSON topology. Boundaries shared by geometries are stored once in Arcs and referenced by index
+// from every geometry they bound, and positions are quantized to integers and delta encoded.
+type Topology struct {
+	Type      string             `json:"type"`
+	BBox      []float64          `json:"bbox,omitempty"`
+	Transform *Transform         `json:"transform,omitempty"`
+	Objects   map[string]*Object `json:"objects"`
+	Arcs      [][][2]int         `json:"arcs"`
+}
+
+// Transform converts quantized positions back to longitude and latitude.
+type Transform struct {
+	Scale     [2]float64 `json:"scale"`
+	Translate [2]float64 `json:"translate"`
+}
+
+// Object is a TopoJSON geometry object. Lines and polygons reference arcs, where a negative index ~i is arc i reversed,
+// and points hold quantized positions.
+type Object struct {
+	Type        string         `json:"type"`
+	ID          string         `json:"id,omitempty"`
+	Properties  map[string]any `json:"properties,omitempty"`
+	Arcs        any            `json:"arcs,omitempty"`
+	Coordinates any            `json:"coordinates,omitempty"`
+	Geometries  []*Object      `json:"geometries,omitempty"`
+}
+
+// Feature is a geometry with its identifier and properties, to be added to a topology.
+type Feature struct {
+	ID         string
+	Properties map[string]any
+	Geometry   *geojson_v2.Geometry
+}
+
+// Layer is a named list of features, added to a topology as a geometry collection object.
+type Layer struct {
+	Name     string
+	Features []Feature
+}
+
+type position = [2]int
+
+// quantizedGeometry is a geometry with positions quantized, before it is cut into arcs.
+type quantizedGeometry struct {
+	geometryType string
+	points       []position
+	lines        [][]position
+	polygons     [][][]position
+	geometries   []*quantizedGeometry
+}
+
+type encoder struct {
+	transform  Transform
+	junctions  map[position]bool
+	neighbors  map[position][2]position
+	arcs       [][]position
+	arcIndexes map[string]int
+}
+
+// Encode builds a topology with a geometry collection object per layer, quantizing positions to a grid of quantization
+// positions along each axis. Features without a geometry are left out, as are lines and rings that collapse once
+// quantized.
+func Encode(layers []Layer, quantization int) (*Topology, error) {
+	if quantization < 2 {
+		return nil, fmt.Errorf("invalid quantization: %d", quantization)
+	}
+
+	bbox := layersBBox(layers)
+	if bbox == nil {
+		return &Topology{Type: "Topology", Objects: make(map[string]*Object), Arcs: [][][2]int{}}, nil
+	}
+
+	e := &encoder{
+		transform:  newTransform(*bbox, quantization),
+		junctions:  make(map[position]bool),
+		neighbors:  make(map[position][2]position),
+		arcIndexes: make(map[string]int),
+	}
+
+	quantized := make([][]*quantizedGeometry, len(layers))
+	for i, layer := range layers {
+		for _, feature := range layer.Features {
+			var geometry *quantizedGeometry
+			if !feature.Geometry.IsEmpty() {
+				geometry = e.quantize(feature.Geometry)
+			}
+			quantized[i] = append(quantized[i], geometry)
+		}
+	}
+
+	for _, geometries := range quantized {
+		for _, geometry := range geometries {
+			e.findJunctions(geometry)
+		}
+	}
+
+	topology := &Topology{
+		Type:      "Topology",
+		BBox:      []float64{bbox.MinLongitude, bbox.MinLatitude, bbox.MaxLongitude, bbox.MaxLatitude},
+		Transform: &e.transform,
+		Objects:   make(map[string]*Object),
+	}
+
+	for i, layer := range layers {
+		collection := &Object{Type: "GeometryCollection", Geometries: []*Object{}}
+		for j, feature := range layer.Features {
+			object := e.object(quantized[i][j])
+			if object == nil {
+				continue
+			}
+
+			object.ID = feature.ID
+			object.Properties = feature.Properties
+			collection.Geometries = append(collection.Geometries, object)
+		}
+		topology.Objects[layer.Name] = collection
+	}
+
+	topology.Arcs = make([][][2]int, len(e.arcs))
+	for i, arc := range e.arcs {
+		topology.Arcs[i] = deltaEncode(arc)
+	}
+
+	return topology, nil
+}
+
+// layersBBox returns the planar bounds of every feature geometry, or nil when there are none.
+func layersBBox(layers []Layer) *geojson_v2.BBox {
+	var bbox *geojson_v2.BBox
+	for _, layer := range layers {
+		for _, feature := range layer.Features {
+			geometryBBox := feature.Geometry.BBox()
+			if geometryBBox == nil {
+				continue
+			}
+
+			for _, part := range geometryBBox.Split() {
+				if bbox == nil {
+					bbox = &part
+					continue
+				}
+
+				bbox.MinLongitude = math.Min(bbox.MinLongitude, part.MinLongitude)
+				bbox.MinLatitude = math.Min(bbox.MinLatitude, part.MinLatitude)
+				bbox.MaxLongitude = math.Max(bbox.MaxLongitude, part.MaxLongitude)
+				bbox.MaxLatitude = math.Max(bbox.MaxLatitude, part.MaxLatitude)
+			}
+		}
+	}
+	return bbox
+}
+
+func newTransform(bbox geojson_v2.BBox, quantization int) Transform {
+	scale := func(min, max float64) float64 {
+		if max > min {
+			return (max - min) / float64(quantization-1)
+		}
+		return 1
+	}
+
+	return Transform{
+		Scale:     [2]float64{scale(bbox.MinLongitude, bbox.MaxLongitude), scale(bbox.MinLatitude, bbox.MaxLatitude)},
+		Translate: [2]float64{bbox.MinLongitude, bbox.MinLatitude},
+	}
+}
+
+func (e *encoder) quantizePoint(point *geojson_v2.Point) position {
+	return position{
+		int(math.Round((point.Longitude - e.transform.Translate[0]) / e.transform.Scale[0])),
+		int(math.Round((point.Latitude - e.transform.Translate[1]) / e.transform.Scale[1])),
+	}
+}
+
+// quantizePath quantizes the points, dropping positions that repeat the one before them.
+func (e *encoder) quantizePath(points []*geojson_v2.Point) []position {
+	var path []position
+	for _, point := range points {
+		quantized := e.quantizePoint(point)
+		if len(path) == 0 || path[len(path)-1] != quantized {
+			path = append(path, quantized)
+		}
+	}
+	return path
+}
+
+// quantizeRing quantizes a ring, returning it open with the closing position removed, or nil when fewer than three
+// distinct positions remain.
+func (e *encoder) quantizeRing(ring *geojson_v2.MultiPoint) []position {
+	if ring == nil {
+		return nil
+	}
+
+	path := e.quantizePath(ring.Points)
+	if len(path) > 1 && path[0] == path[len(path)-1] {
+		path = path[:len(path)-1]
+	}
+
+	if len(path) < 3 {
+		return nil
+	}
+	return path
+}
+
+// quantizePolygon quantizes the rings of the polygon, returning nil when the outer ring collapses.
+func (e *encoder) quantizePolygon(polygon *geojson_v2.Polygon) [][]position {
+	outer := e.quantizeRing(polygon.OuterPath)
+	if outer == nil {
+		return nil
+	}
+
+	rings := [][]position{outer}
+	for _, innerPath := range polygon.InnerPaths {
+		inner := e.quantizeRing(innerPath)
+		if inner != nil {
+			rings = append(rings, inner)
+		}
+	}
+	return rings
+}
+
+func (e *encoder) quantizeLine(points []*geojson_v2.Point) []position {
+	path := e.quantizePath(points)
+	if len(path) < 2 {
+		return nil
+	}
+	return path
+}
+
+func (e *encoder) quantize(g *geojson_v2.Geometry) *quantizedGeometry {
+	if g.Point != nil {
+		return &quantizedGeometry{geometryType: "Point", points: []position{e.quantizePoint(g.Point)}}
+	} else if g.MultiPoint != nil {
+		quantized := &quantizedGeometry{geometryType: "MultiPoint"}
+		for _, point := range g.MultiPoint.Points {
+			quantized.points = append(quantized.points, e.quantizePoint(point))
+		}
+		return quantized
+	} else if g.LineString != nil {
+		quantized := &quantizedGeometry{geometryType: "LineString"}
+		line := e.quantizeLine(g.LineString.Points)
+		if line != nil {
+			quantized.lines = append(quantized.lines, line)
+		}
+		return quantized
+	} else if g.MultiLineString != nil {
+		quantized := &quantizedGeometry{geometryType: "MultiLineString"}
+		for _, lineString := range g.MultiLineString.LineStrings {
+			line := e.quantizeLine(lineString.Points)
+			if line != nil {
+				quantized.lines = append(quantized.lines, line)
+			}
+		}
+		return quantized
+	} else if g.Polygon != nil {
+		quantized := &quantizedGeometry{geometryType: "Polygon"}
+		rings := e.quantizePolygon(g.Polygon)
+		if rings != nil {
+			quantized.polygons = append(quantized.polygons, rings)
+		}
+		return quantized
+	} else if g.MultiPolygon != nil {
+		quantized := &quantizedGeometry{geometryType: "MultiPolygon"}
+		for _, polygon := range g.MultiPolygon.Polygons {
+			rings := e.quantizePolygon(polygon)
+			if rings != nil {
+				quantized.polygons = append(quantized.polygons, rings)
+			}
+		}
+		return quantized
+	} else if g.GeometryCollection != nil {
+		quantized := &quantizedGeometry{geometryType: "GeometryCollection"}
+		for _, geometry := range g.GeometryCollection.Geometries {
+			if !geometry.IsEmpty() {
+				quantized.geometries = append(quantized.geometries, e.quantize(geometry))
+			}
+		}
+		return quantized
+	}
+
+	return nil
+}
+
+// findJunctions marks the positions where paths meet or part ways. A position is a junction when it ends a line, or
+// when it is reached from different neighbors by different paths; arcs are cut at junctions so that a shared boundary
+// becomes the same arc for every path that follows it.
+func (e *encoder) findJunctions(g *quantizedGeometry) {
+	if g == nil {
+		return
+	}
+
+	for _, line := range g.lines {
+		e.junctions[line[0]] = true
+		e.junctions[line[len(line)-1]] = true
+		for i := 1; i < len(line)-1; i++ {
+			e.visit(line[i], line[i-1], line[i+1])
+		}
+	}
+
+	for _, polygon := range g.polygons {
+		for _, ring := range polygon {
+			for i := range ring {
+				e.visit(ring[i], ring[(i+len(ring)-1)%len(ring)], ring[(i+1)%len(ring)])
+			}
+		}
+	}
+
+	for _, geometry := range g.geometries {
+		e.findJunctions(geometry)
+	}
+}
+
+func (e *encoder) visit(point position, previous position, next position) {
+	if e.junctions[point] {
+		return
+	}
+
+	neighbors, ok := e.neighbors[point]
+	if !ok {
+		e.neighbors[point] = [2]position{previous, next}
+	} else if neighbors != [2]position{previous, next} && neighbors != [2]position{next, previous} {
+		e.junctions[point] = true
+	}
+}
+
+// object cuts the quantized geometry into arcs and returns the geometry object referencing them, or nil when nothing
+// is left of the geometry.
+func (e *encoder) object(g *quantizedGeometry) *Object {
+	if g == nil {
+		return nil
+	}
+
+	switch g.geometryType {
+	case "Point":
+		return &Object{Type: g.geometryType, Coordinates: g.points[0]}
+	case "MultiPoint":
+		if len(g.points) == 0 {
+			return nil
+		}
+		return &Object{Type: g.geometryType, Coordinates: g.points}
+	case "LineString":
+		if len(g.lines) == 0 {
+			return nil
+		}
+		return &Object{Type: g.geometryType, Arcs: e.cutLine(g.lines[0])}
+	case "MultiLineString":
+		if len(g.lines) == 0 {
+			return nil
+		}
+
+		arcs := make([][]int, len(g.lines))
+		for i, line := range g.lines {
+			arcs[i] = e.cutLine(line)
+		}
+		return &Object{Type: g.geometryType, Arcs: arcs}
+	case "Polygon":
+		if len(g.polygons) == 0 {
+			return nil
+		}
+		return &Object{Type: g.geometryType, Arcs: e.cutPolygon(g.polygons[0])}
+	case "MultiPolygon":
+		if len(g.polygons) == 0 {
+			return nil
+		}
+
+		arcs := make([][][]int, len(g.polygons))
+		for i, polygon := range g.polygons {
+			arcs[i] = e.cutPolygon(polygon)
+		}
+		return &Object{Type: g.geometryType, Arcs: arcs}
+	default:
+		collection := &Object{Type: g.geometryType, Geometries: []*Object{}}
+		for _, geometry := range g.geometries {
+			object := e.object(geometry)
+			if object != nil {
+				collection.Geometries = append(collection.Geometries, object)
+			}
+		}
+		return collection
+	}
+}
+
+func (e *encoder) cutLine(line []position) []int {
+	var arcs []int
+	start := 0
+	for i := 1; i < len(line); i++ {
+		if i == len(line)-1 || e.junctions[line[i]] {
+			arcs = append(arcs, e.arcIndex(line[start:i+1]))
+			start = i
+		}
+	}
+	return arcs
+}
+
+func (e *encoder) cutPolygon(polygon [][]position) [][]int {
+	arcs := make([][]int, len(polygon))
+	for i, ring := range polygon {
+		arcs[i] = e.cutRing(ring)
+	}
+	return arcs
+}
+
+// cutRing cuts the open ring at its junctions. A ring without junctions becomes a single arc, started from its
+// smallest position so that the same ring is recognized however it was started.
+func (e *encoder) cutRing(ring []position) []int {
+	start := slices.IndexFunc(ring, func(point position) bool {
+		return e.junctions[point]
+	})
+	if start < 0 {
+		start = 0
+		for i, point := range ring {
+			if point[0] < ring[start][0] || (point[0] == ring[start][0] && point[1] < ring[start][1]) {
+				start = i
+			}
+		}
+
+		closed := append(slices.Clone(ring[start:]), ring[:start]...)
+		closed = append(closed, ring[start])
+		return []int{e.arcIndex(closed)}
+	}
+
+	closed := append(slices.Clone(ring[start:]), ring[:start]...)
+	closed = append(closed, ring[start])
+	return e.cutLine(closed)
+}
+
+// arcIndex returns the index of the arc, adding it when neither it nor its reverse has been seen. A reversed arc is
+// referenced by the ones' complement of its index.
+func (e *encoder) arcIndex(arc []position) int {
+	index, ok := e.arcIndexes[arcKey(arc)]
+	if ok {
+		return index
+	}
+
+	reversed := slices.Clone(arc)
+	slices.Reverse(reversed)
+	index, ok = e.arcIndexes[arcKey(reversed)]
+	if ok {
+		return ^index
+	}
+
+	index = len(e.arcs)
+	e.arcs = append(e.arcs, arc)
+	e.arcIndexes[arcKey(arc)] = index
+	return index
+}
+
+func arcKey(arc []position) string {
+	var builder strings.Builder
+	for _, point := range arc {
+		builder.WriteString(strconv.Itoa(point[0]))
+		builder.WriteByte(',')
+		builder.WriteString(strconv.Itoa(point[1]))
+		builder.WriteByte(';')
+	}
+	return builder.String()
+}
+
+// deltaEncode writes the first position of the arc as is and every following position relative to the one before it.
+func deltaEncode(arc []position) [][2]int {
+	encoded := make([][2]int, len(arc))
+	var previous position
+	for i, point := range arc {
+		encoded[i] = [2]int{point[0] - previous[0], point[1] - previous[1]}
+		previous = point
+	}
+	return encoded
+}
@@ -0,0 +1,201 @@
+package topojson
+
+import (
+	"encoding/json"
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/cmeyer18/weather-common/v6/data_structures/geojson_v2"
+)
+
+func mustGeometry(t *testing.T, data string) *geojson_v2.Geometry {
+	t.Helper()
+
+	var geometry geojson_v2.Geometry
+	err := json.Unmarshal([]byte(data), &geometry)
+	if err != nil {
+		t.Fatalf("invalid geometry %s: %v", data, err)
+	}
+	return &geometry
+}
+
+// decodeArcs follows the arc references into absolute positions in longitude and latitude, dropping the position
+// shared by consecutive arcs.
+func decodeArcs(topology *Topology, references []int) [][2]float64 {
+	var positions [][2]float64
+	for _, reference := range references {
+		index := reference
+		if index < 0 {
+			index = ^index
+		}
+
+		var arc [][2]float64
+		var x, y int
+		for _, delta := range topology.Arcs[index] {
+			x += delta[0]
+			y += delta[1]
+			arc = append(arc, [2]float64{
+				float64(x)*topology.Transform.Scale[0] + topology.Transform.Translate[0],
+				float64(y)*topology.Transform.Scale[1] + topology.Transform.Translate[1],
+			})
+		}
+		if reference < 0 {
+			slices.Reverse(arc)
+		}
+
+		if len(positions) > 0 {
+			arc = arc[1:]
+		}
+		positions = append(positions, arc...)
+	}
+	return positions
+}
+
+// samePositions reports whether the closed rings hold the same positions in the same direction, wherever they start.
+func samePositions(ring [][2]float64, want [][2]float64) bool {
+	if len(ring) != len(want)+1 {
+		return false
+	}
+
+	open := ring[:len(ring)-1]
+	for start := range open {
+		matches := true
+		for i := range open {
+			got := open[(start+i)%len(open)]
+			if math.Abs(got[0]-want[i][0]) > 1e-9 || math.Abs(got[1]-want[i][1]) > 1e-9 {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			return true
+		}
+	}
+	return false
+}
+
+func TestEncodeSharesBoundaries(t *testing.T) {
+	west := mustGeometry(t, `{"type":"Polygon","coordinates":[[[0,0],[1,0],[1,1],[0,1],[0,0]]]}`)
+	east := mustGeometry(t, `{"type":"Polygon","coordinates":[[[1,0],[2,0],[2,1],[1,1],[1,0]]]}`)
+
+	topology, err := Encode([]Layer{{Name: "squares", Features: []Feature{
+		{ID: "west", Geometry: west},
+		{ID: "east", Geometry: east},
+	}}}, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The shared edge is stored once, along with the rest of each square.
+	if len(topology.Arcs) != 3 {
+		t.Fatalf("arcs = %v, want 3", topology.Arcs)
+	}
+
+	geometries := topology.Objects["squares"].Geometries
+	if len(geometries) != 2 || geometries[0].ID != "west" || geometries[1].ID != "east" {
+		t.Fatalf("geometries = %+v", geometries)
+	}
+
+	westArcs := geometries[0].Arcs.([][]int)[0]
+	eastArcs := geometries[1].Arcs.([][]int)[0]
+	shared := 0
+	for _, reference := range westArcs {
+		if slices.Contains(eastArcs, ^reference) {
+			shared++
+		}
+	}
+	if shared != 1 {
+		t.Errorf("west %v and east %v share %d arcs in opposite directions, want 1", westArcs, eastArcs, shared)
+	}
+
+	if ring := decodeArcs(topology, westArcs); !samePositions(ring, [][2]float64{{0, 0}, {1, 0}, {1, 1}, {0, 1}}) {
+		t.Errorf("west ring = %v", ring)
+	}
+	if ring := decodeArcs(topology, eastArcs); !samePositions(ring, [][2]float64{{1, 0}, {2, 0}, {2, 1}, {1, 1}}) {
+		t.Errorf("east ring = %v", ring)
+	}
+}
+
+func TestEncodeSharesIdenticalRings(t *testing.T) {
+	// The same ring started at different positions and wound in opposite directions.
+	first := mustGeometry(t, `{"type":"Polygon","coordinates":[[[0,0],[2,0],[2,2],[0,2],[0,0]]]}`)
+	second := mustGeometry(t, `{"type":"Polygon","coordinates":[[[2,2],[2,0],[0,0],[0,2],[2,2]]]}`)
+
+	topology, err := Encode([]Layer{
+		{Name: "slight", Features: []Feature{{Geometry: first}}},
+		{Name: "marginal", Features: []Feature{{Geometry: second}}},
+	}, DefaultQuantization)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(topology.Arcs) != 1 {
+		t.Fatalf("arcs = %v, want the ring stored once", topology.Arcs)
+	}
+
+	firstArcs := topology.Objects["slight"].Geometries[0].Arcs.([][]int)[0]
+	secondArcs := topology.Objects["marginal"].Geometries[0].Arcs.([][]int)[0]
+	if !slices.Equal(firstArcs, []int{0}) || !slices.Equal(secondArcs, []int{^0}) {
+		t.Errorf("arcs = %v and %v, want [0] and [-1]", firstArcs, secondArcs)
+	}
+}
+
+func TestEncodeLinesAndPoints(t *testing.T) {
+	topology, err := Encode([]Layer{{Name: "features", Features: []Feature{
+		{ID: "line", Geometry: mustGeometry(t, `{"type":"LineString","coordinates":[[0,0],[1,1],[2,0]]}`)},
+		{ID: "crossing", Geometry: mustGeometry(t, `{"type":"LineString","coordinates":[[1,2],[1,1],[1,0]]}`)},
+		{ID: "point", Geometry: mustGeometry(t, `{"type":"Point","coordinates":[2,2]}`)},
+		{ID: "missing"},
+	}}}, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	geometries := topology.Objects["features"].Geometries
+	if len(geometries) != 3 {
+		t.Fatalf("geometries = %+v, want the feature without a geometry left out", geometries)
+	}
+
+	// Both lines are cut where they cross at (1, 1).
+	if arcs := geometries[0].Arcs.([]int); len(arcs) != 2 {
+		t.Errorf("line arcs = %v, want 2", arcs)
+	}
+	if arcs := geometries[1].Arcs.([]int); len(arcs) != 2 {
+		t.Errorf("crossing line arcs = %v, want 2", arcs)
+	}
+	if line := decodeArcs(topology, geometries[0].Arcs.([]int)); !slices.Equal(line, [][2]float64{{0, 0}, {1, 1}, {2, 0}}) {
+		t.Errorf("line = %v", line)
+	}
+
+	if geometries[2].Type != "Point" || geometries[2].Coordinates != (position{2, 2}) {
+		t.Errorf("point = %+v", geometries[2])
+	}
+}
+
+func TestEncodeErrors(t *testing.T) {
+	_, err := Encode(nil, 1)
+	if err == nil {
+		t.Error("expected an error for a quantization below 2")
+	}
+
+	topology, err := Encode([]Layer{{Name: "empty"}}, DefaultQuantization)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	marshalled, err := json.Marshal(topology)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"type":"Topology","objects":{},"arcs":[]}`; string(marshalled) != want {
+		t.Errorf("got %s, want %s", marshalled, want)
+	}
+}
+
+func TestDeltaEncode(t *testing.T) {
+	encoded := deltaEncode([]position{{5, 5}, {7, 5}, {7, 2}, {5, 5}})
+	if want := [][2]int{{5, 5}, {2, 0}, {0, -3}, {-2, 3}}; !slices.Equal(encoded, want) {
+		t.Errorf("got %v, want %v", encoded, want)
+	}
+}
@@ -0,0 +1,358 @@
+package vector_tile
+
+import (
+	"math"
+	"slices"
+
+	"github.com/cmeyer18/weather-common/v6/data_structures/geojson_v2"
+)
+
+type geometryType uint32
+
+const (
+	geometryType_Point      geometryType = 1
+	geometryType_LineString geometryType = 2
+	geometryType_Polygon    geometryType = 3
+)
+
+const (
+	command_MoveTo    uint32 = 1
+	command_LineTo    uint32 = 2
+	command_ClosePath uint32 = 7
+)
+
+// tilePoint is a position in the coordinates of a tile before it is rounded.
+type tilePoint struct {
+	x float64
+	y float64
+}
+
+// gridPoint is a position rounded onto the integer grid of a tile.
+type gridPoint struct {
+	x int32
+	y int32
+}
+
+// encodedGeometry is a geometry of a single type as vector tile commands.
+type encodedGeometry struct {
+	geometryType geometryType
+	commands     []uint32
+}
+
+// tileShapes holds the parts of a geometry projected into a tile and clipped to its buffered bounds.
+type tileShapes struct {
+	points   []tilePoint
+	lines    [][]tilePoint
+	polygons [][][]tilePoint
+}
+
+// encodeGeometry projects, clips and encodes the geometry for the tile. Vector tiles do not mix geometry types within
+// a feature, so a geometry collection produces one encoding per type it contains. Nothing is returned when no part of
+// the geometry falls within the buffered tile.
+func encodeGeometry(tile TileID, geometry *geojson_v2.Geometry) []encodedGeometry {
+	shapes := &tileShapes{}
+	shapes.add(tile, geometry)
+
+	var encoded []encodedGeometry
+	if commands := encodePoints(shapes.points); len(commands) > 0 {
+		encoded = append(encoded, encodedGeometry{geometryType: geometryType_Point, commands: commands})
+	}
+	if commands := encodeLines(shapes.lines); len(commands) > 0 {
+		encoded = append(encoded, encodedGeometry{geometryType: geometryType_LineString, commands: commands})
+	}
+	if commands := encodePolygons(shapes.polygons); len(commands) > 0 {
+		encoded = append(encoded, encodedGeometry{geometryType: geometryType_Polygon, commands: commands})
+	}
+	return encoded
+}
+
+func (s *tileShapes) add(tile TileID, g *geojson_v2.Geometry) {
+	if g == nil {
+		return
+	}
+
+	if g.Point != nil {
+		s.addPoint(tile, g.Point)
+	} else if g.MultiPoint != nil {
+		for _, point := range g.MultiPoint.Points {
+			s.addPoint(tile, point)
+		}
+	} else if g.LineString != nil {
+		s.addLine(tile, g.LineString.Points)
+	} else if g.MultiLineString != nil {
+		for _, lineString := range g.MultiLineString.LineStrings {
+			s.addLine(tile, lineString.Points)
+		}
+	} else if g.Polygon != nil {
+		s.addPolygon(tile, g.Polygon)
+	} else if g.MultiPolygon != nil {
+		for _, polygon := range g.MultiPolygon.Polygons {
+			s.addPolygon(tile, polygon)
+		}
+	} else if g.GeometryCollection != nil {
+		for _, geometry := range g.GeometryCollection.Geometries {
+			s.add(tile, geometry)
+		}
+	}
+}
+
+func (s *tileShapes) addPoint(tile TileID, point *geojson_v2.Point) {
+	projected := projectPoint(tile, point)
+	if projected.x >= -Buffer && projected.x <= Extent+Buffer && projected.y >= -Buffer && projected.y <= Extent+Buffer {
+		s.points = append(s.points, projected)
+	}
+}
+
+func (s *tileShapes) addLine(tile TileID, points []*geojson_v2.Point) {
+	s.lines = append(s.lines, clipLine(projectPath(tile, points))...)
+}
+
+func (s *tileShapes) addPolygon(tile TileID, polygon *geojson_v2.Polygon) {
+	if polygon.OuterPath == nil {
+		return
+	}
+
+	outer := clipRing(projectPath(tile, polygon.OuterPath.Points))
+	if len(outer) < 3 {
+		return
+	}
+
+	rings := [][]tilePoint{outer}
+	for _, innerPath := range polygon.InnerPaths {
+		inner := clipRing(projectPath(tile, innerPath.Points))
+		if len(inner) >= 3 {
+			rings = append(rings, inner)
+		}
+	}
+	s.polygons = append(s.polygons, rings)
+}
+
+func projectPoint(tile TileID, point *geojson_v2.Point) tilePoint {
+	x, y := tile.project(*point)
+	return tilePoint{x: x, y: y}
+}
+
+func projectPath(tile TileID, points []*geojson_v2.Point) []tilePoint {
+	path := make([]tilePoint, len(points))
+	for i, point := range points {
+		path[i] = projectPoint(tile, point)
+	}
+	return path
+}
+
+// clipLine clips the path to the buffered tile, returning the runs of the path inside of it.
+func clipLine(path []tilePoint) [][]tilePoint {
+	var lines [][]tilePoint
+	var current []tilePoint
+	for i := 1; i < len(path); i++ {
+		a, b, ok := clipSegment(path[i-1], path[i])
+		if !ok {
+			if len(current) > 1 {
+				lines = append(lines, current)
+			}
+			current = nil
+			continue
+		}
+
+		if len(current) == 0 {
+			current = append(current, a)
+		}
+		current = append(current, b)
+
+		if b != path[i] {
+			lines = append(lines, current)
+			current = nil
+		}
+	}
+
+	if len(current) > 1 {
+		lines = append(lines, current)
+	}
+	return lines
+}
+
+// clipSegment clips the segment to the buffered tile using the Liang-Barsky algorithm, reporting whether any of it is
+// inside.
+func clipSegment(a, b tilePoint) (tilePoint, tilePoint, bool) {
+	dx := b.x - a.x
+	dy := b.y - a.y
+	enter, exit := 0.0, 1.0
+
+	edges := []struct{ p, q float64 }{
+		{-dx, a.x + Buffer},
+		{dx, Extent + Buffer - a.x},
+		{-dy, a.y + Buffer},
+		{dy, Extent + Buffer - a.y},
+	}
+	for _, edge := range edges {
+		if edge.p == 0 {
+			if edge.q < 0 {
+				return a, b, false
+			}
+			continue
+		}
+
+		t := edge.q / edge.p
+		if edge.p < 0 {
+			enter = math.Max(enter, t)
+		} else {
+			exit = math.Min(exit, t)
+		}
+	}
+
+	if enter > exit {
+		return a, b, false
+	}
+
+	clippedA, clippedB := a, b
+	if enter > 0 {
+		clippedA = tilePoint{x: a.x + enter*dx, y: a.y + enter*dy}
+	}
+	if exit < 1 {
+		clippedB = tilePoint{x: a.x + exit*dx, y: a.y + exit*dy}
+	}
+	return clippedA, clippedB, true
+}
+
+// clipRing clips the ring to the buffered tile using the Sutherland-Hodgman algorithm. The result is implicitly closed.
+func clipRing(ring []tilePoint) []tilePoint {
+	if len(ring) > 1 && ring[0] == ring[len(ring)-1] {
+		ring = ring[:len(ring)-1]
+	}
+
+	ring = clipRingEdge(ring, func(p tilePoint) float64 { return p.x + Buffer })
+	ring = clipRingEdge(ring, func(p tilePoint) float64 { return Extent + Buffer - p.x })
+	ring = clipRingEdge(ring, func(p tilePoint) float64 { return p.y + Buffer })
+	ring = clipRingEdge(ring, func(p tilePoint) float64 { return Extent + Buffer - p.y })
+	return ring
+}
+
+// clipRingEdge keeps the part of the ring where the distance to an edge of the buffered tile is not negative.
+func clipRingEdge(ring []tilePoint, distance func(p tilePoint) float64) []tilePoint {
+	var clipped []tilePoint
+	for i, current := range ring {
+		previous := ring[(i+len(ring)-1)%len(ring)]
+		currentDistance := distance(current)
+		previousDistance := distance(previous)
+
+		if (currentDistance >= 0) != (previousDistance >= 0) {
+			t := previousDistance / (previousDistance - currentDistance)
+			clipped = append(clipped, tilePoint{
+				x: previous.x + t*(current.x-previous.x),
+				y: previous.y + t*(current.y-previous.y),
+			})
+		}
+		if currentDistance >= 0 {
+			clipped = append(clipped, current)
+		}
+	}
+	return clipped
+}
+
+// roundPath rounds the path onto the integer grid, dropping positions that round onto the one before.
+func roundPath(path []tilePoint) []gridPoint {
+	var rounded []gridPoint
+	for _, point := range path {
+		gridded := gridPoint{x: int32(math.Round(point.x)), y: int32(math.Round(point.y))}
+		if len(rounded) > 0 && rounded[len(rounded)-1] == gridded {
+			continue
+		}
+		rounded = append(rounded, gridded)
+	}
+	return rounded
+}
+
+// gridRingArea returns the area of the implicitly closed ring by the surveyor's formula. Rings that are clockwise on
+// screen, with y increasing downward, have a positive area.
+func gridRingArea(ring []gridPoint) float64 {
+	area := 0.0
+	for i, j := 0, len(ring)-1; i < len(ring); j, i = i, i+1 {
+		area += float64(ring[j].x)*float64(ring[i].y) - float64(ring[i].x)*float64(ring[j].y)
+	}
+	return area / 2
+}
+
+// commandEncoder writes geometry commands with parameters relative to the position of the cursor.
+type commandEncoder struct {
+	commands []uint32
+	cursor   gridPoint
+}
+
+func (e *commandEncoder) command(id uint32, count int) {
+	e.commands = append(e.commands, id&0x7|uint32(count)<<3)
+}
+
+func (e *commandEncoder) point(p gridPoint) {
+	e.commands = append(e.commands, zigzag(p.x-e.cursor.x), zigzag(p.y-e.cursor.y))
+	e.cursor = p
+}
+
+func encodePoints(points []tilePoint) []uint32 {
+	rounded := make([]gridPoint, len(points))
+	for i, point := range points {
+		rounded[i] = gridPoint{x: int32(math.Round(point.x)), y: int32(math.Round(point.y))}
+	}
+
+	encoder := &commandEncoder{}
+	if len(rounded) > 0 {
+		encoder.command(command_MoveTo, len(rounded))
+		for _, point := range rounded {
+			encoder.point(point)
+		}
+	}
+	return encoder.commands
+}
+
+func encodeLines(lines [][]tilePoint) []uint32 {
+	encoder := &commandEncoder{}
+	for _, line := range lines {
+		rounded := roundPath(line)
+		if len(rounded) < 2 {
+			continue
+		}
+
+		encoder.command(command_MoveTo, 1)
+		encoder.point(rounded[0])
+		encoder.command(command_LineTo, len(rounded)-1)
+		for _, point := range rounded[1:] {
+			encoder.point(point)
+		}
+	}
+	return encoder.commands
+}
+
+// encodePolygons encodes each polygon as its exterior ring with a positive area followed by its interior rings with
+// negative areas, rewinding rings as needed. Rings that collapse when rounded are dropped, along with every ring of a
+// polygon whose exterior ring collapses.
+func encodePolygons(polygons [][][]tilePoint) []uint32 {
+	encoder := &commandEncoder{}
+	for _, polygon := range polygons {
+		for i, ring := range polygon {
+			rounded := roundPath(ring)
+			if len(rounded) > 1 && rounded[0] == rounded[len(rounded)-1] {
+				rounded = rounded[:len(rounded)-1]
+			}
+
+			area := gridRingArea(rounded)
+			if len(rounded) < 3 || area == 0 {
+				if i == 0 {
+					break
+				}
+				continue
+			}
+
+			if (i == 0) != (area > 0) {
+				slices.Reverse(rounded)
+			}
+
+			encoder.command(command_MoveTo, 1)
+			encoder.point(rounded[0])
+			encoder.command(command_LineTo, len(rounded)-1)
+			for _, point := range rounded[1:] {
+				encoder.point(point)
+			}
+			encoder.command(command_ClosePath, 1)
+		}
+	}
+	return encoder.commands
+}
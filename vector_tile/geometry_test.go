@@ -0,0 +1,219 @@
+package vector_tile
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+
+	"github.com/cmeyer18/weather-common/v6/data_structures/geojson_v2"
+)
+
+// decodeCommands reads geometry commands back into absolute positions, starting a new path at every MoveTo.
+func decodeCommands(t *testing.T, commands []uint32) [][]gridPoint {
+	t.Helper()
+
+	var paths [][]gridPoint
+	var cursor gridPoint
+	for i := 0; i < len(commands); {
+		id := commands[i] & 0x7
+		count := int(commands[i] >> 3)
+		i++
+
+		switch id {
+		case command_MoveTo, command_LineTo:
+			for j := 0; j < count; j++ {
+				if i+1 >= len(commands) {
+					t.Fatalf("commands end inside a parameter list: %v", commands)
+				}
+				cursor.x += unzigzag(commands[i])
+				cursor.y += unzigzag(commands[i+1])
+				i += 2
+
+				if id == command_MoveTo {
+					paths = append(paths, nil)
+				}
+				paths[len(paths)-1] = append(paths[len(paths)-1], cursor)
+			}
+		case command_ClosePath:
+			if count != 1 {
+				t.Fatalf("ClosePath with count %d", count)
+			}
+		default:
+			t.Fatalf("unknown command %d", id)
+		}
+	}
+	return paths
+}
+
+func unzigzag(value uint32) int32 {
+	return int32(value>>1) ^ -int32(value&1)
+}
+
+func TestZigzag(t *testing.T) {
+	for value, want := range map[int32]uint32{0: 0, -1: 1, 1: 2, -2: 3, 2: 4, 2147483647: 4294967294, -2147483648: 4294967295} {
+		if got := zigzag(value); got != want {
+			t.Errorf("zigzag(%d) = %d, want %d", value, got, want)
+		}
+		if got := unzigzag(zigzag(value)); got != value {
+			t.Errorf("unzigzag(zigzag(%d)) = %d", value, got)
+		}
+	}
+}
+
+// The expected commands are the examples from section 4.3.5 of the Mapbox Vector Tile specification.
+func TestEncodeCommands(t *testing.T) {
+	tests := []struct {
+		name   string
+		encode func() []uint32
+		want   []uint32
+	}{
+		{name: "point",
+			encode: func() []uint32 { return encodePoints([]tilePoint{{x: 25, y: 17}}) },
+			want:   []uint32{9, 50, 34}},
+		{name: "multi point",
+			encode: func() []uint32 { return encodePoints([]tilePoint{{x: 5, y: 7}, {x: 3, y: 2}}) },
+			want:   []uint32{17, 10, 14, 3, 9}},
+		{name: "line",
+			encode: func() []uint32 { return encodeLines([][]tilePoint{{{x: 2, y: 2}, {x: 2, y: 10}, {x: 10, y: 10}}}) },
+			want:   []uint32{9, 4, 4, 18, 0, 16, 16, 0}},
+		{name: "multi line",
+			encode: func() []uint32 {
+				return encodeLines([][]tilePoint{
+					{{x: 2, y: 2}, {x: 2, y: 10}, {x: 10, y: 10}},
+					{{x: 1, y: 1}, {x: 3, y: 5}},
+				})
+			},
+			want: []uint32{9, 4, 4, 18, 0, 16, 16, 0, 9, 17, 17, 10, 4, 8}},
+		{name: "polygon",
+			encode: func() []uint32 {
+				return encodePolygons([][][]tilePoint{{{{x: 3, y: 6}, {x: 8, y: 12}, {x: 20, y: 34}}}})
+			},
+			want: []uint32{9, 6, 12, 18, 10, 12, 24, 44, 15}},
+		{name: "multi polygon with hole",
+			encode: func() []uint32 {
+				return encodePolygons([][][]tilePoint{
+					{{{x: 0, y: 0}, {x: 10, y: 0}, {x: 10, y: 10}, {x: 0, y: 10}, {x: 0, y: 0}}},
+					{
+						{{x: 11, y: 11}, {x: 20, y: 11}, {x: 20, y: 20}, {x: 11, y: 20}},
+						{{x: 13, y: 13}, {x: 13, y: 17}, {x: 17, y: 17}, {x: 17, y: 13}},
+					},
+				})
+			},
+			want: []uint32{
+				9, 0, 0, 26, 20, 0, 0, 20, 19, 0, 15,
+				9, 22, 2, 26, 18, 0, 0, 18, 17, 0, 15,
+				9, 4, 13, 26, 0, 8, 8, 0, 0, 7, 15,
+			}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := test.encode(); !slices.Equal(got, test.want) {
+				t.Errorf("got %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestEncodePolygonsWinding(t *testing.T) {
+	// The exterior is counterclockwise on screen and the hole clockwise, the opposite of what the specification wants.
+	commands := encodePolygons([][][]tilePoint{{
+		{{x: 0, y: 0}, {x: 0, y: 10}, {x: 10, y: 10}, {x: 10, y: 0}},
+		{{x: 2, y: 2}, {x: 8, y: 2}, {x: 8, y: 8}, {x: 2, y: 8}},
+	}})
+
+	rings := decodeCommands(t, commands)
+	if len(rings) != 2 {
+		t.Fatalf("rings = %v", rings)
+	}
+	if gridRingArea(rings[0]) <= 0 {
+		t.Error("exterior ring does not have a positive area")
+	}
+	if gridRingArea(rings[1]) >= 0 {
+		t.Error("interior ring does not have a negative area")
+	}
+}
+
+func TestEncodePolygonsDropsCollapsedRings(t *testing.T) {
+	commands := encodePolygons([][][]tilePoint{
+		{{{x: 0, y: 0}, {x: 0.2, y: 0}, {x: 0.2, y: 0.2}}, {{x: 0, y: 0}, {x: 0, y: 10}, {x: 10, y: 10}}},
+		{{{x: 0, y: 0}, {x: 10, y: 0}, {x: 10, y: 10}}, {{x: 2, y: 2}, {x: 2.2, y: 2}, {x: 2.2, y: 2.2}}},
+	})
+
+	rings := decodeCommands(t, commands)
+	if len(rings) != 1 || len(rings[0]) != 3 {
+		t.Errorf("rings = %v, want only the exterior of the second polygon", rings)
+	}
+}
+
+func TestClipLine(t *testing.T) {
+	lines := clipLine([]tilePoint{{x: -1000, y: 100}, {x: 1000, y: 100}, {x: 1000, y: 5000}, {x: 1000, y: 6000}, {x: 2000, y: 100}})
+
+	want := [][]tilePoint{
+		{{x: -Buffer, y: 100}, {x: 1000, y: 100}, {x: 1000, y: Extent + Buffer}},
+		{{x: 1000 + 1000*(6000-Extent-Buffer)/5900.0, y: Extent + Buffer}, {x: 2000, y: 100}},
+	}
+	if len(lines) != len(want) {
+		t.Fatalf("lines = %v, want %v", lines, want)
+	}
+	for i := range want {
+		if len(lines[i]) != len(want[i]) {
+			t.Fatalf("line %d = %v, want %v", i, lines[i], want[i])
+		}
+		for j := range want[i] {
+			if roundPath([]tilePoint{lines[i][j]})[0] != roundPath([]tilePoint{want[i][j]})[0] {
+				t.Errorf("line %d position %d = %v, want %v", i, j, lines[i][j], want[i][j])
+			}
+		}
+	}
+}
+
+func TestClipRing(t *testing.T) {
+	ring := clipRing([]tilePoint{{x: -1000, y: -1000}, {x: 1000, y: -1000}, {x: 1000, y: 1000}, {x: -1000, y: 1000}, {x: -1000, y: -1000}})
+
+	want := []tilePoint{{x: -Buffer, y: -Buffer}, {x: 1000, y: -Buffer}, {x: 1000, y: 1000}, {x: -Buffer, y: 1000}}
+	rounded := roundPath(ring)
+	for _, point := range roundPath(want) {
+		if !slices.Contains(rounded, point) {
+			t.Errorf("clipped ring %v is missing %v", rounded, point)
+		}
+	}
+	if len(rounded) != len(want) {
+		t.Errorf("clipped ring = %v, want %v", rounded, want)
+	}
+
+	if outside := clipRing([]tilePoint{{x: -1000, y: -1000}, {x: -500, y: -1000}, {x: -500, y: -500}}); len(outside) != 0 {
+		t.Errorf("ring outside the tile = %v", outside)
+	}
+}
+
+func TestEncodeGeometry(t *testing.T) {
+	var geometry geojson_v2.Geometry
+	err := json.Unmarshal([]byte(`{"type":"GeometryCollection","geometries":[`+
+		`{"type":"Point","coordinates":[0,0]},`+
+		`{"type":"LineString","coordinates":[[-90,0],[90,0]]},`+
+		`{"type":"Polygon","coordinates":[[[-90,-45],[90,-45],[90,45],[-90,45],[-90,-45]]]}]}`), &geometry)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	encoded := encodeGeometry(TileID{}, &geometry)
+	if len(encoded) != 3 {
+		t.Fatalf("encoded = %+v, want one encoding per geometry type", encoded)
+	}
+
+	if encoded[0].geometryType != geometryType_Point || !slices.Equal(encoded[0].commands, []uint32{9, 4096, 4096}) {
+		t.Errorf("point = %+v", encoded[0])
+	}
+	if encoded[1].geometryType != geometryType_LineString || !slices.Equal(encoded[1].commands, []uint32{9, 2048, 4096, 10, 4096, 0}) {
+		t.Errorf("line = %+v", encoded[1])
+	}
+	if encoded[2].geometryType != geometryType_Polygon {
+		t.Errorf("polygon = %+v", encoded[2])
+	}
+
+	outside := encodeGeometry(TileID{Z: 2, X: 0, Y: 0}, &geojson_v2.Geometry{Point: &geojson_v2.Point{Longitude: 100, Latitude: -40}})
+	if len(outside) != 0 {
+		t.Errorf("point outside the tile = %+v", outside)
+	}
+}
@@ -0,0 +1,135 @@
+package vector_tile
+
+import (
+	"time"
+
+	"github.com/cmeyer18/weather-common/v6/data_structures"
+	"github.com/cmeyer18/weather-common/v6/spatial_index"
+)
+
+const (
+	AlertLayer               = "alerts"
+	ConvectiveOutlookLayer   = "convective_outlooks"
+	MesoscaleDiscussionLayer = "mesoscale_discussions"
+)
+
+// Render encodes a tile with a layer per product from items already in memory, such as the results of
+// spatial_index.Index.Intersecting for the bounds of the tile.
+func Render(id TileID, products spatial_index.Results) ([]byte, error) {
+	tile, err := NewTile(id)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tile.AddAlerts(products.Alerts)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tile.AddConvectiveOutlooks(products.ConvectiveOutlooks)
+	if err != nil {
+		return nil, err
+	}
+
+	err = tile.AddMesoscaleDiscussions(products.MesoscaleDiscussions)
+	if err != nil {
+		return nil, err
+	}
+
+	return tile.Marshal(), nil
+}
+
+// RenderFromTables encodes a tile with a layer per product from the active alerts, latest convective outlooks and
+// active mesoscale discussions reaching into the tile.
+func RenderFromTables(id TileID, tables spatial_index.Tables) ([]byte, error) {
+	err := id.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	bbox := id.bufferedBBox(Buffer)
+
+	var products spatial_index.Results
+	products.Alerts, err = tables.Alerts.SelectActiveByBBox(bbox)
+	if err != nil {
+		return nil, err
+	}
+
+	products.ConvectiveOutlooks, err = tables.ConvectiveOutlooks.SelectAllLatestByBBox(bbox)
+	if err != nil {
+		return nil, err
+	}
+
+	products.MesoscaleDiscussions, err = tables.MesoscaleDiscussions.SelectLatestByBBox(bbox)
+	if err != nil {
+		return nil, err
+	}
+
+	return Render(id, products)
+}
+
+// AddAlerts adds the alerts to the alerts layer with their event, severity, certainty, urgency, headline, expiry and
+// whether their geometry was derived from their UGC codes.
+func (t *Tile) AddAlerts(alerts []data_structures.AlertV2) error {
+	layer := t.Layer(AlertLayer)
+	for _, alert := range alerts {
+		err := layer.AddFeature(alert.Geometry, map[string]any{
+			"id":        alert.ID,
+			"event":     alert.Event,
+			"severity":  string(alert.Severity),
+			"certainty": string(alert.Certainty),
+			"urgency":   string(alert.Urgency),
+			"headline":  alert.Headline,
+			"expires":   alert.Expires.Format(time.RFC3339),
+			"derived":   alert.GeometryDerived,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AddConvectiveOutlooks adds the outlooks to the convective outlooks layer with their type, labels, DN and colors.
+func (t *Tile) AddConvectiveOutlooks(outlooks []data_structures.ConvectiveOutlookV2) error {
+	layer := t.Layer(ConvectiveOutlookLayer)
+	for _, outlook := range outlooks {
+		err := layer.AddFeature(outlook.Geometry, map[string]any{
+			"id":          outlook.ID,
+			"outlookType": string(outlook.OutlookType),
+			"label":       outlook.Label,
+			"label2":      outlook.Label2,
+			"dn":          outlook.DN,
+			"stroke":      outlook.Stroke,
+			"fill":        outlook.Fill,
+			"expires":     outlook.Expires.Format(time.RFC3339),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AddMesoscaleDiscussions adds the mesoscale discussions to the mesoscale discussions layer with their number, year,
+// probability of watch issuance and expiry.
+func (t *Tile) AddMesoscaleDiscussions(mds []data_structures.MesoscaleDiscussionV2) error {
+	layer := t.Layer(MesoscaleDiscussionLayer)
+	for _, md := range mds {
+		properties := map[string]any{
+			"id":                         md.ID,
+			"number":                     md.Number,
+			"year":                       md.Year,
+			"probabilityOfWatchIssuance": md.ProbabilityOfWatchIssuance,
+		}
+		if md.Expires != nil {
+			properties["expires"] = md.Expires.Format(time.RFC3339)
+		}
+
+		err := layer.AddFeature(md.Geometry, properties)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
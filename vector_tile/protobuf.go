@@ -0,0 +1,76 @@
+package vector_tile
+
+import "math"
+
+type wireType uint8
+
+const (
+	wireType_Varint  wireType = 0
+	wireType_Fixed64 wireType = 1
+	wireType_Bytes   wireType = 2
+)
+
+// protoBuffer appends protocol buffer fields. Only the parts of the wire format used by the vector tile schema are
+// supported.
+type protoBuffer []byte
+
+func (b *protoBuffer) varint(value uint64) {
+	for value >= 0x80 {
+		*b = append(*b, byte(value)|0x80)
+		value >>= 7
+	}
+	*b = append(*b, byte(value))
+}
+
+func (b *protoBuffer) tag(field uint32, wire wireType) {
+	b.varint(uint64(field)<<3 | uint64(wire))
+}
+
+func (b *protoBuffer) uintField(field uint32, value uint64) {
+	b.tag(field, wireType_Varint)
+	b.varint(value)
+}
+
+func (b *protoBuffer) sintField(field uint32, value int64) {
+	b.tag(field, wireType_Varint)
+	b.varint(uint64(value<<1) ^ uint64(value>>63))
+}
+
+func (b *protoBuffer) boolField(field uint32, value bool) {
+	if value {
+		b.uintField(field, 1)
+	} else {
+		b.uintField(field, 0)
+	}
+}
+
+func (b *protoBuffer) doubleField(field uint32, value float64) {
+	b.tag(field, wireType_Fixed64)
+	bits := math.Float64bits(value)
+	for i := 0; i < 8; i++ {
+		*b = append(*b, byte(bits>>(8*i)))
+	}
+}
+
+func (b *protoBuffer) bytesField(field uint32, value []byte) {
+	b.tag(field, wireType_Bytes)
+	b.varint(uint64(len(value)))
+	*b = append(*b, value...)
+}
+
+func (b *protoBuffer) stringField(field uint32, value string) {
+	b.bytesField(field, []byte(value))
+}
+
+func (b *protoBuffer) packedField(field uint32, values []uint32) {
+	var packed protoBuffer
+	for _, value := range values {
+		packed.varint(uint64(value))
+	}
+	b.bytesField(field, packed)
+}
+
+// zigzag encodes a signed parameter integer of a geometry command.
+func zigzag(value int32) uint32 {
+	return uint32(value<<1) ^ uint32(value>>31)
+}
@@ -0,0 +1,205 @@
+package vector_tile
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/cmeyer18/weather-common/v6/data_structures/geojson_v2"
+)
+
+// Extent is the number of integer coordinates across each edge of a tile.
+const Extent = 4096
+
+// Buffer is the number of tile coordinates that geometries are kept beyond each edge of a tile, so that lines and
+// polygon edges meet without seams when neighboring tiles are drawn.
+const Buffer = 64
+
+// layerVersion is the version of the Mapbox Vector Tile specification the tiles are encoded with.
+const layerVersion = 2
+
+// Tile is a Mapbox Vector Tile being built from GeoJSON geometries.
+type Tile struct {
+	id     TileID
+	bbox   geojson_v2.BBox
+	layers []*Layer
+}
+
+// Layer is a named set of features within a tile, sharing one table of property keys and values.
+type Layer struct {
+	tile         *Tile
+	name         string
+	features     []feature
+	keys         []string
+	keyIndexes   map[string]uint32
+	values       []any
+	valueIndexes map[any]uint32
+}
+
+type feature struct {
+	tags     []uint32
+	geometry encodedGeometry
+}
+
+// NewTile returns an empty tile, or an error when the tile does not exist.
+func NewTile(id TileID) (*Tile, error) {
+	err := id.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Tile{id: id, bbox: id.bufferedBBox(Buffer)}, nil
+}
+
+// Layer returns the layer with the name, adding it to the tile when it does not exist yet.
+func (t *Tile) Layer(name string) *Layer {
+	for _, layer := range t.layers {
+		if layer.name == name {
+			return layer
+		}
+	}
+
+	layer := &Layer{
+		tile:         t,
+		name:         name,
+		keyIndexes:   make(map[string]uint32),
+		valueIndexes: make(map[any]uint32),
+	}
+	t.layers = append(t.layers, layer)
+	return layer
+}
+
+// Marshal encodes the tile as a protocol buffer. Layers without any features are left out.
+func (t *Tile) Marshal() []byte {
+	var tile protoBuffer
+	for _, layer := range t.layers {
+		if len(layer.features) > 0 {
+			tile.bytesField(3, layer.marshal())
+		}
+	}
+	return tile
+}
+
+// AddFeature clips the geometry to the tile and adds it with the properties. Properties may be strings, booleans,
+// integers or floats; nil properties are left out. Nothing is added when the geometry does not reach into the tile.
+// A geometry collection mixing geometry types is added as one feature per type.
+func (l *Layer) AddFeature(geometry *geojson_v2.Geometry, properties map[string]any) error {
+	bbox := geometry.BBox()
+	if bbox == nil || !bbox.Intersects(l.tile.bbox) {
+		return nil
+	}
+
+	encoded := encodeGeometry(l.tile.id, geometry)
+	if len(encoded) == 0 {
+		return nil
+	}
+
+	keys := make([]string, 0, len(properties))
+	for key := range properties {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+
+	var tags []uint32
+	for _, key := range keys {
+		value, err := propertyValue(properties[key])
+		if err != nil {
+			return err
+		} else if value == nil {
+			continue
+		}
+
+		tags = append(tags, l.keyIndex(key), l.valueIndex(value))
+	}
+
+	for _, geometry := range encoded {
+		l.features = append(l.features, feature{tags: tags, geometry: geometry})
+	}
+	return nil
+}
+
+func (l *Layer) keyIndex(key string) uint32 {
+	index, ok := l.keyIndexes[key]
+	if !ok {
+		index = uint32(len(l.keys))
+		l.keyIndexes[key] = index
+		l.keys = append(l.keys, key)
+	}
+	return index
+}
+
+func (l *Layer) valueIndex(value any) uint32 {
+	index, ok := l.valueIndexes[value]
+	if !ok {
+		index = uint32(len(l.values))
+		l.valueIndexes[value] = index
+		l.values = append(l.values, value)
+	}
+	return index
+}
+
+func (l *Layer) marshal() []byte {
+	var layer protoBuffer
+	layer.uintField(15, layerVersion)
+	layer.stringField(1, l.name)
+
+	for _, feature := range l.features {
+		var encoded protoBuffer
+		if len(feature.tags) > 0 {
+			encoded.packedField(2, feature.tags)
+		}
+		encoded.uintField(3, uint64(feature.geometry.geometryType))
+		encoded.packedField(4, feature.geometry.commands)
+		layer.bytesField(2, encoded)
+	}
+
+	for _, key := range l.keys {
+		layer.stringField(3, key)
+	}
+
+	for _, value := range l.values {
+		var encoded protoBuffer
+		switch value := value.(type) {
+		case string:
+			encoded.stringField(1, value)
+		case float64:
+			encoded.doubleField(3, value)
+		case int64:
+			encoded.sintField(6, value)
+		case uint64:
+			encoded.uintField(5, value)
+		case bool:
+			encoded.boolField(7, value)
+		}
+		layer.bytesField(4, encoded)
+	}
+
+	layer.uintField(5, Extent)
+	return layer
+}
+
+// propertyValue converts a property to the type it is encoded as, or returns nil when the property is nil.
+func propertyValue(value any) (any, error) {
+	switch value := value.(type) {
+	case nil:
+		return nil, nil
+	case string, bool, float64, int64, uint64:
+		return value, nil
+	case float32:
+		return float64(value), nil
+	case int:
+		return int64(value), nil
+	case int32:
+		return int64(value), nil
+	case uint:
+		return uint64(value), nil
+	case uint32:
+		return uint64(value), nil
+	case *int:
+		if value == nil {
+			return nil, nil
+		}
+		return int64(*value), nil
+	default:
+		return nil, fmt.Errorf("unsupported property type: %T", value)
+	}
+}
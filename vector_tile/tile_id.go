@@ -0,0 +1,77 @@
+package vector_tile
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/cmeyer18/weather-common/v6/data_structures/geojson_v2"
+)
+
+// maxMercatorLatitude is the latitude in degrees at which the Web Mercator projection is cut off to make the world square.
+const maxMercatorLatitude = 85.0511287798066
+
+// TileID names a tile of the Web Mercator tiling scheme, with x increasing to the east and y increasing to the south.
+type TileID struct {
+	Z uint32
+	X uint32
+	Y uint32
+}
+
+// Validate returns an error when the tile does not exist at its zoom level.
+func (t TileID) Validate() error {
+	if t.Z > 30 {
+		return fmt.Errorf("invalid tile zoom: %d", t.Z)
+	}
+
+	if t.X >= 1<<t.Z || t.Y >= 1<<t.Z {
+		return fmt.Errorf("invalid tile: %d/%d/%d", t.Z, t.X, t.Y)
+	}
+
+	return nil
+}
+
+// BBox returns the bounds of the tile in degrees.
+func (t TileID) BBox() geojson_v2.BBox {
+	return t.bufferedBBox(0)
+}
+
+// bufferedBBox returns the bounds of the tile grown on every side by the buffer in tile coordinates. Bounds reaching past
+// the antimeridian wrap around it.
+func (t TileID) bufferedBBox(buffer float64) geojson_v2.BBox {
+	n := float64(uint64(1) << t.Z)
+	margin := buffer / Extent
+
+	west := (float64(t.X)-margin)/n*360 - 180
+	east := (float64(t.X)+1+margin)/n*360 - 180
+	if east-west >= 360 {
+		west, east = -180, 180
+	} else if west < -180 {
+		west += 360
+	} else if east > 180 {
+		east -= 360
+	}
+
+	return geojson_v2.BBox{
+		MinLongitude: west,
+		MinLatitude:  mercatorLatitude((float64(t.Y) + 1 + margin) / n),
+		MaxLongitude: east,
+		MaxLatitude:  mercatorLatitude((float64(t.Y) - margin) / n),
+	}
+}
+
+// project returns the position in the coordinates of the tile, where the tile spans 0 to Extent on each axis.
+func (t TileID) project(point geojson_v2.Point) (float64, float64) {
+	n := float64(uint64(1) << t.Z)
+	latitude := math.Max(-maxMercatorLatitude, math.Min(maxMercatorLatitude, point.Latitude))
+	sin := math.Sin(latitude * math.Pi / 180)
+
+	x := (point.Longitude + 180) / 360 * n
+	y := (0.5 - math.Log((1+sin)/(1-sin))/(4*math.Pi)) * n
+	return (x - float64(t.X)) * Extent, (y - float64(t.Y)) * Extent
+}
+
+// mercatorLatitude returns the latitude in degrees at the fraction of the height of the world from its northern edge.
+func mercatorLatitude(fraction float64) float64 {
+	fraction = math.Max(0, math.Min(1, fraction))
+	return math.Atan(math.Sinh(math.Pi*(1-2*fraction))) * 180 / math.Pi
+}
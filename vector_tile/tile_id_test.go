@@ -0,0 +1,69 @@
+package vector_tile
+
+import (
+	"math"
+	"testing"
+
+	"github.com/cmeyer18/weather-common/v6/data_structures/geojson_v2"
+)
+
+func TestTileIDValidate(t *testing.T) {
+	for _, id := range []TileID{{}, {Z: 1, X: 1, Y: 1}, {Z: 30, X: 1<<30 - 1, Y: 0}} {
+		if err := id.Validate(); err != nil {
+			t.Errorf("%+v: %v", id, err)
+		}
+	}
+	for _, id := range []TileID{{Z: 0, X: 1}, {Z: 1, Y: 2}, {Z: 31}} {
+		if err := id.Validate(); err == nil {
+			t.Errorf("%+v: expected an error", id)
+		}
+	}
+}
+
+func TestTileIDBBox(t *testing.T) {
+	tests := []struct {
+		id   TileID
+		want geojson_v2.BBox
+	}{
+		{id: TileID{}, want: geojson_v2.BBox{MinLongitude: -180, MinLatitude: -maxMercatorLatitude, MaxLongitude: 180, MaxLatitude: maxMercatorLatitude}},
+		{id: TileID{Z: 1, X: 1, Y: 0}, want: geojson_v2.BBox{MinLongitude: 0, MinLatitude: 0, MaxLongitude: 180, MaxLatitude: maxMercatorLatitude}},
+		{id: TileID{Z: 1, X: 0, Y: 1}, want: geojson_v2.BBox{MinLongitude: -180, MinLatitude: -maxMercatorLatitude, MaxLongitude: 0, MaxLatitude: 0}},
+	}
+
+	for _, test := range tests {
+		bbox := test.id.BBox()
+		if math.Abs(bbox.MinLongitude-test.want.MinLongitude) > 1e-9 || math.Abs(bbox.MinLatitude-test.want.MinLatitude) > 1e-9 ||
+			math.Abs(bbox.MaxLongitude-test.want.MaxLongitude) > 1e-9 || math.Abs(bbox.MaxLatitude-test.want.MaxLatitude) > 1e-9 {
+			t.Errorf("%+v: bbox = %+v, want %+v", test.id, bbox, test.want)
+		}
+	}
+
+	// The buffer of the eastern tile reaches across the antimeridian.
+	buffered := TileID{Z: 1, X: 1, Y: 0}.bufferedBBox(Buffer)
+	if !buffered.CrossesAntimeridian() || math.Abs(buffered.MaxLongitude+180-360.0*Buffer/Extent/2) > 1e-9 {
+		t.Errorf("buffered bbox = %+v, want it to wrap past the antimeridian", buffered)
+	}
+	if world := (TileID{}).bufferedBBox(Buffer); world.MinLongitude != -180 || world.MaxLongitude != 180 {
+		t.Errorf("buffered world bbox = %+v", world)
+	}
+}
+
+func TestTileIDProject(t *testing.T) {
+	tests := []struct {
+		id    TileID
+		point geojson_v2.Point
+		x, y  float64
+	}{
+		{id: TileID{}, point: geojson_v2.Point{}, x: Extent / 2, y: Extent / 2},
+		{id: TileID{}, point: geojson_v2.Point{Longitude: -180, Latitude: maxMercatorLatitude}, x: 0, y: 0},
+		{id: TileID{}, point: geojson_v2.Point{Longitude: 180, Latitude: -90}, x: Extent, y: Extent},
+		{id: TileID{Z: 1, X: 1, Y: 1}, point: geojson_v2.Point{}, x: 0, y: 0},
+	}
+
+	for _, test := range tests {
+		x, y := test.id.project(test.point)
+		if math.Abs(x-test.x) > 1e-6 || math.Abs(y-test.y) > 1e-6 {
+			t.Errorf("%+v: project(%+v) = (%v, %v), want (%v, %v)", test.id, test.point, x, y, test.x, test.y)
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package vector_tile
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/cmeyer18/weather-common/v6/data_structures/geojson_v2"
+)
+
+func TestProtoBuffer(t *testing.T) {
+	var buffer protoBuffer
+	buffer.uintField(1, 300)
+	buffer.sintField(2, -2)
+	buffer.stringField(3, "hi")
+	buffer.packedField(4, []uint32{9, 50, 34})
+	buffer.boolField(5, true)
+
+	want := []byte{0x08, 0xac, 0x02, 0x10, 0x03, 0x1a, 0x02, 'h', 'i', 0x22, 0x03, 9, 50, 34, 0x28, 0x01}
+	if !bytes.Equal(buffer, want) {
+		t.Errorf("got % x, want % x", []byte(buffer), want)
+	}
+}
+
+func TestTile(t *testing.T) {
+	tile, err := NewTile(TileID{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	point := &geojson_v2.Geometry{Point: &geojson_v2.Point{}}
+	layer := tile.Layer("points")
+	for _, properties := range []map[string]any{
+		{"name": "first", "count": 1, "missing": nil},
+		{"name": "second", "count": int64(1)},
+	} {
+		err := layer.AddFeature(point, properties)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if tile.Layer("points") != layer {
+		t.Error("Layer did not return the existing layer")
+	}
+	if len(layer.features) != 2 || len(layer.keys) != 2 || len(layer.values) != 3 {
+		t.Errorf("layer has %d features, keys %v and values %v", len(layer.features), layer.keys, layer.values)
+	}
+
+	err = layer.AddFeature(point, map[string]any{"bad": []string{"a"}})
+	if err == nil {
+		t.Error("expected an error for an unsupported property type")
+	}
+
+	tile.Layer("empty")
+	encoded := tile.Marshal()
+
+	// Field 3 of the tile, holding a layer, and nothing for the empty layer.
+	if len(encoded) == 0 || encoded[0] != 0x1a || bytes.Contains(encoded, []byte("empty")) {
+		t.Errorf("tile = % x", encoded)
+	}
+	if !bytes.Contains(encoded, []byte("points")) || !bytes.Contains(encoded, []byte("second")) {
+		t.Errorf("tile is missing the layer name or values: % x", encoded)
+	}
+
+	if _, err := NewTile(TileID{Z: 1, X: 2}); err == nil {
+		t.Error("expected an error for a tile that does not exist")
+	}
+}
+
+func TestAddFeatureOutsideTile(t *testing.T) {
+	tile, err := NewTile(TileID{Z: 4, X: 3, Y: 6})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	layer := tile.Layer("points")
+	err = layer.AddFeature(&geojson_v2.Geometry{Point: &geojson_v2.Point{Longitude: 100, Latitude: -40}}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(layer.features) != 0 {
+		t.Errorf("layer has %d features", len(layer.features))
+	}
+	if encoded := tile.Marshal(); len(encoded) != 0 {
+		t.Errorf("tile without features = % x", encoded)
+	}
+}